@@ -17,11 +17,13 @@ var badgeCmd = flag.NewFlagSet("badge", flag.ExitOnError)
 var (
 	badgeFromFile = badgeCmd.String("from-file", "", "Path to stats JSON file")
 	badgeData     = badgeCmd.String("data", "", "Stats as JSON string")
+	badgeFormat   = badgeCmd.String("format", formatSVG, "Output format: svg, shields (shields writes one endpoint file per metric into --badge-output dir)")
 )
 
 func init() {
 	badgeCmd.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats badge [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats badge [options]\n")
+		fmt.Fprintf(os.Stderr, "       gh-oss-stats badge validate-theme [options]\n\n")
 		fmt.Fprintf(os.Stderr, "Generate badge from existing stats JSON.\n\n")
 		fmt.Fprintf(os.Stderr, "This command allows you to generate badges without re-fetching data from GitHub,\n")
 		fmt.Fprintf(os.Stderr, "which is useful for creating multiple badge variants from the same stats.\n\n")
@@ -32,17 +34,36 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --from-file stats.json --badge-style summary\n\n")
 		fmt.Fprintf(os.Stderr, "  # Generate badge from JSON string\n")
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --data '{\"username\":\"...\",...}' --badge-style compact\n\n")
+		fmt.Fprintf(os.Stderr, "  # Render every style x variant x theme into ./badges with a README.md gallery\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --from-file stats.json --matrix --badge-output badges\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate shields.io endpoint JSON files into ./shields\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --from-file stats.json --format shields --badge-output shields\n\n")
+		fmt.Fprintf(os.Stderr, "  # Check theme contrast against WCAG AA\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge validate-theme --theme nord\n\n")
 	}
 }
 
 func runBadgeCmd(args []string) {
+	if len(args) > 0 && args[0] == "validate-theme" {
+		runValidateThemeCmd(args[1:])
+		return
+	}
+
 	badgeConfig := newBadgeConfig()
 	badgeConfig.registerBadgeFlags(badgeCmd)
+	matrixConfig := &MatrixConfig{}
+	matrixConfig.registerMatrixFlags(badgeCmd)
+	loadConfig(badgeCmd, args)
 	badgeCmd.Parse(args)
 
 	*badgeFromFile = strings.TrimSpace(*badgeFromFile)
 	*badgeData = strings.TrimSpace(*badgeData)
 
+	if *badgeFormat != formatSVG && *badgeFormat != formatShields {
+		fmt.Fprintf(os.Stderr, "Error: --format must be svg or shields (got: %s)\n", *badgeFormat)
+		os.Exit(1)
+	}
+
 	if *badgeFromFile == "" && *badgeData == "" {
 		fmt.Fprintln(os.Stderr, "Error: badgeFromFile or data has to be provided")
 		os.Exit(1)
@@ -55,18 +76,18 @@ func runBadgeCmd(args []string) {
 			os.Exit(1)
 		}
 
-		err = generateBadgeFromJSONString(string(content), *badgeConfig)
+		err = generateBadgeFromJSONString(string(content), *badgeConfig, *matrixConfig)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, "Error: failed to parse json data")
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 		os.Exit(0)
 	}
 
 	if *badgeData != "" {
-		err := generateBadgeFromJSONString(*badgeData, *badgeConfig)
+		err := generateBadgeFromJSONString(*badgeData, *badgeConfig, *matrixConfig)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, "Error: failed to parse json data")
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 		os.Exit(0)
@@ -74,11 +95,11 @@ func runBadgeCmd(args []string) {
 
 }
 
-func generateBadgeFromJSONString(statsJSON string, badgeConfig BadgeConfig) error {
+func generateBadgeFromJSONString(statsJSON string, badgeConfig BadgeConfig, matrixConfig MatrixConfig) error {
 	var stats ossstats.Stats
 	err := json.Unmarshal([]byte(statsJSON), &stats)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to parse json data: %w", err)
 	}
 
 	badgeOption, err := createBadgeOptions(badgeConfig)
@@ -86,5 +107,13 @@ func generateBadgeFromJSONString(statsJSON string, badgeConfig BadgeConfig) erro
 		return err
 	}
 
-	return writeBadge(badgeOption, badgeConfig.output, verbose, &stats)
+	if *badgeFormat == formatShields {
+		return writeShieldsEndpoints(badgeOption, badgeConfig.output, verbose, &stats)
+	}
+
+	if matrixConfig.enabled {
+		return writeBadgeMatrix(badgeOption, badgeConfig, matrixConfig, verbose, &stats)
+	}
+
+	return writeBadge(badgeOption, badgeConfig, verbose, &stats)
 }
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateBadgeFromJSONStringErrors(t *testing.T) {
+	valid := `{"username":"octocat"}`
+
+	toTempDir := *newBadgeConfig()
+	toTempDir.output = t.TempDir()
+
+	tests := []struct {
+		name     string
+		json     string
+		badge    BadgeConfig
+		matrix   MatrixConfig
+		wantErr  string
+		wantJSON bool // Whether the error blames the JSON data
+	}{
+		{"invalid json", "{", *newBadgeConfig(), MatrixConfig{}, "failed to parse json data", true},
+		{"invalid matrix", valid, toTempDir, MatrixConfig{enabled: true, styles: "huge"}, "huge", false},
+		{"invalid matrix index", valid, toTempDir, MatrixConfig{enabled: true, index: "gallery.txt"}, "gallery.txt", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := generateBadgeFromJSONString(tt.json, tt.badge, tt.matrix)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+			if blamesJSON := strings.Contains(err.Error(), "json"); blamesJSON != tt.wantJSON {
+				t.Errorf("error = %v, blames the JSON data: %v, want %v", err, blamesJSON, tt.wantJSON)
+			}
+		})
+	}
+}
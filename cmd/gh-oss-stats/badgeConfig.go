@@ -13,6 +13,13 @@ type BadgeConfig struct {
 	output  string
 	sort    string
 	limit   int
+	locale  string
+
+	avatars     bool
+	avatarCache string
+	offline     bool
+
+	acceptanceRate bool
 }
 
 // newBadgeConfig creates a new BadgeConfig with default values
@@ -24,6 +31,9 @@ func newBadgeConfig() *BadgeConfig {
 		output:  "",
 		sort:    string(badge.DefaultSortBy),
 		limit:   badge.DefaultPRsLimit,
+		locale:  string(badge.DefaultBadgeLocale),
+
+		avatarCache: badge.DefaultAvatarCacheDir(),
 	}
 }
 
@@ -34,4 +44,9 @@ func (bf *BadgeConfig) registerBadgeFlags(fs *flag.FlagSet) {
 	fs.StringVar(&bf.output, "badge-output", "", "Badge output file (default: badge.svg)")
 	fs.StringVar(&bf.sort, "badge-sort", string(badge.DefaultSortBy), "Sort contributions by: prs, stars, commits")
 	fs.IntVar(&bf.limit, "badge-limit", badge.DefaultPRsLimit, "Number of contributions to show")
+	fs.StringVar(&bf.locale, "badge-locale", string(badge.DefaultBadgeLocale), "Badge language: en, de, fr, es, ar, he")
+	fs.BoolVar(&bf.avatars, "badge-avatars", false, "Show repo owner avatars in detailed badge")
+	fs.StringVar(&bf.avatarCache, "badge-avatar-cache", badge.DefaultAvatarCacheDir(), "Directory to cache downloaded avatars")
+	fs.BoolVar(&bf.offline, "badge-offline", false, "Never download avatars; use cached ones or initials")
+	fs.BoolVar(&bf.acceptanceRate, "badge-acceptance-rate", false, "Show the PR acceptance rate in detailed badge (needs --include-pr-states)")
 }
@@ -29,6 +29,7 @@ func TestNewBadgeConfig(t *testing.T) {
 		{"output default", func() interface{} { return fs.Lookup("badge-output").DefValue }, ""},
 		{"sort default", func() interface{} { return fs.Lookup("badge-sort").DefValue }, string(badge.DefaultSortBy)},
 		{"limit default", func() interface{} { return fs.Lookup("badge-limit").DefValue }, "5"}, // Default as string
+		{"locale default", func() interface{} { return fs.Lookup("badge-locale").DefValue }, string(badge.DefaultBadgeLocale)},
 	}
 
 	for _, tt := range tests {
@@ -59,6 +60,10 @@ func TestBadgeConfigRegisterFlags(t *testing.T) {
 		{"badge-output flag", "badge-output", true},
 		{"badge-sort flag", "badge-sort", true},
 		{"badge-limit flag", "badge-limit", true},
+		{"badge-locale flag", "badge-locale", true},
+		{"badge-avatars flag", "badge-avatars", true},
+		{"badge-avatar-cache flag", "badge-avatar-cache", true},
+		{"badge-offline flag", "badge-offline", true},
 	}
 
 	for _, tt := range tests {
@@ -85,6 +90,7 @@ func TestBadgeConfigFlagValues(t *testing.T) {
 		"--badge-output", "test-badge.svg",
 		"--badge-sort", "stars",
 		"--badge-limit", "10",
+		"--badge-locale", "ar",
 	}
 
 	if err := fs.Parse(args); err != nil {
@@ -103,6 +109,7 @@ func TestBadgeConfigFlagValues(t *testing.T) {
 		{"output", "badge-output", "test-badge.svg"},
 		{"sort", "badge-sort", "stars"},
 		{"limit", "badge-limit", "10"},
+		{"locale", "badge-locale", "ar"},
 	}
 
 	for _, tt := range tests {
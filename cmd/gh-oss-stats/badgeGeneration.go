@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
@@ -33,21 +35,35 @@ func createBadgeOptions(conf BadgeConfig) (badge.BadgeOptions, error) {
 		os.Exit(1)
 	}
 
+	badgeLocale, err := badge.BadgeLocaleFromName(conf.locale)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
 	return badge.BadgeOptions{
 		Style:   badgeStyle,
 		Variant: badgeVariant,
 		Theme:   badgeTheme,
 		SortBy:  badgeSortBy,
 		Limit:   conf.limit,
+		Locale:  badgeLocale,
+
+		ShowAvatars:        conf.avatars,
+		ShowAcceptanceRate: conf.acceptanceRate,
 	}, nil
 }
 
 func writeBadge(
 	opts badge.BadgeOptions,
-	output string,
+	conf BadgeConfig,
 	verbose *bool,
 	stats *ossstats.Stats,
 ) error {
+	if opts.ShowAvatars && opts.Style == badge.StyleDetailed {
+		opts.Avatars = fetchAvatars(opts, conf, verbose, stats)
+	}
+
 	// Generate SVG
 	svg, err := badge.RenderSVG(stats, opts)
 	if err != nil {
@@ -55,7 +71,7 @@ func writeBadge(
 	}
 
 	// Determine output file
-	outputFile := output
+	outputFile := conf.output
 	if outputFile == "" {
 		outputFile = "badge.svg"
 	}
@@ -71,3 +87,30 @@ func writeBadge(
 
 	return nil
 }
+
+// avatarsTimeout bounds downloading all avatars of one badge
+const avatarsTimeout = 30 * time.Second
+
+// fetchAvatars downloads (or loads from cache) the avatars of the owners shown
+// on the badge. Failures only produce warnings: those owners fall back to initials.
+func fetchAvatars(
+	opts badge.BadgeOptions,
+	conf BadgeConfig,
+	verbose *bool,
+	stats *ossstats.Stats,
+) map[string]string {
+	fetcher := badge.NewAvatarFetcher(conf.avatarCache)
+	fetcher.Offline = conf.offline
+
+	ctx, cancel := context.WithTimeout(context.Background(), avatarsTimeout)
+	defer cancel()
+
+	avatars, errs := fetcher.Fetch(ctx, badge.TopOwners(stats, opts))
+	if *verbose {
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: %v (using initials)\n", err)
+		}
+	}
+
+	return avatars
+}
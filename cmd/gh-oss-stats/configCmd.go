@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configCmd flag sets
+var (
+	configInitCmd = flag.NewFlagSet("config init", flag.ExitOnError)
+	configShowCmd = flag.NewFlagSet("config show", flag.ExitOnError)
+)
+
+// Config init flags
+var (
+	configInitPath  = configInitCmd.String("path", configFileName+".yaml", "File to write (.yaml, .yml or .toml)")
+	configInitForce = configInitCmd.Bool("force", false, "Overwrite an existing file")
+)
+
+const starterYAML = `# gh-oss-stats configuration
+#
+# Keys are the long flag names. Precedence: flag > env (GH_OSS_STATS_<KEY>) > this file > default.
+# Select a profile with --profile <name> or GH_OSS_STATS_PROFILE.
+
+user: your-github-username
+# token is read from $GITHUB_TOKEN; avoid committing it here
+
+min-stars: 0
+max-prs: 500
+exclude-orgs: []
+include-loc: false
+timeout: 300
+
+badge-style: summary       # summary, compact, detailed
+badge-variant: default     # default, text-based
+badge-theme: dark          # dark, light, nord, dracula, gruvbox-dark, gruvbox-light
+badge-sort: prs            # prs, stars, commits
+badge-limit: 5
+badge-locale: en           # en, de, fr, es, ar, he
+
+profiles:
+  work:
+    exclude-orgs: [my-company]
+    badge-style: detailed
+    badge-theme: light
+`
+
+const starterTOML = `# gh-oss-stats configuration
+#
+# Keys are the long flag names. Precedence: flag > env (GH_OSS_STATS_<KEY>) > this file > default.
+# Select a profile with --profile <name> or GH_OSS_STATS_PROFILE.
+
+user = "your-github-username"
+# token is read from $GITHUB_TOKEN; avoid committing it here
+
+min-stars = 0
+max-prs = 500
+exclude-orgs = []
+include-loc = false
+timeout = 300
+
+badge-style = "summary"    # summary, compact, detailed
+badge-variant = "default"  # default, text-based
+badge-theme = "dark"       # dark, light, nord, dracula, gruvbox-dark, gruvbox-light
+badge-sort = "prs"         # prs, stars, commits
+badge-limit = 5
+badge-locale = "en"        # en, de, fr, es, ar, he
+
+[profiles.work]
+exclude-orgs = ["my-company"]
+badge-style = "detailed"
+badge-theme = "light"
+`
+
+func init() {
+	configInitCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats config init [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Write a starter config file.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		configInitCmd.PrintDefaults()
+	}
+	configShowCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats config show [options] [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Print the resolved settings and where each one comes from.\n")
+		fmt.Fprintf(os.Stderr, "Any command flag may be passed to see how it combines with the file.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -config string\n    \tConfig file to read\n")
+		fmt.Fprintf(os.Stderr, "  -profile string\n    \tConfig file profile to use\n")
+	}
+}
+
+func printConfigUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats config <init|show> [options]\n\n")
+	fmt.Fprintf(os.Stderr, "Manage the config file (.gh-oss-stats.yaml, .yml or .toml in the current\n")
+	fmt.Fprintf(os.Stderr, "directory, or config.* in $XDG_CONFIG_HOME/gh-oss-stats).\n\n")
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	fmt.Fprintf(os.Stderr, "  init    Write a starter config file\n")
+	fmt.Fprintf(os.Stderr, "  show    Print the resolved settings and their sources\n")
+}
+
+func runConfigCmd(args []string) {
+	if len(args) == 0 {
+		printConfigUsage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "init":
+		runConfigInitCmd(args[1:])
+	case "show":
+		runConfigShowCmd(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown config command: %s\n\n", args[0])
+		printConfigUsage()
+		os.Exit(1)
+	}
+}
+
+func runConfigInitCmd(args []string) {
+	configInitCmd.Parse(args)
+
+	var content string
+	switch strings.ToLower(filepath.Ext(*configInitPath)) {
+	case ".yaml", ".yml":
+		content = starterYAML
+	case ".toml":
+		content = starterTOML
+	default:
+		fmt.Fprintf(os.Stderr, "Error: --path must end in .yaml, .yml or .toml (got: %s)\n", *configInitPath)
+		os.Exit(1)
+	}
+
+	if _, err := os.Stat(*configInitPath); err == nil && !*configInitForce {
+		fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", *configInitPath)
+		os.Exit(1)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if dir := filepath.Dir(*configInitPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := os.WriteFile(*configInitPath, []byte(content), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "Config written to %s\n", *configInitPath)
+}
+
+func runConfigShowCmd(args []string) {
+	// Collect every configurable flag: main command, badge, filters and serve
+	fs := configShowCmd
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	newBadgeConfig().registerBadgeFlags(fs)
+	badgeFormatFlag := badgeCmd.Lookup("format")
+	fs.Var(badgeFormatFlag.Value, "badge-format", badgeFormatFlag.Usage)
+	(&FilterConfig{}).registerFilterFlags(fs)
+	(&QualityConfig{}).registerQualityFlags(fs)
+	serveCmd.VisitAll(func(f *flag.Flag) {
+		if fs.Lookup(f.Name) == nil {
+			fs.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	registerConfigFlags(fs)
+
+	fc, sources, err := applyConfig(fs, args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fs.Parse(args)
+
+	// fs.Visit would also report the values set from the file and env
+	for _, key := range configKeys {
+		if flagInArgs(args, key) {
+			sources[key] = sourceFlag
+		}
+	}
+
+	if fc != nil {
+		fmt.Printf("# config file: %s\n", fc.Path)
+	} else {
+		fmt.Printf("# config file: none\n")
+	}
+	if profile := selectedProfile(args); profile != "" {
+		fmt.Printf("# profile: %s\n", profile)
+	}
+
+	for _, key := range configKeys {
+		f := fs.Lookup(key)
+		if f == nil {
+			continue
+		}
+
+		value := f.Value.String()
+		if key == "token" && value != "" {
+			value = "********"
+		}
+
+		source := sources[key]
+		if source == "" {
+			source = sourceDefault
+		}
+
+		fmt.Printf("%s: %q # %s\n", key, value, source)
+	}
+}
@@ -27,12 +27,17 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats demo --badge-style summary --badge-theme dark\n\n")
 		fmt.Fprintf(os.Stderr, "  # Generate compact demo badge\n")
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats demo --badge-style compact --badge-output demo.svg\n\n")
+		fmt.Fprintf(os.Stderr, "  # Regenerate all demo badges with an HTML gallery\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats demo --matrix --badge-output docs/badges --matrix-index index.html\n\n")
 	}
 }
 
 func runDemoCmd(args []string) {
 	badgeConfig := newBadgeConfig()
 	badgeConfig.registerBadgeFlags(demoCmd)
+	matrixConfig := &MatrixConfig{}
+	matrixConfig.registerMatrixFlags(demoCmd)
+	loadConfig(demoCmd, args)
 	demoCmd.Parse(args)
 
 	var stats = ossstats.Stats{
@@ -41,7 +46,7 @@ func runDemoCmd(args []string) {
 		Summary: ossstats.Summary{
 			TotalProjects:  7,
 			TotalPRsMerged: 17,
-			TotalCommits:   17,
+			TotalCommits:   intPtr(17),
 			TotalAdditions: 0,
 			TotalDeletions: 0,
 		},
@@ -54,7 +59,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         6,
-				Commits:           6,
+				Commits:           intPtr(6),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2025, 11, 21, 14, 48, 30, 0, time.UTC),
@@ -68,7 +73,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         2,
-				Commits:           2,
+				Commits:           intPtr(2),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2025, 11, 19, 12, 6, 16, 0, time.UTC),
@@ -82,7 +87,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         2,
-				Commits:           2,
+				Commits:           intPtr(2),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2025, 6, 14, 20, 55, 24, 0, time.UTC),
@@ -96,7 +101,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         2,
-				Commits:           2,
+				Commits:           intPtr(2),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2025, 6, 9, 18, 24, 56, 0, time.UTC),
@@ -110,7 +115,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         2,
-				Commits:           2,
+				Commits:           intPtr(2),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2025, 6, 3, 14, 8, 20, 0, time.UTC),
@@ -124,7 +129,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         2,
-				Commits:           2,
+				Commits:           intPtr(2),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2025, 2, 17, 15, 46, 51, 0, time.UTC),
@@ -138,7 +143,7 @@ func runDemoCmd(args []string) {
 				RepoURL:           "https://github.com/ibad-al-rahman/android-public",
 				Stars:             15,
 				PRsMerged:         1,
-				Commits:           1,
+				Commits:           intPtr(1),
 				Additions:         0,
 				Deletions:         0,
 				FirstContribution: time.Date(2024, 5, 6, 20, 37, 13, 0, time.UTC),
@@ -153,9 +158,17 @@ func runDemoCmd(args []string) {
 		os.Exit(1)
 	}
 
+	if matrixConfig.enabled {
+		if err := writeBadgeMatrix(badgeOption, *badgeConfig, *matrixConfig, verbose, &stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating badges: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if err := writeBadge(
 		badgeOption,
-		badgeConfig.output,
+		*badgeConfig,
 		verbose,
 		&stats,
 	); err != nil {
@@ -165,3 +178,7 @@ func runDemoCmd(args []string) {
 
 	os.Exit(0)
 }
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// printFetchPlan writes the --dry-run report: what was found, the API calls
+// a full run would make and whether the current rate limits cover them
+func printFetchPlan(w io.Writer, plan *ossstats.FetchPlan) {
+	fmt.Fprintf(w, "Dry run for %s: %d merged PRs in %d repos\n\n", plan.Username, plan.PRs, plan.Repos)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "API\tCalls\tRemaining\tLimit\tResets")
+	for _, row := range []struct {
+		name  string
+		calls ossstats.PlannedCalls
+	}{
+		{"search", plan.Search},
+		{"core", plan.Core},
+		{"graphql", plan.GraphQL},
+	} {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\n",
+			row.name, row.calls.Calls, row.calls.Remaining, row.calls.Limit, row.calls.Reset.Local().Format(time.TimeOnly))
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	if plan.FitsBudget {
+		fmt.Fprintln(w, "The current rate limit budget covers a full run.")
+	} else {
+		fmt.Fprintf(w, "The current rate limit budget does not cover a full run; it would take about %s waiting for resets.\n",
+			plan.EstimatedDuration.Round(time.Minute))
+	}
+	fmt.Fprintln(w, "Optional searches are counted as one page, so the real cost may be higher.")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestPrintFetchPlan(t *testing.T) {
+	plan := &ossstats.FetchPlan{
+		Username: "testuser",
+		PRs:      120,
+		Repos:    8,
+		Search:   ossstats.PlannedCalls{Calls: 2, Limit: 30, Remaining: 30},
+		Core:     ossstats.PlannedCalls{Calls: 128, Limit: 5000, Remaining: 100},
+	}
+
+	var buf bytes.Buffer
+	printFetchPlan(&buf, plan)
+	out := buf.String()
+
+	for _, want := range []string{"testuser: 120 merged PRs in 8 repos", "core     128", "search   2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, "does not cover") {
+		t.Errorf("output should say the budget does not cover the run:\n%s", out)
+	}
+
+	plan.FitsBudget = true
+	plan.EstimatedDuration = 0
+	buf.Reset()
+	printFetchPlan(&buf, plan)
+	if !strings.Contains(buf.String(), "budget covers a full run") {
+		t.Errorf("output should say the budget covers the run:\n%s", buf.String())
+	}
+
+	plan.FitsBudget = false
+	plan.EstimatedDuration = 42*time.Minute + 10*time.Second
+	buf.Reset()
+	printFetchPlan(&buf, plan)
+	if !strings.Contains(buf.String(), "about 42m0s") {
+		t.Errorf("output should estimate the duration:\n%s", buf.String())
+	}
+}
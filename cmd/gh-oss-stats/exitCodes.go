@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// Exit codes of the main command, so scripts can tell failures apart.
+// Partial results are written like complete ones and exit with 0. Code 2 is
+// left to the flag package, which exits with it on unknown flags.
+const (
+	exitError              = 1 // Invalid settings, or a failure without its own code
+	exitUserNotFound       = 3
+	exitAuthentication     = 4
+	exitForbidden          = 5
+	exitRateLimited        = 6
+	exitSecondaryRateLimit = 7
+)
+
+// exitCode returns the exit code for a failed fetch
+func exitCode(err error) int {
+	var (
+		notFoundErr  *ossstats.ErrUserNotFound
+		authErr      *ossstats.ErrAuthentication
+		forbiddenErr *ossstats.ErrForbidden
+		rateLimitErr *ossstats.ErrRateLimited
+		secondaryErr *ossstats.ErrSecondaryRateLimit
+	)
+	switch {
+	case errors.As(err, &notFoundErr):
+		return exitUserNotFound
+	case errors.As(err, &authErr):
+		return exitAuthentication
+	case errors.As(err, &forbiddenErr):
+		return exitForbidden
+	case errors.As(err, &rateLimitErr):
+		return exitRateLimited
+	case errors.As(err, &secondaryErr):
+		return exitSecondaryRateLimit
+	}
+	return exitError
+}
+
+// printErrorHint tells how to get past a failed fetch, when there is a way
+func printErrorHint(err error, checkpoint string) {
+	var (
+		authErr      *ossstats.ErrAuthentication
+		forbiddenErr *ossstats.ErrForbidden
+		rateLimitErr *ossstats.ErrRateLimited
+		secondaryErr *ossstats.ErrSecondaryRateLimit
+		apiErr       *ossstats.APIError
+	)
+	switch {
+	case errors.As(err, &authErr):
+		fmt.Fprintf(os.Stderr, "Hint: Provide a token with --token or set GITHUB_TOKEN\n")
+	case errors.As(err, &forbiddenErr):
+		fmt.Fprintf(os.Stderr, "Hint: Check the token's scopes, and that it is authorized for the organization's SSO\n")
+	case errors.As(err, &rateLimitErr):
+		if checkpoint != "" {
+			fmt.Fprintf(os.Stderr, "Hint: Run again with --checkpoint %s after %s to resume\n",
+				checkpoint, rateLimitErr.ResetAt.Local().Format(time.Kitchen))
+		}
+	case errors.As(err, &secondaryErr):
+		fmt.Fprintf(os.Stderr, "Hint: Wait %s before running again, or lower --concurrency\n", secondaryErr.RetryAfter)
+	}
+
+	if errors.As(err, &apiErr) && apiErr.DocumentationURL != "" {
+		fmt.Fprintf(os.Stderr, "See: %s\n", apiErr.DocumentationURL)
+	}
+}
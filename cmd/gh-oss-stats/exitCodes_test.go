@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{&ossstats.ErrUserNotFound{Username: "ghost"}, exitUserNotFound},
+		{&ossstats.ErrAuthentication{}, exitAuthentication},
+		{fmt.Errorf("searching issues: %w", &ossstats.ErrForbidden{}), exitForbidden},
+		{&ossstats.ErrRateLimited{}, exitRateLimited},
+		{&ossstats.ErrSecondaryRateLimit{}, exitSecondaryRateLimit},
+		{errors.New("timeout"), exitError},
+	}
+	seen := make(map[int]bool)
+	for _, tt := range tests {
+		got := exitCode(tt.err)
+		if got != tt.want {
+			t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+		if seen[got] {
+			t.Errorf("exit code %d is used twice", got)
+		}
+		seen[got] = true
+	}
+}
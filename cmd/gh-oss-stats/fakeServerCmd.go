@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/ghfake"
+)
+
+// fakeServerCmd flag set
+var fakeServerCmd = flag.NewFlagSet("fake-server", flag.ExitOnError)
+
+// Fake server command flags
+var (
+	fakeAddr     = fakeServerCmd.String("addr", "127.0.0.1:8081", "Address to listen on")
+	fakeScenario = fakeServerCmd.String("scenario", "", "YAML scenario file with the repositories, PRs, rate limits and faults to serve (required)")
+)
+
+func init() {
+	fakeServerCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats fake-server --scenario FILE [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Serve a fake GitHub API from a scenario file, for testing offline.\n\n")
+		fmt.Fprintf(os.Stderr, "Endpoints:\n")
+		fmt.Fprintf(os.Stderr, "  GET /search/issues?q=&page=&per_page=\n")
+		fmt.Fprintf(os.Stderr, "  GET /repos/{owner}/{repo}\n")
+		fmt.Fprintf(os.Stderr, "  GET /repos/{owner}/{repo}/pulls/{number}\n")
+		fmt.Fprintf(os.Stderr, "  GET /rate_limit\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fakeServerCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Serve a scenario and fetch stats from it\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats fake-server --scenario scenario.yaml &\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats --user octocat --base-url http://127.0.0.1:8081\n\n")
+	}
+}
+
+func runFakeServerCmd(args []string) {
+	fakeServerCmd.Parse(args)
+
+	if *fakeScenario == "" {
+		fmt.Fprintf(os.Stderr, "Error: --scenario is required\n\n")
+		fakeServerCmd.Usage()
+		os.Exit(1)
+	}
+
+	scenario, err := ghfake.LoadScenario(*fakeScenario)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *fakeAddr,
+		Handler:           ghfake.New(scenario),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		httpServer.Shutdown(context.Background())
+	}()
+
+	log.Printf("Serving %s on http://%s", *fakeScenario, *fakeAddr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// configFileName is searched for in the current directory
+	configFileName = ".gh-oss-stats"
+
+	// envPrefix prefixes environment variables overriding config keys,
+	// e.g. GH_OSS_STATS_MIN_STARS for min-stars
+	envPrefix = "GH_OSS_STATS_"
+)
+
+// configExtensions are the supported config file formats, in search order
+var configExtensions = []string{".yaml", ".yml", ".toml"}
+
+// configKeys lists every setting a config file may contain. Keys are the
+// long flag names, so a file reads like the command line it replaces.
+var configKeys = []string{
+	// Fetching
+	"user", "token", "base-url", "include-loc", "include-prs", "min-stars", "max-prs",
+	"exclude-orgs", "timeout", "concurrency", "checkpoint", "debug",
+	"debug-scenario", "include-reviews", "include-issues", "include-closed-issues",
+	"include-discussions", "include-commits", "include-pr-states", "include-coauthored-commits",
+
+	// Filters
+	"include-repos", "exclude-repos", "include-owners", "exclude-owners",
+	"include-languages", "exclude-languages", "include-topics", "exclude-topics",
+	"exclude-archived", "exclude-forks", "min-prs-per-repo",
+
+	// PR quality
+	"min-pr-lines", "exclude-docs-only", "exclude-dependency-bumps", "exclude-pr-titles",
+	"exclude-bot-coauthored",
+
+	// Output
+	"output", "format", "verbose", "log-format", "log-level", "trace", "record", "replay", "badge",
+
+	// Badge
+	"badge-style", "badge-variant", "badge-theme", "badge-output", "badge-sort",
+	"badge-limit", "badge-locale", "badge-avatars", "badge-avatar-cache", "badge-offline",
+	"badge-acceptance-rate", "badge-format",
+
+	// Serve
+	"addr", "cache-ttl", "stale-ttl", "metrics", "metrics-users", "allow-users",
+}
+
+// commandKeys maps, per flag set, the keys that configure a flag of another
+// name. The badge command's --format takes other values than the main
+// command's, so a file sets it with badge-format and format is left alone.
+var commandKeys = map[string]map[string]string{
+	"badge": {"badge-format": "format"},
+}
+
+// flagName returns the flag of fs that key configures, or "" when key
+// configures a flag renamed by commandKeys
+func flagName(fs *flag.FlagSet, key string) string {
+	renames := commandKeys[fs.Name()]
+	if name, ok := renames[key]; ok {
+		return name
+	}
+	for _, name := range renames {
+		if name == key {
+			return ""
+		}
+	}
+	return key
+}
+
+// repeatableKeys are the keys of repeatableFlag flags. Their file lists are
+// joined with newlines instead of commas, since items may contain commas.
+var repeatableKeys = []string{"exclude-pr-titles"}
+
+// repeatableFlag is a flag that may be given several times, each adding one
+// item, for values that can contain commas such as regular expressions.
+// Items from a config file or the environment are newline-separated, and are
+// replaced by the first occurrence of the flag on the command line.
+type repeatableFlag struct {
+	items      []string
+	fromConfig bool
+}
+
+func (f *repeatableFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(f.items, "\n")
+}
+
+func (f *repeatableFlag) Set(value string) error {
+	if f.fromConfig {
+		f.items, f.fromConfig = nil, false
+	}
+	f.items = append(f.items, value)
+	return nil
+}
+
+// setConfig replaces the items with the newline-separated items of value
+func (f *repeatableFlag) setConfig(value string) {
+	f.items, f.fromConfig = nil, true
+	for _, item := range strings.Split(value, "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			f.items = append(f.items, item)
+		}
+	}
+}
+
+// Setting sources, from lowest to highest precedence
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+)
+
+// FileConfig holds the settings read from a config file. Top-level keys apply
+// to every run; keys in a profile override them when that profile is selected.
+type FileConfig struct {
+	Path     string
+	Values   map[string]string
+	Profiles map[string]map[string]string
+}
+
+// ConfigSources records where each applied setting came from
+type ConfigSources map[string]string
+
+// registerConfigFlags adds --config and --profile to fs. Their values are
+// read before parsing (see applyConfig); registering them only keeps Parse
+// from rejecting them.
+func registerConfigFlags(fs *flag.FlagSet) {
+	fs.String("config", "", "Config file (default: ./.gh-oss-stats.{yaml,yml,toml}, then $XDG_CONFIG_HOME/gh-oss-stats/config.*)")
+	fs.String("profile", "", "Config file profile to use (default: $GH_OSS_STATS_PROFILE)")
+}
+
+// loadConfig registers the config flags on fs and applies the config file
+// and environment to it, exiting on errors. Call it right before fs.Parse.
+func loadConfig(fs *flag.FlagSet, args []string) {
+	registerConfigFlags(fs)
+	if _, _, err := applyConfig(fs, args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// selectedProfile returns the profile chosen by --profile or GH_OSS_STATS_PROFILE
+func selectedProfile(args []string) string {
+	if profile, ok := argValue(args, "profile"); ok {
+		return profile
+	}
+	return os.Getenv(envPrefix + "PROFILE")
+}
+
+// applyConfig loads the config file and environment variables into fs as
+// flag values, so that a following fs.Parse(args) gives the precedence
+// flag > env > file > default. It returns the loaded file (nil if none was
+// found) and the source of every value it set.
+func applyConfig(fs *flag.FlagSet, args []string) (*FileConfig, ConfigSources, error) {
+	sources := ConfigSources{}
+
+	path, explicit := argValue(args, "config")
+	if !explicit {
+		var err error
+		if path, err = findConfigFile(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	profile := selectedProfile(args)
+
+	var fc *FileConfig
+	if path != "" {
+		var err error
+		if fc, err = loadConfigFile(path); err != nil {
+			return nil, nil, err
+		}
+
+		values, err := fc.resolve(profile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := setFlags(fs, values, sourceFile, sources); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if profile != "" {
+		return nil, nil, fmt.Errorf("profile %q selected but no config file found", profile)
+	}
+
+	if err := setFlags(fs, envValues(), sourceEnv, sources); err != nil {
+		return nil, nil, fmt.Errorf("environment: %w", err)
+	}
+
+	return fc, sources, nil
+}
+
+// setFlags sets every value whose key is a flag of fs. Keys for other
+// commands are skipped, so one file can configure all of them.
+func setFlags(fs *flag.FlagSet, values map[string]string, source string, sources ConfigSources) error {
+	for _, key := range configKeys {
+		value, ok := values[key]
+		name := flagName(fs, key)
+		if !ok || name == "" || fs.Lookup(name) == nil {
+			continue
+		}
+		if list, ok := fs.Lookup(name).Value.(*repeatableFlag); ok {
+			list.setConfig(value)
+			sources[key] = source
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+		}
+		sources[key] = source
+	}
+	return nil
+}
+
+// envValues returns the config keys set through GH_OSS_STATS_* variables.
+// The token also falls back to GITHUB_TOKEN.
+func envValues() map[string]string {
+	values := map[string]string{}
+	for _, key := range configKeys {
+		name := envPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		if value, ok := os.LookupEnv(name); ok {
+			values[key] = value
+		}
+	}
+	if _, ok := values["token"]; !ok {
+		if value := os.Getenv("GITHUB_TOKEN"); value != "" {
+			values["token"] = value
+		}
+	}
+	return values
+}
+
+// argValue returns the value of --name/-name in args without parsing them,
+// accepting both "--name value" and "--name=value"
+func argValue(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == arg {
+			continue
+		}
+		if trimmed == name && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if value, ok := strings.CutPrefix(trimmed, name+"="); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// flagInArgs reports whether --name/-name is given in args
+func flagInArgs(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed != arg && (trimmed == name || strings.HasPrefix(trimmed, name+"=")) {
+			return true
+		}
+	}
+	return false
+}
+
+// findConfigFile returns the first existing config file, or "" if none exists:
+//
+//	./.gh-oss-stats.yaml, ./.gh-oss-stats.yml, ./.gh-oss-stats.toml
+//	$XDG_CONFIG_HOME/gh-oss-stats/config.{yaml,yml,toml} (default: ~/.config)
+func findConfigFile() (string, error) {
+	var candidates []string
+	for _, ext := range configExtensions {
+		candidates = append(candidates, configFileName+ext)
+	}
+	if dir := xdgConfigDir(); dir != "" {
+		for _, ext := range configExtensions {
+			candidates = append(candidates, filepath.Join(dir, "config"+ext))
+		}
+	}
+
+	for _, path := range candidates {
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// xdgConfigDir returns the gh-oss-stats directory under $XDG_CONFIG_HOME,
+// falling back to ~/.config as the XDG spec defines
+func xdgConfigDir() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "gh-oss-stats")
+}
+
+// loadConfigFile reads a YAML or TOML config file, chosen by extension
+func loadConfigFile(path string) (*FileConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format (must be .yaml, .yml or .toml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	fc := &FileConfig{Path: path, Profiles: map[string]map[string]string{}}
+
+	profiles, _ := raw["profiles"].(map[string]any)
+	if _, ok := raw["profiles"]; ok && profiles == nil {
+		return nil, fmt.Errorf("%s: profiles must be a table of named profiles", path)
+	}
+	delete(raw, "profiles")
+
+	if fc.Values, err = normalizeValues(raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for name, section := range profiles {
+		values, ok := section.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: profile %s must be a table", path, name)
+		}
+		if fc.Profiles[name], err = normalizeValues(values); err != nil {
+			return nil, fmt.Errorf("%s: profile %s: %w", path, name, err)
+		}
+	}
+
+	return fc, nil
+}
+
+// resolve returns the top-level values overlaid with the given profile
+func (fc *FileConfig) resolve(profile string) (map[string]string, error) {
+	values := make(map[string]string, len(fc.Values))
+	for k, v := range fc.Values {
+		values[k] = v
+	}
+
+	if profile == "" {
+		return values, nil
+	}
+
+	overrides, ok := fc.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown profile %q", fc.Path, profile)
+	}
+	for k, v := range overrides {
+		values[k] = v
+	}
+
+	return values, nil
+}
+
+// normalizeValues converts decoded YAML/TOML values into flag strings.
+// Lists become comma-separated values (e.g. exclude-orgs), or
+// newline-separated ones for repeatableKeys.
+func normalizeValues(raw map[string]any) (map[string]string, error) {
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if !slices.Contains(configKeys, key) {
+			return nil, fmt.Errorf("unknown setting %q", key)
+		}
+
+		sep := ","
+		if slices.Contains(repeatableKeys, key) {
+			sep = "\n"
+		}
+		s, err := flagString(value, sep)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		values[key] = s
+	}
+	return values, nil
+}
+
+func flagString(value any, sep string) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := flagString(item, sep)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, sep), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
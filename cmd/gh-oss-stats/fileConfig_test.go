@@ -0,0 +1,339 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newConfigTestFlagSet registers a few main and badge flags the way the commands do
+func newConfigTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("user", "", "")
+	fs.String("token", "", "")
+	fs.Int("min-stars", 0, "")
+	fs.String("exclude-orgs", "", "")
+	fs.Bool("include-loc", false, "")
+	newBadgeConfig().registerBadgeFlags(fs)
+	registerConfigFlags(fs)
+	return fs
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// isolateConfig runs the test in an empty directory without config env vars
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+	t.Setenv("GITHUB_TOKEN", "")
+	for _, key := range []string{"PROFILE", "USER", "MIN_STARS", "BADGE_THEME", "TOKEN"} {
+		os.Unsetenv(envPrefix + key)
+	}
+	return dir
+}
+
+const testYAMLConfig = `
+user: mabd-dev
+min-stars: 10
+exclude-orgs: [org-a, org-b]
+include-loc: true
+badge-theme: nord
+profiles:
+  work:
+    min-stars: 100
+    badge-style: detailed
+`
+
+func TestLoadConfigFileYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "config.yaml", testYAMLConfig)
+
+	fc, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatalf("loadConfigFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"user":         "mabd-dev",
+		"min-stars":    "10",
+		"exclude-orgs": "org-a,org-b",
+		"include-loc":  "true",
+		"badge-theme":  "nord",
+	}
+	for k, v := range want {
+		if fc.Values[k] != v {
+			t.Errorf("Values[%s] = %q, want %q", k, fc.Values[k], v)
+		}
+	}
+
+	values, err := fc.resolve("work")
+	if err != nil {
+		t.Fatalf("resolve(work) error = %v", err)
+	}
+	if values["min-stars"] != "100" || values["badge-style"] != "detailed" || values["user"] != "mabd-dev" {
+		t.Errorf("resolve(work) = %v", values)
+	}
+
+	if _, err := fc.resolve("missing"); err == nil {
+		t.Error("resolve() should fail for an unknown profile")
+	}
+}
+
+func TestLoadConfigFileTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "config.toml", `
+user = "mabd-dev"
+min-stars = 10
+exclude-orgs = ["org-a", "org-b"]
+
+[profiles.work]
+badge-theme = "light"
+`)
+
+	fc, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatalf("loadConfigFile() error = %v", err)
+	}
+	if fc.Values["min-stars"] != "10" || fc.Values["exclude-orgs"] != "org-a,org-b" {
+		t.Errorf("Values = %v", fc.Values)
+	}
+	if fc.Profiles["work"]["badge-theme"] != "light" {
+		t.Errorf("Profiles = %v", fc.Profiles)
+	}
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"unknown key", "a.yaml", "min-starz: 10\n"},
+		{"unknown key in profile", "b.yaml", "profiles:\n  work:\n    colour: red\n"},
+		{"invalid yaml", "c.yaml", "user: [\n"},
+		{"nested value", "d.yaml", "user:\n  name: x\n"},
+		{"unsupported format", "e.json", "{}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, dir, tt.file, tt.content)
+			if _, err := loadConfigFile(path); err == nil {
+				t.Error("loadConfigFile() should fail")
+			}
+		})
+	}
+}
+
+func TestApplyConfigPrecedence(t *testing.T) {
+	dir := isolateConfig(t)
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", testYAMLConfig)
+	t.Setenv(envPrefix+"MIN_STARS", "50")
+
+	args := []string{"--badge-theme", "light"}
+	fs := newConfigTestFlagSet()
+	fc, sources, err := applyConfig(fs, args)
+	if err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	if fc == nil || fc.Path != ".gh-oss-stats.yaml" {
+		t.Fatalf("config file = %v, want .gh-oss-stats.yaml", fc)
+	}
+
+	tests := []struct {
+		key, want, source string
+	}{
+		{"user", "mabd-dev", sourceFile},     // file > default
+		{"min-stars", "50", sourceEnv},       // env > file
+		{"badge-theme", "light", sourceFile}, // flag > file (source is recorded before parsing)
+		{"badge-style", "summary", ""},       // default
+		{"exclude-orgs", "org-a,org-b", sourceFile},
+	}
+	for _, tt := range tests {
+		if got := fs.Lookup(tt.key).Value.String(); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+		}
+		if sources[tt.key] != tt.source {
+			t.Errorf("source of %s = %q, want %q", tt.key, sources[tt.key], tt.source)
+		}
+	}
+}
+
+func TestApplyConfigBadgeFormat(t *testing.T) {
+	dir := isolateConfig(t)
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", "format: json\nbadge-format: shields\n")
+
+	mainFS := flag.NewFlagSet("gh-oss-stats", flag.ContinueOnError)
+	mainFS.String("format", "json", "")
+	badgeFS := flag.NewFlagSet("badge", flag.ContinueOnError)
+	badgeFS.String("format", "svg", "")
+
+	tests := []struct {
+		fs   *flag.FlagSet
+		want string
+	}{
+		{mainFS, "json"},
+		{badgeFS, "shields"},
+	}
+	for _, tt := range tests {
+		if _, _, err := applyConfig(tt.fs, nil); err != nil {
+			t.Fatalf("%s: applyConfig() error = %v", tt.fs.Name(), err)
+		}
+		if got := tt.fs.Lookup("format").Value.String(); got != tt.want {
+			t.Errorf("%s: format = %q, want %q", tt.fs.Name(), got, tt.want)
+		}
+	}
+
+	// format alone must not reach the badge command
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", "format: json\n")
+	badgeFS = flag.NewFlagSet("badge", flag.ContinueOnError)
+	badgeFS.String("format", "svg", "")
+	if _, _, err := applyConfig(badgeFS, nil); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+	if got := badgeFS.Lookup("format").Value.String(); got != "svg" {
+		t.Errorf("badge format = %q, want svg", got)
+	}
+}
+
+func TestApplyConfigRepeatableFlag(t *testing.T) {
+	dir := isolateConfig(t)
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", "exclude-pr-titles: ['^v\\d{1,3}$', '^chore']\n")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	qc := &QualityConfig{}
+	qc.registerQualityFlags(fs)
+	if _, _, err := applyConfig(fs, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{`^v\d{1,3}$`, "^chore"}; !slices.Equal(qc.titlePatterns.items, want) {
+		t.Errorf("patterns from file = %q, want %q", qc.titlePatterns.items, want)
+	}
+
+	// Flags replace the file's patterns
+	if err := fs.Parse([]string{"--exclude-pr-titles", "^WIP", "--exclude-pr-titles", `^a{2,}`}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"^WIP", `^a{2,}`}; !slices.Equal(qc.titlePatterns.items, want) {
+		t.Errorf("patterns from flags = %q, want %q", qc.titlePatterns.items, want)
+	}
+}
+
+func TestApplyConfigProfile(t *testing.T) {
+	dir := isolateConfig(t)
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", testYAMLConfig)
+
+	args := []string{"--profile=work"}
+	fs := newConfigTestFlagSet()
+	if _, _, err := applyConfig(fs, args); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+
+	if got := fs.Lookup("min-stars").Value.String(); got != "100" {
+		t.Errorf("min-stars = %q, want 100", got)
+	}
+	if got := fs.Lookup("badge-style").Value.String(); got != "detailed" {
+		t.Errorf("badge-style = %q, want detailed", got)
+	}
+}
+
+func TestApplyConfigTokenFromGithubEnv(t *testing.T) {
+	dir := isolateConfig(t)
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", "token: from-file\n")
+	t.Setenv("GITHUB_TOKEN", "from-env")
+
+	fs := newConfigTestFlagSet()
+	if _, _, err := applyConfig(fs, nil); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+
+	if got := fs.Lookup("token").Value.String(); got != "from-env" {
+		t.Errorf("token = %q, want from-env", got)
+	}
+}
+
+func TestApplyConfigXDG(t *testing.T) {
+	dir := isolateConfig(t)
+	writeTestFile(t, dir, "xdg/gh-oss-stats/config.toml", "user = \"xdg-user\"\n")
+
+	fs := newConfigTestFlagSet()
+	fc, _, err := applyConfig(fs, nil)
+	if err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+	if fc == nil {
+		t.Fatal("XDG config file not found")
+	}
+	if got := fs.Lookup("user").Value.String(); got != "xdg-user" {
+		t.Errorf("user = %q, want xdg-user", got)
+	}
+
+	// The current directory wins over XDG
+	writeTestFile(t, dir, ".gh-oss-stats.yml", "user: local-user\n")
+	fs = newConfigTestFlagSet()
+	if _, _, err := applyConfig(fs, nil); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+	if got := fs.Lookup("user").Value.String(); got != "local-user" {
+		t.Errorf("user = %q, want local-user", got)
+	}
+}
+
+func TestApplyConfigErrors(t *testing.T) {
+	dir := isolateConfig(t)
+
+	if _, _, err := applyConfig(newConfigTestFlagSet(), []string{"--profile", "work"}); err == nil {
+		t.Error("applyConfig() should fail when a profile is selected without a config file")
+	}
+
+	if _, _, err := applyConfig(newConfigTestFlagSet(), []string{"--config", "missing.yaml"}); err == nil {
+		t.Error("applyConfig() should fail when --config does not exist")
+	}
+
+	writeTestFile(t, dir, ".gh-oss-stats.yaml", "min-stars: lots\n")
+	if _, _, err := applyConfig(newConfigTestFlagSet(), nil); err == nil {
+		t.Error("applyConfig() should fail for a value the flag rejects")
+	}
+}
+
+func TestArgValue(t *testing.T) {
+	tests := []struct {
+		args   []string
+		want   string
+		wantOK bool
+	}{
+		{[]string{"--config", "a.yaml"}, "a.yaml", true},
+		{[]string{"-config=b.toml"}, "b.toml", true},
+		{[]string{"--user", "x", "--config=c.yaml"}, "c.yaml", true},
+		{[]string{"--user", "config"}, "", false},
+		{[]string{"--", "--config", "d.yaml"}, "", false},
+		{[]string{"--config"}, "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := argValue(tt.args, "config")
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("argValue(%v) = %q, %v, want %q, %v", tt.args, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// FilterConfig holds the repository filter flags
+type FilterConfig struct {
+	includeRepos     string
+	excludeRepos     string
+	includeOwners    string
+	excludeOwners    string
+	includeLanguages string
+	excludeLanguages string
+	includeTopics    string
+	excludeTopics    string
+	excludeArchived  bool
+	excludeForks     bool
+	minPRs           int
+}
+
+func (fc *FilterConfig) registerFilterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&fc.includeRepos, "include-repos", "", "Comma-separated owner/repo globs to include, e.g. kubernetes/*")
+	fs.StringVar(&fc.excludeRepos, "exclude-repos", "", "Comma-separated owner/repo globs to exclude, e.g. */awesome-*,*/docs")
+	fs.StringVar(&fc.includeOwners, "include-owners", "", "Comma-separated repository owners to include")
+	fs.StringVar(&fc.excludeOwners, "exclude-owners", "", "Comma-separated repository owners to exclude")
+	fs.StringVar(&fc.includeLanguages, "include-languages", "", "Comma-separated primary languages to include, e.g. Go,Rust")
+	fs.StringVar(&fc.excludeLanguages, "exclude-languages", "", "Comma-separated primary languages to exclude")
+	fs.StringVar(&fc.includeTopics, "include-topics", "", "Comma-separated repository topics to include")
+	fs.StringVar(&fc.excludeTopics, "exclude-topics", "", "Comma-separated repository topics to exclude")
+	fs.BoolVar(&fc.excludeArchived, "exclude-archived", false, "Exclude archived repositories")
+	fs.BoolVar(&fc.excludeForks, "exclude-forks", false, "Exclude repositories that are forks")
+	fs.IntVar(&fc.minPRs, "min-prs-per-repo", 0, "Minimum merged PRs per repository")
+}
+
+// createFilter builds the library filter, or returns false if no filter flag is set
+func createFilter(conf FilterConfig) (ossstats.Filter, bool, error) {
+	filter := ossstats.Filter{
+		IncludeRepos:     splitList(conf.includeRepos),
+		ExcludeRepos:     splitList(conf.excludeRepos),
+		IncludeOwners:    splitList(conf.includeOwners),
+		ExcludeOwners:    splitList(conf.excludeOwners),
+		IncludeLanguages: splitList(conf.includeLanguages),
+		ExcludeLanguages: splitList(conf.excludeLanguages),
+		IncludeTopics:    splitList(conf.includeTopics),
+		ExcludeTopics:    splitList(conf.excludeTopics),
+		ExcludeArchived:  conf.excludeArchived,
+		ExcludeForks:     conf.excludeForks,
+		MinPRs:           conf.minPRs,
+	}
+
+	if conf.minPRs < 0 {
+		return filter, false, fmt.Errorf("--min-prs-per-repo must be >= 0 (got: %d)", conf.minPRs)
+	}
+	if err := filter.Validate(); err != nil {
+		return filter, false, err
+	}
+
+	set := conf != FilterConfig{}
+	return filter, set, nil
+}
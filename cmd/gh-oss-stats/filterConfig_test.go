@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestCreateFilter(t *testing.T) {
+	fc := &FilterConfig{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fc.registerFilterFlags(fs)
+
+	err := fs.Parse([]string{
+		"--exclude-repos", "*/awesome-*, */docs",
+		"--include-languages", "Go,Rust",
+		"--exclude-forks",
+		"--min-prs-per-repo", "2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter, set, err := createFilter(*fc)
+	if err != nil {
+		t.Fatalf("createFilter() error = %v", err)
+	}
+	if !set {
+		t.Fatal("createFilter() should report the filter as set")
+	}
+	if !slices.Equal(filter.ExcludeRepos, []string{"*/awesome-*", "*/docs"}) {
+		t.Errorf("ExcludeRepos = %v", filter.ExcludeRepos)
+	}
+	if !slices.Equal(filter.IncludeLanguages, []string{"Go", "Rust"}) {
+		t.Errorf("IncludeLanguages = %v", filter.IncludeLanguages)
+	}
+	if !filter.ExcludeForks || filter.ExcludeArchived || filter.MinPRs != 2 {
+		t.Errorf("filter = %+v", filter)
+	}
+}
+
+func TestCreateFilterUnset(t *testing.T) {
+	if _, set, err := createFilter(FilterConfig{}); err != nil || set {
+		t.Errorf("createFilter(empty) = set %v, err %v; want unset, nil", set, err)
+	}
+}
+
+func TestCreateFilterErrors(t *testing.T) {
+	if _, _, err := createFilter(FilterConfig{includeRepos: "[bad"}); err == nil {
+		t.Error("createFilter() should reject a malformed glob")
+	}
+	if _, _, err := createFilter(FilterConfig{minPRs: -1}); err == nil {
+		t.Error("createFilter() should reject a negative --min-prs-per-repo")
+	}
+}
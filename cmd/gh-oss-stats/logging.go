@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Log formats
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// newLogHandler creates the slog handler writing logs to w in format, at
+// level (debug, info, warn or error) and above
+func newLogHandler(w io.Writer, format, level string) (slog.Handler, error) {
+	var minLevel slog.Level
+	if err := minLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("--log-level must be debug, info, warn or error (got: %s)", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: minLevel}
+	switch format {
+	case logFormatText:
+		return slog.NewTextHandler(w, opts), nil
+	case logFormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("--log-format must be text or json (got: %s)", format)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewLogHandler(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		h, err := newLogHandler(&buf, logFormatJSON, "warn")
+		if err != nil {
+			t.Fatalf("newLogHandler() error = %v", err)
+		}
+		if h.Enabled(context.Background(), slog.LevelInfo) {
+			t.Error("info logs should be disabled at warn level")
+		}
+
+		slog.New(h).Warn("Failed to fetch repo", "repo", "owner/repo")
+		var record map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatalf("invalid JSON log %q: %v", buf.String(), err)
+		}
+		if record["level"] != "WARN" || record["repo"] != "owner/repo" {
+			t.Errorf("record = %v", record)
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		h, err := newLogHandler(&buf, logFormatText, "DEBUG")
+		if err != nil {
+			t.Fatalf("newLogHandler() error = %v", err)
+		}
+
+		slog.New(h).Debug("GitHub API request", "status", 200)
+		if out := buf.String(); !strings.Contains(out, "level=DEBUG") || !strings.Contains(out, "status=200") {
+			t.Errorf("output = %q", out)
+		}
+	})
+
+	for _, tt := range []struct{ format, level string }{
+		{"xml", "info"},
+		{logFormatText, "verbose"},
+	} {
+		if _, err := newLogHandler(&bytes.Buffer{}, tt.format, tt.level); err == nil {
+			t.Errorf("newLogHandler(%q, %q) should fail", tt.format, tt.level)
+		}
+	}
+}
@@ -21,6 +21,12 @@ func main() {
 		runBadgeCmd(args[1:])
 	case "demo":
 		runDemoCmd(args[1:])
+	case "serve":
+		runServeCmd(args[1:])
+	case "fake-server":
+		runFakeServerCmd(args[1:])
+	case "config":
+		runConfigCmd(args[1:])
 	case "version":
 		fmt.Printf("gh-oss-stats v%s\n", version)
 		os.Exit(0)
@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,18 +29,42 @@ var (
 	outputShort  = flag.String("o", "", "Output file (short)")
 	verbose      = flag.Bool("verbose", false, "Verbose logging to stderr")
 	verboseShort = flag.Bool("v", false, "Verbose logging (short)")
+	logFormat    = flag.String("log-format", logFormatText, "Log format: text or json")
+	logLevel     = flag.String("log-level", "", "Log level: debug, info, warn or error (default: no logs, info with --verbose)")
+	traceTo      = flag.String("trace", "", "Export OpenTelemetry traces: stdout (to stderr) or otlp (OTEL_EXPORTER_OTLP_* settings)")
 	timeoutSec   = flag.Int("timeout", int(ossstats.DefaultTimeout.Seconds()), "Timeout in seconds")
+	concurrency  = flag.Int("concurrency", ossstats.DefaultConcurrency, "Max API requests in flight (lowered automatically as the rate limit runs low)")
+	checkpoint   = flag.String("checkpoint", "", "Checkpoint file: records progress and resumes an interrupted run from it")
+	baseURL      = flag.String("base-url", "", "GitHub API URL, e.g. https://HOST/api/v3 for GitHub Enterprise or a fake-server (default: https://api.github.com)")
+	record       = flag.String("record", "", "Record the GitHub API responses of the run to a fixture file (tokens are scrubbed)")
+	replayFrom   = flag.String("replay", "", "Replay GitHub API responses from a fixture file made with --record")
+
+	includeCommits      = flag.Bool("include-commits", false, "Count commits per PR without fetching LOC (1 GraphQL call per 100 PRs, needs a token)")
+	includeReviews      = flag.Bool("include-reviews", false, "Count PRs reviewed on external repos")
+	includeIssues       = flag.Bool("include-issues", false, "Count issues opened on external repos")
+	includeClosedIssues = flag.Bool("include-closed-issues", false, "Count issues closed by merged PRs (1 GraphQL call per PR, needs a token)")
+	includeDiscussions  = flag.Bool("include-discussions", false, "Count accepted discussion answers (needs a token)")
+	includePRStates     = flag.Bool("include-pr-states", false, "Count open and closed-unmerged PRs and report acceptance rates")
+	includeCoAuthored   = flag.Bool("include-coauthored-commits", false, "Count authored and co-authored commits that landed outside your PRs")
 
 	generateBadge = flag.Bool("badge", false, "Generate SVG badge")
+	format        = flag.String("format", formatJSON, "Output format: json, shields (shields writes one endpoint file per metric into --output dir)")
+	dryRun        = flag.Bool("dry-run", false, "Only search for PRs and print the API calls a full run would make")
 
-	debug = flag.Bool("debug", false, "Uses fake data when true")
+	debug         = flag.Bool("debug", false, "Replay built-in recorded API responses instead of calling GitHub")
+	debugScenario = flag.String("debug-scenario", "", "Serve a built-in debug scenario instead of calling GitHub (implies --debug): "+strings.Join(ossstats.DebugScenarios(), ", "))
 )
 
 func runMainCmd(args []string) {
 	// Initialize local badge configuration
 	badgeConfig := newBadgeConfig()
 	badgeConfig.registerBadgeFlags(flag.CommandLine)
-	flag.Parse()
+	filterConfig := &FilterConfig{}
+	filterConfig.registerFilterFlags(flag.CommandLine)
+	qualityConfig := &QualityConfig{}
+	qualityConfig.registerQualityFlags(flag.CommandLine)
+	loadConfig(flag.CommandLine, args)
+	flag.CommandLine.Parse(args)
 
 	// Merge short and long flags
 	if *userShort != "" {
@@ -78,6 +104,15 @@ func runMainCmd(args []string) {
 		fmt.Fprintf(os.Stderr, "Error: --timeout must be > 0 seconds (got: %d)\n\n", *timeoutSec)
 		os.Exit(1)
 	}
+	if *concurrency <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --concurrency must be > 0 (got: %d)\n\n", *concurrency)
+		os.Exit(1)
+	}
+
+	if *format != formatJSON && *format != formatShields {
+		fmt.Fprintf(os.Stderr, "Error: --format must be json or shields (got: %s)\n\n", *format)
+		os.Exit(1)
+	}
 
 	badgeOption, err := createBadgeOptions(*badgeConfig)
 	if err != nil {
@@ -85,33 +120,95 @@ func runMainCmd(args []string) {
 		os.Exit(1)
 	}
 
+	filter, hasFilter, err := createFilter(*filterConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	quality, hasQuality, err := createQualityFilter(*qualityConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *debugScenario != "" {
+		if !slices.Contains(ossstats.DebugScenarios(), *debugScenario) {
+			fmt.Fprintf(os.Stderr, "Error: --debug-scenario must be one of %s (got: %s)\n\n",
+				strings.Join(ossstats.DebugScenarios(), ", "), *debugScenario)
+			os.Exit(1)
+		}
+		*debug = true
+	}
+	if *debug && (*record != "" || *replayFrom != "") {
+		fmt.Fprintf(os.Stderr, "Error: --debug can't be used with --record or --replay\n\n")
+		os.Exit(1)
+	}
+	httpClient, saveRecording, err := fixtureHTTPClient(*record, *replayFrom, *username, *token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		os.Exit(1)
+	}
+
 	// Warn if no token provided (not an error, but rate limits will be severe)
-	if *token == "" {
+	if *token == "" && !*debug && *replayFrom == "" {
 		fmt.Fprintf(os.Stderr, "Warning: No GitHub token provided. You'll hit rate limits quickly (60 requests/hour).\n")
 		fmt.Fprintf(os.Stderr, "Hint: Set GITHUB_TOKEN environment variable or use --token flag\n")
 		fmt.Fprintf(os.Stderr, "      Create a token at: https://github.com/settings/tokens\n\n")
 	}
 
-	// Set up logger
-	var logger ossstats.Logger
-	if *verbose {
-		logger = log.New(os.Stderr, "[gh-oss-stats] ", log.LstdFlags)
+	// Set up logging: --verbose logs at info level unless --log-level is set
+	level := *logLevel
+	if level == "" && *verbose {
+		level = "info"
+	}
+	var logHandler slog.Handler
+	if level != "" {
+		logHandler, err = newLogHandler(os.Stderr, *logFormat, level)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+			os.Exit(1)
+		}
 	}
 
 	// Create client with options
 	opts := []ossstats.Option{
 		ossstats.WithLOC(*includeLOC),
 		ossstats.WithPRDetails(*includePRs),
+		ossstats.WithCommitCounts(*includeCommits),
+		ossstats.WithReviews(*includeReviews),
+		ossstats.WithIssues(*includeIssues),
+		ossstats.WithClosedIssues(*includeClosedIssues),
+		ossstats.WithDiscussionAnswers(*includeDiscussions),
+		ossstats.WithPRStates(*includePRStates),
+		ossstats.WithCoAuthoredCommits(*includeCoAuthored),
 		ossstats.WithMinStars(*minStars),
 		ossstats.WithMaxPRs(*maxPRs),
 		ossstats.WithTimeout(time.Duration(*timeoutSec) * time.Second),
+		ossstats.WithConcurrency(*concurrency),
 		ossstats.WithDebug(*debug),
 	}
 
+	if *debugScenario != "" {
+		opts = append(opts, ossstats.WithDebugScenario(*debugScenario))
+	}
+
+	if *checkpoint != "" {
+		opts = append(opts, ossstats.WithCheckpoint(*checkpoint))
+	}
+
 	if *token != "" {
 		opts = append(opts, ossstats.WithToken(*token))
 	}
 
+	if httpClient != nil {
+		opts = append(opts, ossstats.WithHTTPClient(httpClient))
+	}
+
+	if *baseURL != "" {
+		opts = append(opts, ossstats.WithBaseURL(*baseURL))
+	}
+
 	if *excludeOrgs != "" {
 		orgs := strings.Split(*excludeOrgs, ",")
 		// Trim whitespace from each org name
@@ -121,45 +218,89 @@ func runMainCmd(args []string) {
 		opts = append(opts, ossstats.WithExcludeOrgs(orgs))
 	}
 
-	if logger != nil {
-		opts = append(opts, ossstats.WithLogger(logger))
+	if hasFilter {
+		opts = append(opts, ossstats.WithFilter(filter))
+	}
+
+	if hasQuality {
+		opts = append(opts, ossstats.WithQualityFilter(quality))
+	}
+
+	if logHandler != nil {
+		opts = append(opts, ossstats.WithLogHandler(logHandler))
+	}
+
+	// Log lines and the progress bar would overwrite each other
+	if logHandler == nil && isTerminal(os.Stderr) {
+		opts = append(opts, ossstats.WithProgress((&progressBar{w: os.Stderr}).handle))
+	}
+
+	ctx := context.Background()
+
+	// Spans and recordings are flushed before the output is written, as
+	// errors exit early
+	flushTraces := func() {}
+	if *traceTo != "" {
+		tp, err := newTracerProvider(ctx, *traceTo, os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, ossstats.WithTracerProvider(tp))
+		flushTraces = func() {
+			if err := tp.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: exporting traces: %v\n", err)
+			}
+		}
+	}
+	flush := func() {
+		flushTraces()
+		if err := saveRecording(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: saving recording: %v\n", err)
+		}
 	}
 
 	client := ossstats.New(opts...)
 
+	if *dryRun {
+		plan, err := client.PlanContributions(ctx, *username)
+		flush()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printFetchPlan(os.Stdout, plan)
+		os.Exit(0)
+	}
+
 	// Fetch contributions
-	ctx := context.Background()
 	stats, err := client.GetContributions(ctx, *username)
+	flush()
 
 	// Handle errors
 	if err != nil {
-		// Check for partial results
-		if partialErr, ok := err.(*ossstats.ErrPartialResults); ok {
-			fmt.Fprintf(os.Stderr, "Warning: %v\n", partialErr)
-			stats = partialErr.Stats
-		} else if rateLimitErr, ok := err.(*ossstats.ErrRateLimited); ok {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", rateLimitErr)
-			os.Exit(1)
-		} else if authErr, ok := err.(*ossstats.ErrAuthentication); ok {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", authErr)
-			fmt.Fprintf(os.Stderr, "Hint: Provide a token with --token or set GITHUB_TOKEN\n")
-			os.Exit(1)
-		} else if notFoundErr, ok := err.(*ossstats.ErrNotFound); ok {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", notFoundErr)
-			os.Exit(1)
-		} else {
+		var partialErr *ossstats.ErrPartialResults
+		if !errors.As(err, &partialErr) {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			printErrorHint(err, *checkpoint)
+			os.Exit(exitCode(err))
 		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", partialErr)
+		stats = partialErr.Stats
 	}
 
-	if strings.TrimSpace(*output) != "" {
+	if *format == formatShields {
+		if err := writeShieldsEndpoints(badgeOption, strings.TrimSpace(*output), verbose, stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating shields endpoints: %v\n", err)
+			os.Exit(1)
+		}
+	} else if strings.TrimSpace(*output) != "" {
 		writeStatsToFile(output, stats)
 		if *verbose {
 			fmt.Fprintf(os.Stderr, "Output written to %s\n", *output)
 		}
 	} else if *generateBadge {
-		if err := writeBadge(badgeOption, badgeConfig.output, verbose, stats); err != nil {
+		if err := writeBadge(badgeOption, *badgeConfig, verbose, stats); err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating badge: %v\n", err)
 			os.Exit(1)
 		}
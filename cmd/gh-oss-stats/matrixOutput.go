@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+// MatrixConfig holds the flags for rendering many badges in one run
+type MatrixConfig struct {
+	enabled  bool
+	variants string
+	styles   string
+	themes   string
+	index    string
+}
+
+func (mc *MatrixConfig) registerMatrixFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&mc.enabled, "matrix", false, "Render every variant x style x theme combination into the --badge-output dir")
+	fs.StringVar(&mc.variants, "matrix-variants", "", "Comma-separated variants to render (default: all)")
+	fs.StringVar(&mc.styles, "matrix-styles", "", "Comma-separated styles to render (default: all)")
+	fs.StringVar(&mc.themes, "matrix-themes", "", "Comma-separated themes to render (default: all)")
+	fs.StringVar(&mc.index, "matrix-index", "README.md", "Gallery index file: *.md or *.html (empty: no index)")
+}
+
+// createMatrix parses the comma-separated matrix flags
+func createMatrix(conf MatrixConfig) (badge.Matrix, error) {
+	var m badge.Matrix
+
+	for _, name := range splitList(conf.variants) {
+		variant, err := badge.BadgeVariantFromName(name)
+		if err != nil {
+			return m, err
+		}
+		m.Variants = append(m.Variants, variant)
+	}
+	for _, name := range splitList(conf.styles) {
+		style, err := badge.BadgeStyleFromName(name)
+		if err != nil {
+			return m, err
+		}
+		m.Styles = append(m.Styles, style)
+	}
+	for _, name := range splitList(conf.themes) {
+		theme, err := badge.BadgeThemeFromName(name)
+		if err != nil {
+			return m, err
+		}
+		m.Themes = append(m.Themes, theme)
+	}
+
+	ext := strings.ToLower(filepath.Ext(conf.index))
+	if conf.index != "" && ext != ".md" && ext != ".html" {
+		return m, fmt.Errorf("invalid matrix index: %s (must end in .md or .html)", conf.index)
+	}
+
+	return m, nil
+}
+
+// writeBadgeMatrix renders the selected combinations into the badge output
+// directory as <variant>-<style>-<theme>.svg, plus a gallery index
+func writeBadgeMatrix(
+	opts badge.BadgeOptions,
+	conf BadgeConfig,
+	matrixConf MatrixConfig,
+	verbose *bool,
+	stats *ossstats.Stats,
+) error {
+	m, err := createMatrix(matrixConf)
+	if err != nil {
+		return err
+	}
+
+	// Fetch avatars once for all detailed badges
+	if opts.ShowAvatars {
+		detailed := opts
+		detailed.Style = badge.StyleDetailed
+		opts.Avatars = fetchAvatars(detailed, conf, verbose, stats)
+	}
+
+	badges, err := badge.RenderMatrix(stats, m, opts)
+	if err != nil {
+		return fmt.Errorf("failed to render badges: %w", err)
+	}
+
+	dir := conf.output
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, b := range badges {
+		if err := os.WriteFile(filepath.Join(dir, b.FileName), []byte(b.SVG), 0644); err != nil {
+			return fmt.Errorf("failed to write badge: %w", err)
+		}
+	}
+
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "%d badges written to %s\n", len(badges), dir)
+	}
+
+	if matrixConf.index == "" {
+		return nil
+	}
+
+	var index string
+	if strings.EqualFold(filepath.Ext(matrixConf.index), ".html") {
+		if index, err = badge.RenderGalleryHTML(stats.Username, badges); err != nil {
+			return err
+		}
+	} else {
+		index = badge.RenderGalleryMarkdown(stats.Username, badges)
+	}
+
+	indexFile := filepath.Join(dir, matrixConf.index)
+	if err := os.WriteFile(indexFile, []byte(index), 0644); err != nil {
+		return fmt.Errorf("failed to write gallery index: %w", err)
+	}
+
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "Gallery index written to %s\n", indexFile)
+	}
+
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// progressBarWidth is the number of cells in the bar
+const progressBarWidth = 30
+
+// phaseLabels names the fetch phases on the progress bar
+var phaseLabels = map[ossstats.Phase]string{
+	ossstats.PhaseSearch:       "Searching PRs",
+	ossstats.PhaseCommitCounts: "Counting commits",
+	ossstats.PhaseActivity:     "Searching activity",
+	ossstats.PhasePRDetails:    "Fetching PRs",
+	ossstats.PhaseRepoMetadata: "Fetching repos",
+}
+
+// progressBar redraws a single status line from progress events. It is meant
+// for terminals, where "\r" moves back to the start of the line.
+type progressBar struct {
+	w io.Writer
+}
+
+func (p *progressBar) handle(e ossstats.Event) {
+	switch e.Kind {
+	case ossstats.EventPhase:
+		if e.Phase == ossstats.PhaseDone {
+			p.clear()
+			return
+		}
+		p.draw(phaseLabels[e.Phase], 0, e.Total)
+	case ossstats.EventSearchPage, ossstats.EventPRFetched, ossstats.EventPRFailed, ossstats.EventRepoEnriched:
+		p.draw(phaseLabels[e.Phase], e.Done, e.Total)
+	case ossstats.EventRateLimitWait:
+		p.line(fmt.Sprintf("Waiting for the %s rate limit to reset at %s", e.Resource, e.ResetAt.Local().Format(time.TimeOnly)))
+	}
+}
+
+// draw shows label with a bar of done out of total, or only the label when
+// the total is unknown
+func (p *progressBar) draw(label string, done, total int) {
+	if total <= 0 {
+		p.line(label + "...")
+		return
+	}
+
+	filled := min(done, total) * progressBarWidth / total
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	p.line(fmt.Sprintf("%-18s %s %d/%d", label, bar, done, total))
+}
+
+// line replaces the current status line
+func (p *progressBar) line(s string) {
+	fmt.Fprintf(p.w, "\r\033[K%s", s)
+}
+
+func (p *progressBar) clear() {
+	fmt.Fprint(p.w, "\r\033[K")
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
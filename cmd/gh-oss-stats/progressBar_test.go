@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestProgressBar(t *testing.T) {
+	var buf bytes.Buffer
+	bar := &progressBar{w: &buf}
+
+	bar.handle(ossstats.Event{Kind: ossstats.EventPhase, Phase: ossstats.PhaseSearch})
+	if !strings.HasSuffix(buf.String(), "Searching PRs...") {
+		t.Errorf("phase line = %q", buf.String())
+	}
+
+	buf.Reset()
+	bar.handle(ossstats.Event{Kind: ossstats.EventPRFetched, Phase: ossstats.PhasePRDetails, Done: 15, Total: 30})
+	out := buf.String()
+	if !strings.HasPrefix(out, "\r\033[K") || !strings.HasSuffix(out, " 15/30") {
+		t.Errorf("progress line = %q", out)
+	}
+	if filled := strings.Count(out, "█"); filled != progressBarWidth/2 {
+		t.Errorf("filled cells = %d, want %d", filled, progressBarWidth/2)
+	}
+
+	buf.Reset()
+	bar.handle(ossstats.Event{Kind: ossstats.EventRateLimitWait, Resource: "search"})
+	if !strings.Contains(buf.String(), "Waiting for the search rate limit") {
+		t.Errorf("wait line = %q", buf.String())
+	}
+
+	buf.Reset()
+	bar.handle(ossstats.Event{Kind: ossstats.EventPhase, Phase: ossstats.PhaseDone})
+	if buf.String() != "\r\033[K" {
+		t.Errorf("done should clear the line, got %q", buf.String())
+	}
+}
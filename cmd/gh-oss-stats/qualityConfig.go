@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// QualityConfig holds the flags for dropping trivial and bot-assisted PRs
+type QualityConfig struct {
+	minLines      int
+	docsOnly      bool
+	bumps         bool
+	titlePatterns repeatableFlag
+	botCoAuthored bool
+}
+
+func (qc *QualityConfig) registerQualityFlags(fs *flag.FlagSet) {
+	fs.IntVar(&qc.minLines, "min-pr-lines", 0, "Exclude PRs with fewer changed (added + deleted) lines")
+	fs.BoolVar(&qc.docsOnly, "exclude-docs-only", false, "Exclude PRs that only touch docs/README files (1 extra API call per PR)")
+	fs.BoolVar(&qc.bumps, "exclude-dependency-bumps", false, "Exclude PRs titled like dependency bumps (Dependabot, Renovate, \"Bump x from a to b\")")
+	fs.Var(&qc.titlePatterns, "exclude-pr-titles", "Regular expression; exclude PRs whose title matches it (repeatable)")
+	fs.BoolVar(&qc.botCoAuthored, "exclude-bot-coauthored", false, "Exclude PRs with commits authored or co-authored by bots (1 extra API call per PR)")
+}
+
+// createQualityFilter builds the library quality filter, or returns false if
+// no quality flag is set
+func createQualityFilter(conf QualityConfig) (ossstats.QualityFilter, bool, error) {
+	filter := ossstats.QualityFilter{
+		MinChangedLines:      conf.minLines,
+		ExcludeDocsOnly:      conf.docsOnly,
+		ExcludeTitlePatterns: slices.Clone(conf.titlePatterns.items),
+		ExcludeBotCoAuthored: conf.botCoAuthored,
+	}
+	if conf.bumps {
+		filter.ExcludeTitlePatterns = append(filter.ExcludeTitlePatterns, ossstats.DependencyBumpPatterns...)
+	}
+
+	if conf.minLines < 0 {
+		return filter, false, fmt.Errorf("--min-pr-lines must be >= 0 (got: %d)", conf.minLines)
+	}
+	if err := filter.Validate(); err != nil {
+		return filter, false, err
+	}
+
+	set := conf.minLines != 0 || conf.docsOnly || conf.bumps || len(conf.titlePatterns.items) > 0 || conf.botCoAuthored
+	return filter, set, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestCreateQualityFilter(t *testing.T) {
+	filter, set, err := createQualityFilter(QualityConfig{
+		minLines:      5,
+		bumps:         true,
+		titlePatterns: repeatableFlag{items: []string{`^WIP\b`, `^v\d{1,3}$`}},
+	})
+	if err != nil {
+		t.Fatalf("createQualityFilter() error = %v", err)
+	}
+	if !set {
+		t.Fatal("createQualityFilter() should report the filter as set")
+	}
+	if filter.MinChangedLines != 5 {
+		t.Errorf("MinChangedLines = %d, want 5", filter.MinChangedLines)
+	}
+	if want := 2 + len(ossstats.DependencyBumpPatterns); len(filter.ExcludeTitlePatterns) != want {
+		t.Errorf("ExcludeTitlePatterns = %v, want %d patterns", filter.ExcludeTitlePatterns, want)
+	}
+
+	if _, set, _ := createQualityFilter(QualityConfig{}); set {
+		t.Error("createQualityFilter(empty) should report the filter as unset")
+	}
+	if _, _, err := createQualityFilter(QualityConfig{titlePatterns: repeatableFlag{items: []string{"("}}}); err == nil {
+		t.Error("createQualityFilter() should reject a malformed pattern")
+	}
+	if _, _, err := createQualityFilter(QualityConfig{minLines: -1}); err == nil {
+		t.Error("createQualityFilter() should reject a negative --min-pr-lines")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/replay"
+)
+
+// fixtureHTTPClient returns an HTTP client recording the GitHub API exchanges
+// of the run to the record file, or replaying them from the replay file, and
+// a function saving the recording. It returns a nil client when neither is
+// set.
+func fixtureHTTPClient(record, replayFrom, username, token string) (*http.Client, func() error, error) {
+	save := func() error { return nil }
+	switch {
+	case record != "" && replayFrom != "":
+		return nil, save, errors.New("--record and --replay can't be used together")
+	case record != "":
+		recorder := replay.NewRecorder(nil, username, token)
+		return &http.Client{Transport: recorder}, func() error { return recorder.Save(record) }, nil
+	case replayFrom != "":
+		fixture, err := replay.Load(replayFrom)
+		if err != nil {
+			return nil, save, err
+		}
+		return &http.Client{Transport: replay.NewReplayer(fixture, username)}, save, nil
+	}
+	return nil, save, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/replay"
+)
+
+func TestFixtureHTTPClient(t *testing.T) {
+	dir := t.TempDir()
+
+	client, save, err := fixtureHTTPClient("", "", "octocat", "")
+	if err != nil || client != nil || save() != nil {
+		t.Errorf("without files: client = %v, err = %v, want none", client, err)
+	}
+
+	if _, _, err := fixtureHTTPClient("a.json", "b.json", "octocat", ""); err == nil {
+		t.Error("--record with --replay should fail")
+	}
+
+	if _, _, err := fixtureHTTPClient("", filepath.Join(dir, "missing.json"), "octocat", ""); err == nil {
+		t.Error("--replay of a missing file should fail")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "` + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + `"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(dir, "recording.json")
+	client, save, err = fixtureHTTPClient(path, "", "octocat", "secret-token")
+	if err != nil {
+		t.Fatalf("fixtureHTTPClient() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/rate_limit", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("recorded request error = %v", err)
+	}
+	resp.Body.Close()
+	if err := save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("recording should not contain the token:\n%s", data)
+	}
+
+	client, _, err = fixtureHTTPClient("", path, "octocat", "")
+	if err != nil {
+		t.Fatalf("fixtureHTTPClient() error = %v", err)
+	}
+	if _, ok := client.Transport.(*replay.Replayer); !ok {
+		t.Errorf("--replay transport = %T, want *replay.Replayer", client.Transport)
+	}
+	resp, err = client.Get("https://api.github.com/rate_limit")
+	if err != nil {
+		t.Fatalf("replayed request error = %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/metrics"
+	"github.com/mabd-dev/gh-oss-stats/internal/server"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// serveCmd flag set
+var serveCmd = flag.NewFlagSet("serve", flag.ExitOnError)
+
+// Serve command flags
+var (
+	serveAddr        = serveCmd.String("addr", ":8080", "Address to listen on")
+	serveToken       = serveCmd.String("token", os.Getenv("GITHUB_TOKEN"), "GitHub token (default: $GITHUB_TOKEN)")
+	serveBaseURL     = serveCmd.String("base-url", "", "GitHub API URL, e.g. https://HOST/api/v3 for GitHub Enterprise or a fake-server (default: https://api.github.com)")
+	serveCacheTTL    = serveCmd.Duration("cache-ttl", server.DefaultTTL, "How long fetched stats stay fresh")
+	serveStaleTTL    = serveCmd.Duration("stale-ttl", server.DefaultStaleTTL, "How long stale stats are served while refreshing in the background")
+	serveIncludeLOC  = serveCmd.Bool("include-loc", ossstats.DefaultIncludeLOC, "Include LOC metrics")
+	serveMinStars    = serveCmd.Int("min-stars", ossstats.DefaultMinStars, "Minimum repo stars")
+	serveMaxPRs      = serveCmd.Int("max-prs", ossstats.DefaultMaxPRS, "Max PRs to fetch per user")
+	serveExcludeOrgs = serveCmd.String("exclude-orgs", "", "Comma-separated list of organizations to exclude")
+	serveTimeoutSec  = serveCmd.Int("timeout", int(ossstats.DefaultTimeout.Seconds()), "Timeout in seconds for fetching one user")
+	serveConcurrency = serveCmd.Int("concurrency", ossstats.DefaultConcurrency, "Max API requests in flight across all users (lowered automatically as the rate limit runs low)")
+	serveVerbose     = serveCmd.Bool("verbose", false, "Verbose logging to stderr")
+	serveLogFormat   = serveCmd.String("log-format", logFormatText, "Log format: text or json")
+	serveLogLevel    = serveCmd.String("log-level", "", "Log level: debug, info, warn or error (default: info, fetch logs only with --verbose)")
+	serveDebug       = serveCmd.Bool("debug", false, "Replay built-in recorded API responses instead of calling GitHub")
+	serveScenario    = serveCmd.String("debug-scenario", "", "Serve a built-in debug scenario instead of calling GitHub (implies --debug): "+strings.Join(ossstats.DebugScenarios(), ", "))
+	serveMetrics     = serveCmd.Bool("metrics", false, "Serve Prometheus metrics at /metrics")
+	serveTrace       = serveCmd.String("trace", "", "Export OpenTelemetry traces: stdout (to stderr) or otlp (OTEL_EXPORTER_OTLP_* settings)")
+	serveMetricUsers = serveCmd.String("metrics-users", "", "Comma-separated users whose stats are fetched every --cache-ttl for /metrics (implies --metrics)")
+	serveAllowUsers  = serveCmd.String("allow-users", "", "Comma-separated users whose stats are served; others get 403 (default: any user, don't expose the server publicly without it)")
+
+	serveIncludeCommits      = serveCmd.Bool("include-commits", false, "Count commits per PR without fetching LOC (1 GraphQL call per 100 PRs, needs a token)")
+	serveIncludeReviews      = serveCmd.Bool("include-reviews", false, "Count PRs reviewed on external repos")
+	serveIncludeIssues       = serveCmd.Bool("include-issues", false, "Count issues opened on external repos")
+	serveIncludeClosedIssues = serveCmd.Bool("include-closed-issues", false, "Count issues closed by merged PRs (1 GraphQL call per PR, needs a token)")
+	serveIncludeDiscussions  = serveCmd.Bool("include-discussions", false, "Count accepted discussion answers (needs a token)")
+	serveIncludePRStates     = serveCmd.Bool("include-pr-states", false, "Count open and closed-unmerged PRs and report acceptance rates")
+	serveIncludeCoAuthored   = serveCmd.Bool("include-coauthored-commits", false, "Count authored and co-authored commits that landed outside your PRs")
+)
+
+func init() {
+	serveCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats serve [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Serve live badges and stats JSON over HTTP.\n\n")
+		fmt.Fprintf(os.Stderr, "Endpoints:\n")
+		fmt.Fprintf(os.Stderr, "  GET /u/{user}/badge.svg?style=&variant=&theme=&sort=&limit=&locale=\n")
+		fmt.Fprintf(os.Stderr, "  GET /u/{user}/stats.json\n")
+		fmt.Fprintf(os.Stderr, "  GET /healthz\n")
+		fmt.Fprintf(os.Stderr, "  GET /metrics (with --metrics)\n\n")
+		fmt.Fprintf(os.Stderr, "Badge flags set the defaults for missing query parameters.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		serveCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Serve on port 8080 with a 6 hour cache\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats serve --addr :8080 --cache-ttl 6h\n\n")
+		fmt.Fprintf(os.Stderr, "  # Serve only your own badges\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats serve --allow-users mabd-dev\n\n")
+		fmt.Fprintf(os.Stderr, "  # Serve light-themed badges by default\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats serve --badge-theme light\n\n")
+		fmt.Fprintf(os.Stderr, "  # Export the stats of a team to Prometheus\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats serve --metrics-users alice,bob --cache-ttl 6h\n\n")
+	}
+}
+
+func runServeCmd(args []string) {
+	badgeConfig := newBadgeConfig()
+	badgeConfig.registerBadgeFlags(serveCmd)
+	filterConfig := &FilterConfig{}
+	filterConfig.registerFilterFlags(serveCmd)
+	qualityConfig := &QualityConfig{}
+	qualityConfig.registerQualityFlags(serveCmd)
+	loadConfig(serveCmd, args)
+	serveCmd.Parse(args)
+
+	if *serveCacheTTL <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --cache-ttl must be > 0 (got: %s)\n", *serveCacheTTL)
+		os.Exit(1)
+	}
+	if *serveStaleTTL < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --stale-ttl must be >= 0 (got: %s)\n", *serveStaleTTL)
+		os.Exit(1)
+	}
+	if *serveTimeoutSec <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --timeout must be > 0 seconds (got: %d)\n", *serveTimeoutSec)
+		os.Exit(1)
+	}
+	if *serveConcurrency <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --concurrency must be > 0 (got: %d)\n", *serveConcurrency)
+		os.Exit(1)
+	}
+
+	badgeOption, err := createBadgeOptions(*badgeConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	filter, hasFilter, err := createFilter(*filterConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	quality, hasQuality, err := createQualityFilter(*qualityConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *serveScenario != "" {
+		if !slices.Contains(ossstats.DebugScenarios(), *serveScenario) {
+			fmt.Fprintf(os.Stderr, "Error: --debug-scenario must be one of %s (got: %s)\n",
+				strings.Join(ossstats.DebugScenarios(), ", "), *serveScenario)
+			os.Exit(1)
+		}
+		*serveDebug = true
+	}
+
+	if *serveToken == "" && !*serveDebug {
+		fmt.Fprintf(os.Stderr, "Warning: No GitHub token provided. You'll hit rate limits quickly (60 requests/hour).\n")
+		fmt.Fprintf(os.Stderr, "Hint: Set GITHUB_TOKEN environment variable or use --token flag\n\n")
+	}
+
+	// Server logs are always on. Fetch logs need --verbose or --log-level.
+	level := *serveLogLevel
+	fetchLogs := level != "" || *serveVerbose
+	if level == "" {
+		level = "info"
+	}
+	logHandler, err := newLogHandler(os.Stderr, *serveLogFormat, level)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	logger := slog.NewLogLogger(logHandler, slog.LevelInfo)
+
+	opts := []ossstats.Option{
+		ossstats.WithLOC(*serveIncludeLOC),
+		ossstats.WithCommitCounts(*serveIncludeCommits),
+		ossstats.WithReviews(*serveIncludeReviews),
+		ossstats.WithIssues(*serveIncludeIssues),
+		ossstats.WithClosedIssues(*serveIncludeClosedIssues),
+		ossstats.WithDiscussionAnswers(*serveIncludeDiscussions),
+		ossstats.WithPRStates(*serveIncludePRStates),
+		ossstats.WithCoAuthoredCommits(*serveIncludeCoAuthored),
+		ossstats.WithMinStars(*serveMinStars),
+		ossstats.WithMaxPRs(*serveMaxPRs),
+		ossstats.WithTimeout(time.Duration(*serveTimeoutSec) * time.Second),
+		ossstats.WithConcurrency(*serveConcurrency),
+		ossstats.WithDebug(*serveDebug),
+	}
+
+	if *serveScenario != "" {
+		opts = append(opts, ossstats.WithDebugScenario(*serveScenario))
+	}
+
+	if *serveToken != "" {
+		opts = append(opts, ossstats.WithToken(*serveToken))
+	}
+
+	if *serveBaseURL != "" {
+		opts = append(opts, ossstats.WithBaseURL(*serveBaseURL))
+	}
+
+	if *serveExcludeOrgs != "" {
+		orgs := strings.Split(*serveExcludeOrgs, ",")
+		for i, org := range orgs {
+			orgs[i] = strings.TrimSpace(org)
+		}
+		opts = append(opts, ossstats.WithExcludeOrgs(orgs))
+	}
+
+	if hasFilter {
+		opts = append(opts, ossstats.WithFilter(filter))
+	}
+
+	if hasQuality {
+		opts = append(opts, ossstats.WithQualityFilter(quality))
+	}
+
+	if fetchLogs {
+		opts = append(opts, ossstats.WithLogHandler(logHandler))
+	}
+
+	var metricUsers []string
+	for _, user := range strings.Split(*serveMetricUsers, ",") {
+		if user = strings.TrimSpace(user); user != "" {
+			metricUsers = append(metricUsers, user)
+		}
+	}
+
+	var exporter *metrics.Exporter
+	if *serveMetrics || len(metricUsers) > 0 {
+		exporter = metrics.NewExporter()
+		opts = append(opts, ossstats.WithHTTPClient(&http.Client{Transport: exporter.Transport(nil)}))
+	}
+
+	if *serveTrace != "" {
+		tp, err := newTracerProvider(context.Background(), *serveTrace, os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer tp.Shutdown(context.Background())
+		opts = append(opts, ossstats.WithTracerProvider(tp))
+	}
+
+	// A negative stale TTL disables serving stale stats
+	staleTTL := *serveStaleTTL
+	if staleTTL == 0 {
+		staleTTL = -1
+	}
+
+	handler := server.New(ossstats.New(opts...), server.Config{
+		TTL:          *serveCacheTTL,
+		StaleTTL:     staleTTL,
+		BadgeOptions: badgeOption,
+		Logger:       logger,
+		Metrics:      exporter,
+		AllowUsers:   splitList(*serveAllowUsers),
+	})
+
+	httpServer := &http.Server{
+		Addr:              *serveAddr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Let in-flight requests finish on Ctrl+C / SIGTERM
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if len(metricUsers) > 0 {
+		go handler.Track(ctx, metricUsers, *serveCacheTTL)
+	}
+
+	if *serveAllowUsers == "" {
+		logger.Printf("Serving stats of any user; use --allow-users before exposing the server publicly")
+	}
+	logger.Printf("Listening on %s", *serveAddr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	<-shutdownDone
+	logger.Printf("Server stopped")
+}
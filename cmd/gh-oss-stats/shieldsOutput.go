@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+// Output formats
+const (
+	formatJSON    = "json"
+	formatSVG     = "svg"
+	formatShields = "shields"
+)
+
+// writeShieldsEndpoints writes one shields.io endpoint JSON file per metric
+// into dir, named <metric>.json (projects.json, prs.json, loc.json, stars.json)
+func writeShieldsEndpoints(
+	opts badge.BadgeOptions,
+	dir string,
+	verbose *bool,
+	stats *ossstats.Stats,
+) error {
+	if dir == "" {
+		dir = "."
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, metric := range badge.ShieldsMetrics {
+		endpoint, err := badge.RenderShields(stats, metric, opts)
+		if err != nil {
+			return fmt.Errorf("failed to render %s endpoint: %w", metric, err)
+		}
+
+		jsonData, err := json.MarshalIndent(endpoint, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s endpoint: %w", metric, err)
+		}
+
+		outputFile := filepath.Join(dir, string(metric)+".json")
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			return fmt.Errorf("failed to write %s endpoint: %w", metric, err)
+		}
+
+		if *verbose {
+			fmt.Fprintf(os.Stderr, "Shields endpoint written to %s\n", outputFile)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Trace exporters
+const (
+	traceExporterStdout = "stdout"
+	traceExporterOTLP   = "otlp"
+)
+
+// newTracerProvider creates a tracer provider sending spans to exporter:
+// stdout writes them as JSON to w, otlp sends them over OTLP/HTTP to the
+// endpoint of the standard OTEL_EXPORTER_OTLP_* variables (default:
+// localhost:4318).
+func newTracerProvider(ctx context.Context, exporter string, w io.Writer) (*sdktrace.TracerProvider, error) {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case traceExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	case traceExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("--trace must be stdout or otlp (got: %s)", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s trace exporter: %w", exporter, err)
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "gh-oss-stats"),
+		attribute.String("service.version", version),
+	)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	), nil
+}
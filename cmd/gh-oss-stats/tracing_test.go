@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	tp, err := newTracerProvider(ctx, traceExporterStdout, &buf)
+	if err != nil {
+		t.Fatalf("newTracerProvider() error = %v", err)
+	}
+	_, span := tp.Tracer("test").Start(ctx, "ossstats.GetContributions")
+	span.End()
+	if err := tp.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{`"Name": "ossstats.GetContributions"`, `"Value": "gh-oss-stats"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("exported spans missing %s:\n%s", want, out)
+		}
+	}
+
+	if _, err := newTracerProvider(ctx, "jaeger", &buf); err == nil {
+		t.Error("newTracerProvider() should reject unknown exporters")
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+// validateThemeCmd flag set
+var validateThemeCmd = flag.NewFlagSet("validate-theme", flag.ExitOnError)
+
+// Validate theme command flags
+var (
+	validateThemeName = validateThemeCmd.String("theme", "", "Built-in theme to validate (default: all built-in themes)")
+	validateThemeFile = validateThemeCmd.String("theme-file", "", "Path to a custom theme JSON file")
+)
+
+func init() {
+	validateThemeCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats badge validate-theme [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Check that theme text colors meet WCAG AA contrast (%.1f:1) against theme backgrounds.\n\n", badge.MinContrastAA)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		validateThemeCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Validate all built-in themes\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge validate-theme\n\n")
+		fmt.Fprintf(os.Stderr, "  # Validate a custom theme\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge validate-theme --theme-file my-theme.json\n\n")
+	}
+}
+
+func runValidateThemeCmd(args []string) {
+	validateThemeCmd.Parse(args)
+
+	*validateThemeName = strings.TrimSpace(*validateThemeName)
+	*validateThemeFile = strings.TrimSpace(*validateThemeFile)
+
+	themes := map[string]badge.ThemeColors{}
+	var names []string
+
+	switch {
+	case *validateThemeFile != "":
+		content, err := os.ReadFile(*validateThemeFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var colors badge.ThemeColors
+		if err := json.Unmarshal(content, &colors); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to parse theme file: %v\n", err)
+			os.Exit(1)
+		}
+		themes[*validateThemeFile] = colors
+		names = append(names, *validateThemeFile)
+	case *validateThemeName != "":
+		theme, err := badge.BadgeThemeFromName(*validateThemeName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		themes[string(theme)] = badge.GetThemeColors(theme)
+		names = append(names, string(theme))
+	default:
+		for _, theme := range badge.BadgeThemes {
+			themes[string(theme)] = badge.GetThemeColors(theme)
+			names = append(names, string(theme))
+		}
+	}
+
+	failed := false
+	for _, name := range names {
+		checks, err := badge.CheckThemeContrast(themes[name])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: theme %s: %v\n", name, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s\n", name)
+		for _, check := range checks {
+			fmt.Printf("  %s\n", check)
+			if !check.PassesAA {
+				failed = true
+			}
+		}
+	}
+
+	if failed {
+		fmt.Fprintf(os.Stderr, "Error: one or more themes are below WCAG AA contrast\n")
+		os.Exit(1)
+	}
+}
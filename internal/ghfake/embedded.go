@@ -0,0 +1,38 @@
+package ghfake
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// scenarios are the built-in scenario packs. Their pull requests and issues
+// have no author, so they match whichever user is searched for.
+//
+//go:embed scenarios/*.yaml
+var scenarios embed.FS
+
+// EmbeddedNames returns the names of the built-in scenarios, sorted
+func EmbeddedNames() []string {
+	files, _ := fs.Glob(scenarios, "scenarios/*.yaml")
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(path.Base(file), ".yaml"))
+	}
+	return names
+}
+
+// Embedded returns the built-in scenario called name
+func Embedded(name string) (*Scenario, error) {
+	data, err := scenarios.ReadFile("scenarios/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown scenario %q (available: %s)", name, strings.Join(EmbeddedNames(), ", "))
+	}
+	scenario, err := ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", name, err)
+	}
+	return scenario, nil
+}
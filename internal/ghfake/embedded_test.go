@@ -0,0 +1,68 @@
+package ghfake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestEmbedded(t *testing.T) {
+	names := EmbeddedNames()
+	if len(names) == 0 {
+		t.Fatal("EmbeddedNames() is empty")
+	}
+	for _, name := range names {
+		if _, err := Embedded(name); err != nil {
+			t.Errorf("Embedded(%q) error = %v", name, err)
+		}
+	}
+
+	if _, err := Embedded("missing"); err == nil {
+		t.Error("Embedded() should fail for an unknown scenario")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	scenario, err := LoadScenario("testdata/scenario.yaml")
+	if err != nil {
+		t.Fatalf("LoadScenario() error = %v", err)
+	}
+	client := &http.Client{Transport: New(scenario).Transport()}
+
+	resp, err := client.Get("https://api.github.com" + search("author:octocat type:pr is:merged -user:octocat", 1, 9))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result github.SearchIssuesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || result.TotalCount != 3 {
+		t.Fatalf("got %d with %d results, want 200 with 3", resp.StatusCode, result.TotalCount)
+	}
+	if url := result.Items[0].RepositoryURL; url != "https://api.github.com/repos/acme/widgets" {
+		t.Errorf("RepositoryURL = %s, want the requested host", url)
+	}
+}
+
+func TestTransportCanceled(t *testing.T) {
+	scenario, err := ParseScenario([]byte("latency: 1m"))
+	if err != nil {
+		t.Fatalf("ParseScenario() error = %v", err)
+	}
+	client := &http.Client{Transport: New(scenario).Transport()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/rate_limit", nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want the deadline", err)
+	}
+}
@@ -0,0 +1,254 @@
+package ghfake
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rate limit resources
+const (
+	resourceCore   = "core"
+	resourceSearch = "search"
+)
+
+// Pull request and issue states
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+// defaultRateLimits match GitHub's limits for an authenticated user
+var defaultRateLimits = map[string]RateLimit{
+	resourceCore:   {Limit: 5000, ResetIn: time.Hour},
+	resourceSearch: {Limit: 30, ResetIn: time.Minute},
+}
+
+// Scenario describes the data and behavior of a fake server
+type Scenario struct {
+	// Latency delays every response
+	Latency time.Duration `yaml:"latency"`
+
+	// RateLimits configures the core and search rate limits. Every request
+	// but /rate_limit uses up one request of its resource.
+	RateLimits map[string]RateLimit `yaml:"rateLimits"`
+
+	// Users are the logins that exist. When set, /users/{username} and
+	// searches by author fail for other logins, like GitHub's do for unknown
+	// users. Default: every login exists.
+	Users []string `yaml:"users"`
+
+	Repositories []Repository  `yaml:"repositories"`
+	PullRequests []PullRequest `yaml:"pullRequests"`
+	Issues       []Issue       `yaml:"issues"`
+
+	// Faults inject error responses
+	Faults []Fault `yaml:"faults"`
+}
+
+// RateLimit configures the budget of a rate limit resource
+type RateLimit struct {
+	Limit     int           `yaml:"limit"`
+	Remaining *int          `yaml:"remaining"` // Left in the first window. Default: Limit
+	ResetIn   time.Duration `yaml:"resetIn"`   // Window length. Default: 1h for core, 1m for search
+}
+
+// Repository is a repository served by /repos/{owner}/{repo}
+type Repository struct {
+	Name        string   `yaml:"name"` // owner/repo
+	Description string   `yaml:"description"`
+	Language    string   `yaml:"language"`
+	Stars       int      `yaml:"stars"`
+	Forks       int      `yaml:"forks"`
+	Topics      []string `yaml:"topics"`
+	Archived    bool     `yaml:"archived"`
+	Fork        bool     `yaml:"fork"`
+}
+
+// PullRequest is a pull request served by the issue search and
+// /repos/{owner}/{repo}/pulls/{number}
+type PullRequest struct {
+	Repo         string    `yaml:"repo"` // owner/repo
+	Number       int       `yaml:"number"`
+	Title        string    `yaml:"title"`
+	Author       string    `yaml:"author"` // Empty matches any author: qualifier
+	State        string    `yaml:"state"`  // open, closed or merged. Default: merged
+	CreatedAt    time.Time `yaml:"createdAt"`
+	ClosedAt     time.Time `yaml:"closedAt"` // Closed or merged. Default: a day after CreatedAt
+	Additions    int       `yaml:"additions"`
+	Deletions    int       `yaml:"deletions"`
+	Commits      int       `yaml:"commits"`
+	ChangedFiles int       `yaml:"changedFiles"`
+	Reviewers    []string  `yaml:"reviewers"` // Matched by reviewed-by:
+}
+
+// Issue is an issue served by the issue search
+type Issue struct {
+	Repo      string    `yaml:"repo"` // owner/repo
+	Number    int       `yaml:"number"`
+	Title     string    `yaml:"title"`
+	Author    string    `yaml:"author"` // Empty matches any author: qualifier
+	State     string    `yaml:"state"`  // open or closed. Default: open
+	CreatedAt time.Time `yaml:"createdAt"`
+	ClosedAt  time.Time `yaml:"closedAt"` // Default: a day after CreatedAt when closed
+}
+
+// Fault makes matching requests fail. Requests count from the first one
+// matching Endpoint, so a fault can hit mid-run.
+type Fault struct {
+	// Endpoint matches request paths with their parameters replaced, e.g.
+	// /repos/{owner}/{repo}/pulls/{number}. Empty matches every request.
+	Endpoint string `yaml:"endpoint"`
+
+	Status     int           `yaml:"status"`     // 401, 403, 429 or 5xx
+	After      int           `yaml:"after"`      // Matching requests served before failing
+	Times      int           `yaml:"times"`      // Failed requests before recovering. Default: all
+	RetryAfter time.Duration `yaml:"retryAfter"` // Sets the Retry-After header
+	Message    string        `yaml:"message"`    // Default: GitHub's message for Status
+}
+
+// LoadScenario reads a YAML scenario file
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading scenario: %w", err)
+	}
+	scenario, err := ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scenario, nil
+}
+
+// ParseScenario decodes and validates a YAML scenario, filling in defaults
+func ParseScenario(data []byte) (*Scenario, error) {
+	var scenario Scenario
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&scenario); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing scenario: %w", err)
+	}
+	if err := scenario.normalize(); err != nil {
+		return nil, err
+	}
+	return &scenario, nil
+}
+
+// normalize validates the scenario and fills in defaults
+func (s *Scenario) normalize() error {
+	if s.Latency < 0 {
+		return fmt.Errorf("latency must be >= 0 (got: %s)", s.Latency)
+	}
+
+	limits := make(map[string]RateLimit, len(defaultRateLimits))
+	for resource, limit := range defaultRateLimits {
+		limits[resource] = limit
+	}
+	for resource, limit := range s.RateLimits {
+		def, ok := defaultRateLimits[resource]
+		if !ok {
+			return fmt.Errorf("rateLimits: unknown resource %q (must be core or search)", resource)
+		}
+		if limit.Limit <= 0 {
+			limit.Limit = def.Limit
+		}
+		if limit.ResetIn <= 0 {
+			limit.ResetIn = def.ResetIn
+		}
+		if limit.Remaining != nil && (*limit.Remaining < 0 || *limit.Remaining > limit.Limit) {
+			return fmt.Errorf("rateLimits: %s remaining must be between 0 and %d (got: %d)", resource, limit.Limit, *limit.Remaining)
+		}
+		limits[resource] = limit
+	}
+	s.RateLimits = limits
+
+	for i, repo := range s.Repositories {
+		if !validRepoName(repo.Name) {
+			return fmt.Errorf("repositories[%d]: name must be owner/repo (got: %q)", i, repo.Name)
+		}
+	}
+
+	seen := make(map[string]bool)
+	number := func(repo string, n int) error {
+		if !validRepoName(repo) {
+			return fmt.Errorf("repo must be owner/repo (got: %q)", repo)
+		}
+		if n <= 0 {
+			return fmt.Errorf("number must be > 0 (got: %d)", n)
+		}
+		key := strings.ToLower(repo) + "#" + fmt.Sprint(n)
+		if seen[key] {
+			return fmt.Errorf("%s#%d is defined twice", repo, n)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	for i := range s.PullRequests {
+		pr := &s.PullRequests[i]
+		if err := number(pr.Repo, pr.Number); err != nil {
+			return fmt.Errorf("pullRequests[%d]: %w", i, err)
+		}
+		switch pr.State {
+		case "":
+			pr.State = StateMerged
+		case StateOpen, StateClosed, StateMerged:
+		default:
+			return fmt.Errorf("pullRequests[%d]: state must be open, closed or merged (got: %q)", i, pr.State)
+		}
+		if pr.State != StateOpen && pr.ClosedAt.IsZero() {
+			pr.ClosedAt = pr.CreatedAt.Add(24 * time.Hour)
+		}
+	}
+
+	for i := range s.Issues {
+		issue := &s.Issues[i]
+		if err := number(issue.Repo, issue.Number); err != nil {
+			return fmt.Errorf("issues[%d]: %w", i, err)
+		}
+		switch issue.State {
+		case "":
+			issue.State = StateOpen
+		case StateOpen, StateClosed:
+		default:
+			return fmt.Errorf("issues[%d]: state must be open or closed (got: %q)", i, issue.State)
+		}
+		if issue.State == StateClosed && issue.ClosedAt.IsZero() {
+			issue.ClosedAt = issue.CreatedAt.Add(24 * time.Hour)
+		}
+	}
+
+	for i, fault := range s.Faults {
+		switch {
+		case fault.Status == http.StatusUnauthorized, fault.Status == http.StatusForbidden,
+			fault.Status == http.StatusTooManyRequests, fault.Status >= 500 && fault.Status <= 599:
+		default:
+			return fmt.Errorf("faults[%d]: status must be 401, 403, 429 or 5xx (got: %d)", i, fault.Status)
+		}
+		if fault.After < 0 || fault.Times < 0 || fault.RetryAfter < 0 {
+			return fmt.Errorf("faults[%d]: after, times and retryAfter must be >= 0", i)
+		}
+	}
+
+	return nil
+}
+
+// userExists reports whether login is one of the scenario's users
+func (s *Scenario) userExists(login string) bool {
+	return len(s.Users) == 0 || slices.ContainsFunc(s.Users, func(u string) bool { return strings.EqualFold(u, login) })
+}
+
+// validRepoName reports whether name has the form owner/repo
+func validRepoName(name string) bool {
+	owner, repo, ok := strings.Cut(name, "/")
+	return ok && owner != "" && repo != "" && !strings.Contains(repo, "/")
+}
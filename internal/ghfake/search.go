@@ -0,0 +1,296 @@
+package ghfake
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+const (
+	defaultPerPage = 30
+	maxPerPage     = 100
+
+	// maxSearchResults is how far GitHub pages into search results
+	maxSearchResults = 1000
+)
+
+// qualifier is one key:value term of a search query, e.g. -user:octocat
+type qualifier struct {
+	key    string
+	value  string
+	negate bool
+}
+
+// searchItem is a pull request or issue as the search matches it
+type searchItem struct {
+	repo      string
+	number    int
+	title     string
+	author    string
+	state     string // open, closed or merged
+	pr        bool
+	reviewers []string
+	createdAt time.Time
+	closedAt  time.Time
+}
+
+// parseQuery splits a search query into qualifiers and free text terms. It
+// fails on qualifiers the fake doesn't know, so tests notice queries it
+// would answer wrongly.
+func parseQuery(query string) ([]qualifier, []string, error) {
+	var qualifiers []qualifier
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			terms = append(terms, strings.ToLower(field))
+			continue
+		}
+
+		q := qualifier{key: strings.ToLower(key), value: strings.ToLower(value)}
+		if strings.HasPrefix(q.key, "-") {
+			q.key = q.key[1:]
+			q.negate = true
+		}
+		switch q.key {
+		case "author", "user", "org", "repo", "reviewed-by":
+		case "type":
+			if q.value != "pr" && q.value != "issue" {
+				return nil, nil, fmt.Errorf("type must be pr or issue (got: %s)", value)
+			}
+		case "is":
+			switch q.value {
+			case "pr", "issue", "open", "closed", "merged", "unmerged":
+			default:
+				return nil, nil, fmt.Errorf("unsupported qualifier is:%s", value)
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported qualifier %s", key)
+		}
+		qualifiers = append(qualifiers, q)
+	}
+	return qualifiers, terms, nil
+}
+
+// matches reports whether item satisfies every qualifier and term
+func (item searchItem) matches(qualifiers []qualifier, terms []string) bool {
+	for _, q := range qualifiers {
+		if item.matchesQualifier(q) == q.negate {
+			return false
+		}
+	}
+	title := strings.ToLower(item.title)
+	for _, term := range terms {
+		if !strings.Contains(title, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func (item searchItem) matchesQualifier(q qualifier) bool {
+	owner, _, _ := strings.Cut(item.repo, "/")
+	switch q.key {
+	case "author":
+		return item.author == "" || strings.EqualFold(item.author, q.value)
+	case "user", "org":
+		return strings.EqualFold(owner, q.value)
+	case "repo":
+		return strings.EqualFold(item.repo, q.value)
+	case "reviewed-by":
+		return slices.ContainsFunc(item.reviewers, func(r string) bool { return strings.EqualFold(r, q.value) })
+	case "type":
+		return item.pr == (q.value == "pr")
+	}
+
+	// is:
+	switch q.value {
+	case "pr":
+		return item.pr
+	case "issue":
+		return !item.pr
+	case "open":
+		return item.state == StateOpen
+	case "closed":
+		return item.state != StateOpen
+	case "merged":
+		return item.state == StateMerged
+	default: // unmerged
+		return item.pr && item.state != StateMerged
+	}
+}
+
+func (item searchItem) updatedAt() time.Time {
+	if item.state == StateOpen {
+		return item.createdAt
+	}
+	return item.closedAt
+}
+
+// searchItems returns every pull request and issue of the scenario
+func (s *Server) searchItems() []searchItem {
+	items := make([]searchItem, 0, len(s.scenario.PullRequests)+len(s.scenario.Issues))
+	for _, pr := range s.scenario.PullRequests {
+		items = append(items, searchItem{
+			repo: pr.Repo, number: pr.Number, title: pr.Title, author: pr.Author, state: pr.State,
+			pr: true, reviewers: pr.Reviewers, createdAt: pr.CreatedAt, closedAt: pr.ClosedAt,
+		})
+	}
+	for _, issue := range s.scenario.Issues {
+		items = append(items, searchItem{
+			repo: issue.Repo, number: issue.Number, title: issue.Title, author: issue.Author, state: issue.State,
+			createdAt: issue.CreatedAt, closedAt: issue.ClosedAt,
+		})
+	}
+	return items
+}
+
+func (s *Server) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	if params.Get("q") == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: q is missing")
+		return
+	}
+	qualifiers, terms, err := parseQuery(params.Get("q"))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: "+err.Error())
+		return
+	}
+
+	for _, q := range qualifiers {
+		if q.key == "author" && !q.negate && !s.scenario.userExists(q.value) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: The listed users cannot be searched "+
+				"either because the users do not exist or you do not have permission to view the users.")
+			return
+		}
+	}
+
+	page, perPage, err := pagination(params)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: "+err.Error())
+		return
+	}
+	if (page-1)*perPage >= maxSearchResults {
+		writeError(w, http.StatusUnprocessableEntity, "Only the first 1000 search results are available")
+		return
+	}
+
+	var matched []searchItem
+	for _, item := range s.searchItems() {
+		if item.matches(qualifiers, terms) {
+			matched = append(matched, item)
+		}
+	}
+
+	// Most recently updated first, like the sort=updated the client asks for
+	slices.SortFunc(matched, func(a, b searchItem) int {
+		return cmp.Or(
+			b.updatedAt().Compare(a.updatedAt()),
+			strings.Compare(a.repo, b.repo),
+			cmp.Compare(b.number, a.number),
+		)
+	})
+
+	reachable := min(len(matched), maxSearchResults)
+	start := min((page-1)*perPage, reachable)
+	end := min(start+perPage, reachable)
+
+	response := github.SearchIssuesResponse{TotalCount: len(matched), Items: []github.Issue{}}
+	for _, item := range matched[start:end] {
+		response.Items = append(response.Items, item.issue(apiBaseURL(r)))
+	}
+
+	lastPage := max(1, (reachable+perPage-1)/perPage)
+	if link := linkHeader(r, page, lastPage); link != "" {
+		w.Header().Set("Link", link)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// issue returns item as the search API returns it
+func (item searchItem) issue(baseURL string) github.Issue {
+	kind := "issues"
+	if item.pr {
+		kind = "pull"
+	}
+	issue := github.Issue{
+		Number:        item.number,
+		Title:         item.title,
+		State:         StateOpen,
+		CreatedAt:     item.createdAt,
+		UpdatedAt:     item.updatedAt(),
+		RepositoryURL: baseURL + "/repos/" + item.repo,
+		HTMLURL:       fmt.Sprintf("https://github.com/%s/%s/%d", item.repo, kind, item.number),
+		User:          github.User{Login: item.author},
+	}
+	if item.state != StateOpen {
+		closedAt := item.closedAt
+		issue.State = StateClosed
+		issue.ClosedAt = &closedAt
+	}
+	if item.pr {
+		issue.PullRequest = &github.PullRequestRef{
+			URL:     fmt.Sprintf("%s/repos/%s/pulls/%d", baseURL, item.repo, item.number),
+			HTMLURL: issue.HTMLURL,
+		}
+		if item.state == StateMerged {
+			issue.PullRequest.MergedAt = issue.ClosedAt
+		}
+	}
+	return issue
+}
+
+// pagination returns the page and per_page parameters
+func pagination(params url.Values) (page, perPage int, err error) {
+	page, perPage = 1, defaultPerPage
+	if v := params.Get("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("page must be a positive number (got: %s)", v)
+		}
+	}
+	if v := params.Get("per_page"); v != "" {
+		if perPage, err = strconv.Atoi(v); err != nil || perPage < 1 {
+			return 0, 0, fmt.Errorf("per_page must be a positive number (got: %s)", v)
+		}
+		perPage = min(perPage, maxPerPage)
+	}
+	return page, perPage, nil
+}
+
+// linkHeader returns the Link header pointing to the other pages of the
+// search, as GitHub sends it
+func linkHeader(r *http.Request, page, lastPage int) string {
+	pageURL := func(n int) string {
+		params := r.URL.Query()
+		params.Set("page", strconv.Itoa(n))
+		return fmt.Sprintf("<%s%s?%s>", apiBaseURL(r), r.URL.Path, params.Encode())
+	}
+
+	var links []string
+	if page > 1 {
+		links = append(links, pageURL(page-1)+`; rel="prev"`)
+	}
+	if page < lastPage {
+		links = append(links, pageURL(page+1)+`; rel="next"`, pageURL(lastPage)+`; rel="last"`)
+	}
+	if page > 1 {
+		links = append(links, pageURL(1)+`; rel="first"`)
+	}
+	return strings.Join(links, ", ")
+}
+
+// apiBaseURL returns the URL the server was reached at
+func apiBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
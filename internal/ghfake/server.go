@@ -0,0 +1,304 @@
+// Package ghfake is a fake of the subset of the GitHub REST API that
+// gh-oss-stats uses, backed by a scenario file. It lets tests and CI exercise
+// the real HTTP client offline, including rate limits, errors and latency.
+package ghfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// docsURL is the documentation_url of error responses
+const docsURL = "https://docs.github.com/rest"
+
+// Server serves:
+//
+//	GET /search/issues?q=&page=&per_page=
+//	GET /repos/{owner}/{repo}
+//	GET /repos/{owner}/{repo}/pulls/{number}
+//	GET /users/{username}
+//	GET /rate_limit
+type Server struct {
+	scenario *Scenario
+	mux      *http.ServeMux
+
+	// now is replaced in tests
+	now func() time.Time
+
+	mu       sync.Mutex
+	limits   map[string]*rateLimitState
+	faultHit []int // Matching requests seen by each fault
+	requests int
+}
+
+// rateLimitState is the current window of a rate limit resource
+type rateLimitState struct {
+	RateLimit
+	remaining int
+	reset     time.Time
+}
+
+// New creates a Server for scenario, which must come from ParseScenario or
+// LoadScenario
+func New(scenario *Scenario) *Server {
+	s := &Server{
+		scenario: scenario,
+		mux:      http.NewServeMux(),
+		now:      time.Now,
+		limits:   make(map[string]*rateLimitState),
+		faultHit: make([]int, len(scenario.Faults)),
+	}
+
+	s.mux.HandleFunc("GET /search/issues", s.handleSearchIssues)
+	s.mux.HandleFunc("GET /repos/{owner}/{repo}", s.handleRepository)
+	s.mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}", s.handlePullRequest)
+	s.mux.HandleFunc("GET /users/{username}", s.handleUser)
+	s.mux.HandleFunc("GET /rate_limit", s.handleRateLimit)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+
+	return s
+}
+
+// ServeHTTP implements http.Handler. Requests are delayed by the scenario
+// latency, then fail if a fault or the rate limit says so.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.scenario.Latency > 0 {
+		timer := time.NewTimer(s.scenario.Latency)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	resource := resourceCore
+	if strings.HasPrefix(r.URL.Path, "/search/") {
+		resource = resourceSearch
+	}
+
+	s.mu.Lock()
+	s.requests++
+	w.Header().Set(github.RequestIDHeader, fmt.Sprintf("FAKE:%04X", s.requests))
+
+	fault := s.fault(r)
+	limited := false
+	if r.URL.Path != "/rate_limit" {
+		limit := s.rateLimit(resource)
+		if fault == nil {
+			if limit.remaining == 0 {
+				limited = true
+			} else {
+				limit.remaining--
+			}
+		}
+		setRateLimitHeaders(w.Header(), resource, limit)
+	}
+	s.mu.Unlock()
+
+	switch {
+	case fault != nil:
+		writeFault(w, fault)
+	case limited:
+		writeError(w, http.StatusForbidden, "API rate limit exceeded. Wait for the reset or use a token with a higher limit.")
+	default:
+		s.mux.ServeHTTP(w, r)
+	}
+}
+
+// fault returns the fault failing r, if any. s.mu must be held.
+func (s *Server) fault(r *http.Request) *Fault {
+	endpoint := github.Endpoint(r.URL.Path)
+	var failed *Fault
+	for i := range s.scenario.Faults {
+		fault := &s.scenario.Faults[i]
+		if fault.Endpoint != "" && fault.Endpoint != endpoint {
+			continue
+		}
+		s.faultHit[i]++
+		hit := s.faultHit[i]
+		if failed == nil && hit > fault.After && (fault.Times == 0 || hit <= fault.After+fault.Times) {
+			failed = fault
+		}
+	}
+	return failed
+}
+
+// rateLimit returns the current window of resource, starting a new one when
+// the last has reset. s.mu must be held.
+func (s *Server) rateLimit(resource string) *rateLimitState {
+	now := s.now()
+	limit, ok := s.limits[resource]
+	if !ok {
+		conf := s.scenario.RateLimits[resource]
+		limit = &rateLimitState{RateLimit: conf, remaining: conf.Limit, reset: now.Add(conf.ResetIn)}
+		if conf.Remaining != nil {
+			limit.remaining = *conf.Remaining
+		}
+		s.limits[resource] = limit
+	}
+	if !now.Before(limit.reset) {
+		limit.remaining = limit.Limit
+		limit.reset = now.Add(limit.ResetIn)
+	}
+	return limit
+}
+
+func (l *rateLimitState) response() github.RateLimit {
+	return github.RateLimit{
+		Limit:     l.Limit,
+		Remaining: l.remaining,
+		Reset:     l.reset.Unix(),
+		Used:      l.Limit - l.remaining,
+	}
+}
+
+func setRateLimitHeaders(h http.Header, resource string, limit *rateLimitState) {
+	h.Set(github.RateLimitLimitHeader, strconv.Itoa(limit.Limit))
+	h.Set(github.RateLimitRemainingHeader, strconv.Itoa(limit.remaining))
+	h.Set(github.RateLimitResetHeader, strconv.FormatInt(limit.reset.Unix(), 10))
+	h.Set("X-RateLimit-Used", strconv.Itoa(limit.Limit-limit.remaining))
+	h.Set(github.RateLimitResourceHeader, resource)
+}
+
+func (s *Server) handleRateLimit(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	response := github.RateLimitResponse{Resources: github.RateLimitResources{
+		Core:   s.rateLimit(resourceCore).response(),
+		Search: s.rateLimit(resourceSearch).response(),
+	}}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	login := r.PathValue("username")
+	if !s.scenario.userExists(login) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, github.User{Login: login, Type: "User"})
+}
+
+func (s *Server) handleRepository(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("owner") + "/" + r.PathValue("repo")
+	for _, repo := range s.scenario.Repositories {
+		if strings.EqualFold(repo.Name, name) {
+			writeJSON(w, http.StatusOK, repo.response())
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) handlePullRequest(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("owner") + "/" + r.PathValue("repo")
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	for _, pr := range s.scenario.PullRequests {
+		if strings.EqualFold(pr.Repo, name) && pr.Number == number {
+			writeJSON(w, http.StatusOK, pr.response())
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (repo Repository) response() github.Repository {
+	owner, name, _ := strings.Cut(repo.Name, "/")
+	return github.Repository{
+		Name:            name,
+		FullName:        repo.Name,
+		Owner:           github.User{Login: owner},
+		Description:     repo.Description,
+		HTMLURL:         "https://github.com/" + repo.Name,
+		Fork:            repo.Fork,
+		Archived:        repo.Archived,
+		Topics:          repo.Topics,
+		StargazersCount: repo.Stars,
+		Language:        repo.Language,
+		ForksCount:      repo.Forks,
+		DefaultBranch:   "main",
+	}
+}
+
+func (pr PullRequest) response() github.PullRequest {
+	response := github.PullRequest{
+		Number:       pr.Number,
+		State:        StateOpen,
+		Title:        pr.Title,
+		User:         github.User{Login: pr.Author},
+		CreatedAt:    pr.CreatedAt,
+		UpdatedAt:    pr.updatedAt(),
+		Merged:       pr.State == StateMerged,
+		Commits:      pr.Commits,
+		Additions:    pr.Additions,
+		Deletions:    pr.Deletions,
+		ChangedFiles: pr.ChangedFiles,
+		HTMLURL:      fmt.Sprintf("https://github.com/%s/pull/%d", pr.Repo, pr.Number),
+	}
+	if pr.State != StateOpen {
+		closedAt := pr.ClosedAt
+		response.State = StateClosed
+		response.ClosedAt = &closedAt
+		if pr.State == StateMerged {
+			response.MergedAt = &closedAt
+		}
+	}
+	return response
+}
+
+func (pr PullRequest) updatedAt() time.Time {
+	if pr.State == StateOpen {
+		return pr.CreatedAt
+	}
+	return pr.ClosedAt
+}
+
+// writeFault writes the error response of fault
+func writeFault(w http.ResponseWriter, fault *Fault) {
+	if fault.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+	}
+
+	message := fault.Message
+	if message == "" {
+		switch fault.Status {
+		case http.StatusUnauthorized:
+			message = "Bad credentials"
+		case http.StatusForbidden, http.StatusTooManyRequests:
+			message = "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."
+		default:
+			message = "Server Error"
+		}
+	}
+	writeError(w, fault.Status, message)
+}
+
+// writeError writes a GitHub-style error body
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": docsURL,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
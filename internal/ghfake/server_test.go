@@ -0,0 +1,324 @@
+package ghfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func newTestServer(t *testing.T, scenario string) (*Server, *httptest.Server) {
+	t.Helper()
+
+	s, err := ParseScenario([]byte(scenario))
+	if err != nil {
+		t.Fatalf("ParseScenario() error = %v", err)
+	}
+	fake := New(s)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func get(t *testing.T, server *httptest.Server, path string, result any) *http.Response {
+	t.Helper()
+
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", path, err)
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			t.Fatalf("GET %s: decoding response: %v", path, err)
+		}
+	}
+	return resp
+}
+
+func search(query string, page, perPage int) string {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+	return "/search/issues?" + params.Encode()
+}
+
+func TestSearchIssues(t *testing.T) {
+	scenario, err := LoadScenario("testdata/scenario.yaml")
+	if err != nil {
+		t.Fatalf("LoadScenario() error = %v", err)
+	}
+	server := httptest.NewServer(New(scenario))
+	defer server.Close()
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"author:octocat type:pr is:merged -user:octocat", []int{15, 12, 3}},
+		{"author:octocat type:pr is:merged", []int{15, 12, 3, 1}},
+		{"author:octocat type:pr is:open -user:octocat", []int{4}},
+		{"author:octocat type:pr is:closed is:unmerged -user:octocat", []int{5}},
+		{"author:octocat type:pr is:merged -user:octocat -org:acme", nil},
+		{"type:pr reviewed-by:hubot -author:hubot", []int{15}},
+		{"type:issue author:octocat -user:octocat", []int{11}},
+		{"author:OctoCat repo:acme/gadgets is:merged", []int{3}},
+		{"author:octocat widget", []int{15, 12, 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var result github.SearchIssuesResponse
+			resp := get(t, server, search(tt.query, 1, 9), &result)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d", resp.StatusCode)
+			}
+
+			var got []int
+			for _, item := range result.Items {
+				got = append(got, item.Number)
+			}
+			if result.TotalCount != len(tt.want) || !slices.Equal(got, tt.want) {
+				t.Errorf("got %v (total %d), want %v", got, result.TotalCount, tt.want)
+			}
+		})
+	}
+
+	var result github.SearchIssuesResponse
+	get(t, server, search("author:octocat type:pr is:merged -user:octocat", 1, 9), &result)
+	item := result.Items[0]
+	if item.PullRequest == nil || item.PullRequest.MergedAt == nil {
+		t.Fatalf("merged PR should have a merge time: %+v", item)
+	}
+	if owner, repo, _ := github.ParseRepoURL(item.RepositoryURL); owner != "acme" || repo != "widgets" {
+		t.Errorf("RepositoryURL = %s", item.RepositoryURL)
+	}
+}
+
+func TestSearchIssuesPagination(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("pullRequests:\n")
+	for n := 1; n <= 5; n++ {
+		fmt.Fprintf(&b, "  - {repo: acme/widgets, author: octocat, number: %d, createdAt: 2024-01-0%dT00:00:00Z}\n", n, n)
+	}
+	_, server := newTestServer(t, b.String())
+
+	var pages [][]int
+	for page := 1; page <= 3; page++ {
+		var result github.SearchIssuesResponse
+		resp := get(t, server, search("author:octocat", page, 2), &result)
+		if result.TotalCount != 5 {
+			t.Errorf("page %d: TotalCount = %d, want 5", page, result.TotalCount)
+		}
+
+		links := github.ParseLinkHeader(resp.Header.Get("Link"))
+		if _, ok := links["next"]; ok != (page < 3) {
+			t.Errorf("page %d: Link = %q", page, resp.Header.Get("Link"))
+		}
+
+		var numbers []int
+		for _, item := range result.Items {
+			numbers = append(numbers, item.Number)
+		}
+		pages = append(pages, numbers)
+	}
+
+	want := [][]int{{5, 4}, {3, 2}, {1}}
+	for i := range want {
+		if !slices.Equal(pages[i], want[i]) {
+			t.Errorf("pages = %v, want %v", pages, want)
+			break
+		}
+	}
+}
+
+func TestSearchIssuesInvalidQuery(t *testing.T) {
+	_, server := newTestServer(t, "")
+
+	for _, path := range []string{
+		"/search/issues",
+		search("author:octocat label:bug", 1, 9),
+		search("is:draft", 1, 9),
+		"/search/issues?q=author:octocat&page=11&per_page=100",
+	} {
+		if resp := get(t, server, path, nil); resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("GET %s status = %d, want 422", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestRepositoryAndPullRequest(t *testing.T) {
+	scenario, err := LoadScenario("testdata/scenario.yaml")
+	if err != nil {
+		t.Fatalf("LoadScenario() error = %v", err)
+	}
+	server := httptest.NewServer(New(scenario))
+	defer server.Close()
+
+	var repo github.Repository
+	get(t, server, "/repos/Acme/Widgets", &repo)
+	if repo.FullName != "acme/widgets" || repo.StargazersCount != 1200 || repo.Owner.Login != "acme" || len(repo.Topics) != 2 {
+		t.Errorf("repository = %+v", repo)
+	}
+
+	var pr github.PullRequest
+	get(t, server, "/repos/acme/widgets/pulls/15", &pr)
+	if !pr.Merged || pr.Additions != 120 || pr.Commits != 4 || pr.MergedAt == nil {
+		t.Errorf("pull request = %+v", pr)
+	}
+
+	var closed github.PullRequest
+	get(t, server, "/repos/acme/gadgets/pulls/5", &closed)
+	if closed.Merged || closed.State != "closed" || closed.MergedAt != nil {
+		t.Errorf("closed pull request = %+v", closed)
+	}
+
+	for _, path := range []string{"/repos/acme/missing", "/repos/acme/widgets/pulls/99", "/repos/acme/widgets/pulls/x", "/orgs/acme"} {
+		var body map[string]string
+		if resp := get(t, server, path, &body); resp.StatusCode != http.StatusNotFound || body["message"] != "Not Found" {
+			t.Errorf("GET %s = %d %v, want 404", path, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestUsers(t *testing.T) {
+	_, anyone := newTestServer(t, "")
+	var user github.User
+	if resp := get(t, anyone, "/users/hubot", &user); resp.StatusCode != http.StatusOK || user.Login != "hubot" {
+		t.Errorf("GET /users/hubot = %d %+v, want every user to exist", resp.StatusCode, user)
+	}
+
+	_, server := newTestServer(t, "users: [octocat]")
+	if resp := get(t, server, "/users/OctoCat", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /users/OctoCat status = %d, want 200", resp.StatusCode)
+	}
+	if resp := get(t, server, "/users/ghost", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /users/ghost status = %d, want 404", resp.StatusCode)
+	}
+
+	var body map[string]string
+	resp := get(t, server, search("author:ghost type:pr", 1, 9), &body)
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(body["message"], "users do not exist") {
+		t.Errorf("search by an unknown author = %d %v, want 422", resp.StatusCode, body)
+	}
+	if resp := get(t, server, search("author:octocat -author:ghost", 1, 9), nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("search by octocat status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	fake, server := newTestServer(t, `
+rateLimits:
+  search: {limit: 10, remaining: 2, resetIn: 1m}
+`)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake.now = func() time.Time { return now }
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		resp := get(t, server, search("author:octocat", 1, 9), nil)
+		if resp.StatusCode != http.StatusOK || resp.Header.Get(github.RateLimitRemainingHeader) != wantRemaining {
+			t.Errorf("request %d: status %d, remaining %s, want 200 and %s", i, resp.StatusCode,
+				resp.Header.Get(github.RateLimitRemainingHeader), wantRemaining)
+		}
+	}
+
+	resp := get(t, server, search("author:octocat", 1, 9), nil)
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("request past the limit: status %d, want 403", resp.StatusCode)
+	}
+	if reset := resp.Header.Get(github.RateLimitResetHeader); reset != "1704067260" {
+		t.Errorf("reset = %s, want a minute after the first request", reset)
+	}
+
+	// Core requests have their own budget
+	if resp := get(t, server, "/repos/acme/widgets", nil); resp.Header.Get(github.RateLimitResourceHeader) != "core" ||
+		resp.Header.Get(github.RateLimitRemainingHeader) != "4999" {
+		t.Errorf("core request headers = %v", resp.Header)
+	}
+
+	var limits github.RateLimitResponse
+	get(t, server, "/rate_limit", &limits)
+	if limits.Resources.Search.Remaining != 0 || limits.Resources.Search.Limit != 10 || limits.Resources.Core.Used != 1 {
+		t.Errorf("rate_limit = %+v", limits.Resources)
+	}
+
+	now = now.Add(time.Minute)
+	if resp := get(t, server, search("author:octocat", 1, 9), nil); resp.StatusCode != http.StatusOK ||
+		resp.Header.Get(github.RateLimitRemainingHeader) != "9" {
+		t.Errorf("after reset: status %d, remaining %s", resp.StatusCode, resp.Header.Get(github.RateLimitRemainingHeader))
+	}
+}
+
+func TestFaults(t *testing.T) {
+	_, server := newTestServer(t, `
+faults:
+  - endpoint: /repos/{owner}/{repo}/pulls/{number}
+    status: 502
+    after: 1
+    times: 2
+  - endpoint: /search/issues
+    status: 403
+    retryAfter: 60s
+`)
+
+	var statuses []int
+	for range 4 {
+		statuses = append(statuses, get(t, server, "/repos/acme/widgets/pulls/1", nil).StatusCode)
+	}
+	if want := []int{404, 502, 502, 404}; !slices.Equal(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+
+	var body map[string]string
+	resp := get(t, server, search("author:octocat", 1, 9), &body)
+	if resp.StatusCode != http.StatusForbidden || resp.Header.Get("Retry-After") != "60" ||
+		!strings.Contains(body["message"], "secondary rate limit") {
+		t.Errorf("secondary rate limit = %d %v %v", resp.StatusCode, resp.Header, body)
+	}
+	if resp.Header.Get(github.RateLimitRemainingHeader) != "30" {
+		t.Errorf("failed requests should not use up the rate limit, remaining = %s",
+			resp.Header.Get(github.RateLimitRemainingHeader))
+	}
+}
+
+func TestLatency(t *testing.T) {
+	_, server := newTestServer(t, "latency: 50ms")
+
+	start := time.Now()
+	get(t, server, "/rate_limit", nil)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response took %s, want at least 50ms", elapsed)
+	}
+}
+
+func TestParseScenarioErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		want     string
+	}{
+		{"unknown field", "pullrequests: []", "field pullrequests not found"},
+		{"unknown resource", "rateLimits: {graphql: {limit: 1}}", "unknown resource"},
+		{"remaining above limit", "rateLimits: {search: {limit: 5, remaining: 6}}", "remaining must be between"},
+		{"bad repo name", "repositories: [{name: widgets}]", "owner/repo"},
+		{"bad state", "pullRequests: [{repo: a/b, number: 1, state: draft}]", "state must be"},
+		{"duplicate number", "pullRequests: [{repo: a/b, number: 1}]\nissues: [{repo: A/b, number: 1}]", "defined twice"},
+		{"bad fault status", "faults: [{status: 404}]", "status must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScenario([]byte(tt.scenario))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseScenario() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
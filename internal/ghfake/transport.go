@@ -0,0 +1,51 @@
+package ghfake
+
+import (
+	"cmp"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Transport returns a RoundTripper that serves requests with s in process,
+// whatever host they are sent to, so a client can use the fake without a
+// listening server.
+func (s *Server) Transport() http.RoundTripper {
+	return transport{server: s}
+}
+
+type transport struct {
+	server *Server
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	ctx := req.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fill in what the server side of a connection would
+	r := req.Clone(ctx)
+	r.Host = cmp.Or(req.Host, req.URL.Host)
+	r.RequestURI = req.URL.RequestURI()
+	if req.URL.Scheme == "https" {
+		r.TLS = &tls.ConnectionState{}
+	}
+	if r.Body == nil {
+		r.Body = http.NoBody
+	}
+
+	rec := httptest.NewRecorder()
+	t.server.ServeHTTP(rec, r)
+
+	// The latency wait ends early without a response when ctx is done
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
@@ -1,13 +1,20 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 const (
@@ -16,6 +23,9 @@ const (
 
 	// APIVersion is the GitHub API version header value
 	APIVersion = "2022-11-28"
+
+	// tracerName identifies the spans of API requests
+	tracerName = "github.com/mabd-dev/gh-oss-stats/internal/github"
 )
 
 // APIClient is a low-level GitHub API client.
@@ -23,21 +33,94 @@ type APIClient struct {
 	httpClient *http.Client
 	token      string
 	baseURL    string
+	scheduler  *Scheduler
+	tracer     trace.Tracer
 }
 
-// NewAPIClient creates a new GitHub API client.
-func NewAPIClient(httpClient *http.Client, token string) *APIClient {
+// NewAPIClient creates a new GitHub API client. Requests are paced by the
+// scheduler, or sent right away when it is nil.
+func NewAPIClient(httpClient *http.Client, token string, scheduler *Scheduler) *APIClient {
 	return &APIClient{
 		httpClient: httpClient,
 		token:      token,
 		baseURL:    GitHubAPIBaseURL,
+		scheduler:  scheduler,
+		tracer:     noop.NewTracerProvider().Tracer(tracerName),
 	}
 }
 
+// SetBaseURL sends requests to another API server instead of GitHubAPIBaseURL
+func (c *APIClient) SetBaseURL(baseURL string) {
+	c.baseURL = baseURL
+}
+
+// SetTracerProvider records a span for every request with a tracer from tp
+func (c *APIClient) SetTracerProvider(tp trace.TracerProvider) {
+	c.tracer = tp.Tracer(tracerName)
+}
+
 // doRequest performs an HTTP request with proper authentication and headers.
+// When the rate limit budget runs out mid-request and resets before the
+// context deadline, the request is retried once after the reset.
 func (c *APIClient) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	endpoint := Endpoint(path)
+	ctx, span := c.tracer.Start(ctx, method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("github.endpoint", endpoint),
+			attribute.String("github.rate_limit.resource", string(resourceFor(path))),
+		))
+	defer span.End()
+
+	// Keep the body to send it again on retry
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+	}
+
+	retries := 0
+	resp, err := c.send(ctx, method, path, payload)
+	if err == nil && c.scheduler != nil && c.canWaitForReset(ctx, resp) {
+		resp.Body.Close()
+		retries++
+		resp, err = c.send(ctx, method, path, payload)
+	}
+
+	recordResponse(span, resp, err, retries)
+	return resp, err
+}
+
+// recordResponse adds the outcome of a request to its span
+func recordResponse(span trace.Span, resp *http.Response, err error, retries int) {
+	span.SetAttributes(attribute.Int("http.request.resend_count", retries))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if remaining, err := strconv.Atoi(resp.Header.Get(RateLimitRemainingHeader)); err == nil {
+		span.SetAttributes(attribute.Int("github.rate_limit.remaining", remaining))
+	}
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+}
+
+// send performs one HTTP request through the scheduler.
+func (c *APIClient) send(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
 	url := c.baseURL + path
 
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
@@ -46,32 +129,101 @@ func (c *APIClient) doRequest(ctx context.Context, method, path string, body io.
 	// Set required headers
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("X-GitHub-Api-Version", APIVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	// Add authentication if token is provided
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
 
+	resource := resourceFor(path)
+	if c.scheduler != nil {
+		release, err := c.scheduler.Acquire(ctx, resource)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for %s rate limit: %w", resource, err)
+		}
+		defer release()
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
 
+	if c.scheduler != nil {
+		c.scheduler.Observe(resource, resp.Header)
+	}
+
 	return resp, nil
 }
 
+// canWaitForReset reports whether resp was rejected for an exhausted rate
+// limit that resets before the context deadline.
+func (c *APIClient) canWaitForReset(ctx context.Context, resp *http.Response) bool {
+	if !IsRateLimited(resp) {
+		return false
+	}
+	info, err := ParseRateLimitHeaders(resp.Header)
+	if err != nil || info.Remaining > 0 {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || info.Reset.Add(resetBuffer).Before(deadline)
+}
+
+// Endpoint turns a request path into its endpoint template, so owners,
+// repositories, users and numbers don't make every path unique.
+// For example /repos/golang/go/pulls/42 becomes /repos/{owner}/{repo}/pulls/{number}.
+func Endpoint(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		switch {
+		case parts[0] == "repos" && i == 1:
+			parts[i] = "{owner}"
+		case parts[0] == "repos" && i == 2:
+			parts[i] = "{repo}"
+		case parts[0] == "users" && i == 1:
+			parts[i] = "{user}"
+		case isNumber(part):
+			parts[i] = "{number}"
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
 // get performs a GET request and decodes the JSON response.
 func (c *APIClient) get(ctx context.Context, path string, result interface{}) (*http.Response, error) {
 	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
+	return c.decode(resp, result)
+}
+
+// post performs a POST request with a JSON body and decodes the JSON response.
+func (c *APIClient) post(ctx context.Context, path string, body io.Reader, result interface{}) (*http.Response, error) {
+	resp, err := c.doRequest(ctx, "POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.decode(resp, result)
+}
+
+// decode checks the response status and decodes the JSON body into result.
+func (c *APIClient) decode(resp *http.Response, result interface{}) (*http.Response, error) {
 	defer resp.Body.Close()
 
 	// Check for HTTP errors
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return resp, newAPIError(resp, body)
 	}
 
 	// Decode JSON response
@@ -104,6 +256,26 @@ func (c *APIClient) SearchIssues(ctx context.Context, query string, page, perPag
 	return &result, resp, nil
 }
 
+// SearchCommits searches for commits matching the given query.
+func (c *APIClient) SearchCommits(ctx context.Context, query string, page, perPage int) (*SearchCommitsResponse, *http.Response, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("per_page", fmt.Sprintf("%d", perPage))
+	params.Set("sort", "committer-date")
+	params.Set("order", "desc")
+
+	path := "/search/commits?" + params.Encode()
+
+	var result SearchCommitsResponse
+	resp, err := c.get(ctx, path, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
 // GetPullRequest fetches detailed information about a pull request.
 func (c *APIClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *http.Response, error) {
 	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number)
@@ -117,6 +289,34 @@ func (c *APIClient) GetPullRequest(ctx context.Context, owner, repo string, numb
 	return &result, resp, nil
 }
 
+// ListPullRequestFiles fetches the files changed by a pull request.
+// Only the first page (up to 100 files) is returned.
+func (c *APIClient) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]PullRequestFile, *http.Response, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/files?per_page=100", owner, repo, number)
+
+	var result []PullRequestFile
+	resp, err := c.get(ctx, path, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// ListPullRequestCommits fetches the commits of a pull request.
+// Only the first page (up to 100 commits) is returned.
+func (c *APIClient) ListPullRequestCommits(ctx context.Context, owner, repo string, number int) ([]Commit, *http.Response, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/commits?per_page=100", owner, repo, number)
+
+	var result []Commit
+	resp, err := c.get(ctx, path, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
 // GetRepository fetches information about a repository.
 func (c *APIClient) GetRepository(ctx context.Context, owner, repo string) (*Repository, *http.Response, error) {
 	path := fmt.Sprintf("/repos/%s/%s", owner, repo)
@@ -130,6 +330,19 @@ func (c *APIClient) GetRepository(ctx context.Context, owner, repo string) (*Rep
 	return &result, resp, nil
 }
 
+// GetUser fetches a user or organization by login.
+func (c *APIClient) GetUser(ctx context.Context, username string) (*User, *http.Response, error) {
+	path := "/users/" + url.PathEscape(username)
+
+	var result User
+	resp, err := c.get(ctx, path, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
 // GetRateLimit fetches the current rate limit status.
 func (c *APIClient) GetRateLimit(ctx context.Context) (*RateLimitResponse, error) {
 	path := "/rate_limit"
@@ -15,7 +15,7 @@ func TestNewAPIClient(t *testing.T) {
 	httpClient := &http.Client{}
 	token := "test-token"
 
-	client := NewAPIClient(httpClient, token)
+	client := NewAPIClient(httpClient, token, nil)
 
 	if client == nil {
 		t.Fatal("Expected non-nil client")
@@ -37,7 +37,7 @@ func TestNewAPIClient(t *testing.T) {
 func TestNewAPIClientWithoutToken(t *testing.T) {
 	httpClient := &http.Client{}
 
-	client := NewAPIClient(httpClient, "")
+	client := NewAPIClient(httpClient, "", nil)
 
 	if client == nil {
 		t.Fatal("Expected non-nil client")
@@ -99,7 +99,7 @@ func TestAPIClientDoRequest(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := NewAPIClient(&http.Client{}, tt.token)
+			client := NewAPIClient(&http.Client{}, tt.token, nil)
 			client.baseURL = server.URL
 
 			ctx := context.Background()
@@ -123,7 +123,7 @@ func TestAPIClientDoRequestContextCancellation(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -149,7 +149,7 @@ func TestAPIClientGet(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	var result map[string]string
@@ -205,7 +205,7 @@ func TestAPIClientGetHTTPError(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := NewAPIClient(&http.Client{}, "token")
+			client := NewAPIClient(&http.Client{}, "token", nil)
 			client.baseURL = server.URL
 
 			var result map[string]string
@@ -230,7 +230,7 @@ func TestAPIClientGetInvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	var result map[string]string
@@ -300,7 +300,7 @@ func TestAPIClientSearchIssues(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -327,6 +327,49 @@ func TestAPIClientSearchIssues(t *testing.T) {
 	}
 }
 
+func TestAPIClientSearchCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/commits" {
+			t.Errorf("Expected path /search/commits, got %s", r.URL.Path)
+		}
+		if q := r.URL.Query().Get("q"); q != "author:testuser -user:testuser" {
+			t.Errorf("Unexpected query %q", q)
+		}
+		if sort := r.URL.Query().Get("sort"); sort != "committer-date" {
+			t.Errorf("Expected sort=committer-date, got %s", sort)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"total_count": 1,
+			"incomplete_results": false,
+			"items": [{
+				"sha": "abc123",
+				"commit": {"message": "Fix race", "author": {"name": "Test", "email": "t@example.com", "date": "2024-01-15T10:30:00Z"}},
+				"author": {"login": "testuser", "id": 1, "type": "User"},
+				"repository": {"name": "repo", "full_name": "owner/repo", "owner": {"login": "owner"}}
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	result, _, err := client.SearchCommits(context.Background(), "author:testuser -user:testuser", 1, 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Items) != 1 {
+		t.Fatalf("Expected 1 commit, got %d", len(result.Items))
+	}
+	item := result.Items[0]
+	if item.SHA != "abc123" || item.Repository.FullName != "owner/repo" || item.Commit.Author.Date.IsZero() {
+		t.Errorf("Unexpected commit: %+v", item)
+	}
+}
+
 func TestAPIClientGetPullRequest(t *testing.T) {
 	expectedOwner := "testowner"
 	expectedRepo := "testrepo"
@@ -361,7 +404,7 @@ func TestAPIClientGetPullRequest(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -396,6 +439,90 @@ func TestAPIClientGetPullRequest(t *testing.T) {
 	}
 }
 
+func TestAPIClientListPullRequestFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/repos/testowner/testrepo/pulls/456/files"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("Expected per_page=100, got %s", r.URL.Query().Get("per_page"))
+		}
+
+		response := []PullRequestFile{
+			{Filename: "README.md", Status: "modified", Additions: 3, Deletions: 1, Changes: 4},
+			{Filename: "main.go", Status: "added", Additions: 10, Changes: 10},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	result, _, err := client.ListPullRequestFiles(context.Background(), "testowner", "testrepo", 456)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(result))
+	}
+
+	if result[0].Filename != "README.md" || result[0].Changes != 4 {
+		t.Errorf("Unexpected first file: %+v", result[0])
+	}
+}
+
+func TestAPIClientListPullRequestCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/repos/testowner/testrepo/pulls/456/commits"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{
+				"sha": "abc123",
+				"commit": {
+					"message": "Fix typo\n\nCo-authored-by: bot <bot@example.com>",
+					"author": {"name": "Contributor", "email": "c@example.com", "date": "2024-01-15T10:30:00Z"}
+				},
+				"author": {"login": "contributor", "id": 1, "type": "User"}
+			},
+			{
+				"sha": "def456",
+				"commit": {"message": "Apply suggestions", "author": {"name": "Someone", "email": "s@example.com"}},
+				"author": null
+			}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	result, _, err := client.ListPullRequestCommits(context.Background(), "testowner", "testrepo", 456)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(result))
+	}
+
+	if result[0].SHA != "abc123" || result[0].Author == nil || result[0].Author.Login != "contributor" {
+		t.Errorf("Unexpected first commit: %+v", result[0])
+	}
+
+	if result[1].Author != nil {
+		t.Error("Expected nil author for unlinked commit")
+	}
+}
+
 func TestAPIClientGetRepository(t *testing.T) {
 	expectedOwner := "testowner"
 	expectedRepo := "testrepo"
@@ -426,7 +553,7 @@ func TestAPIClientGetRepository(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -486,7 +613,7 @@ func TestAPIClientGetRateLimit(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -660,7 +787,7 @@ func TestAPIClientGetNilResult(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -692,7 +819,7 @@ func TestAPIClientDoRequestWithBody(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -715,7 +842,7 @@ func TestAPIClientSearchIssuesError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -741,7 +868,7 @@ func TestAPIClientGetPullRequestError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -767,7 +894,7 @@ func TestAPIClientGetRepositoryError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewAPIClient(&http.Client{}, "token")
+	client := NewAPIClient(&http.Client{}, "token", nil)
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -785,3 +912,19 @@ func TestAPIClientGetRepositoryError(t *testing.T) {
 		t.Error("Expected non-nil response even on error")
 	}
 }
+
+func TestEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"/search/issues":               "/search/issues",
+		"/graphql":                     "/graphql",
+		"/repos/golang/go":             "/repos/{owner}/{repo}",
+		"/repos/golang/go/pulls/42":    "/repos/{owner}/{repo}/pulls/{number}",
+		"/repos/o/123/pulls/7/commits": "/repos/{owner}/{repo}/pulls/{number}/commits",
+		"/users/octocat":               "/users/{user}",
+	}
+	for path, want := range tests {
+		if got := Endpoint(path); got != want {
+			t.Errorf("Endpoint(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
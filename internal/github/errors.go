@@ -0,0 +1,47 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader identifies a request to GitHub support
+const RequestIDHeader = "X-GitHub-Request-Id"
+
+// APIError is an error response of the GitHub API
+type APIError struct {
+	StatusCode       int
+	Message          string // GitHub's message, or the raw body when it isn't JSON
+	DocumentationURL string
+	RequestID        string // X-GitHub-Request-Id of the response
+}
+
+// newAPIError builds the APIError of resp, whose body was read into body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(RequestIDHeader),
+	}
+
+	var payload struct {
+		Message          string `json:"message"`
+		DocumentationURL string `json:"documentation_url"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		apiErr.Message = payload.Message
+		apiErr.DocumentationURL = payload.DocumentationURL
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID: %s)", e.RequestID)
+	}
+	return msg
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want APIError
+	}{
+		{
+			name: "GitHub error",
+			body: `{"message":"Not Found","documentation_url":"https://docs.github.com/rest"}`,
+			want: APIError{StatusCode: 404, Message: "Not Found", DocumentationURL: "https://docs.github.com/rest", RequestID: "ABCD:1234"},
+		},
+		{
+			name: "not JSON",
+			body: "upstream connect error\n",
+			want: APIError{StatusCode: 404, Message: "upstream connect error", RequestID: "ABCD:1234"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(RequestIDHeader, "ABCD:1234")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewAPIClient(&http.Client{}, "token", nil)
+			client.baseURL = server.URL
+
+			_, _, err := client.GetUser(context.Background(), "ghost")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetUser() error = %v, want an APIError", err)
+			}
+			if *apiErr != tt.want {
+				t.Errorf("APIError = %+v, want %+v", *apiErr, tt.want)
+			}
+		})
+	}
+
+	err := &APIError{StatusCode: 403, Message: "Forbidden", RequestID: "ABCD:1234"}
+	if got, want := err.Error(), "HTTP 403: Forbidden (request ID: ABCD:1234)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestRateLimitKinds(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		message   string
+		primary   bool
+		secondary bool
+	}{
+		{"budget used up", 403, rateLimitHeaders(5000, 0, time.Unix(1700000000, 0)), "API rate limit exceeded", true, false},
+		{"secondary message", 403, rateLimitHeaders(5000, 4000, time.Unix(1700000000, 0)), "You have exceeded a secondary rate limit", false, true},
+		{"retry after", 403, http.Header{"Retry-After": {"30"}}, "", false, true},
+		{"too many requests", 429, http.Header{}, "", false, true},
+		{"forbidden", 403, http.Header{}, "Resource not accessible by integration", false, false},
+		{"not found", 404, rateLimitHeaders(5000, 0, time.Unix(1700000000, 0)), "Not Found", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: tt.header}
+			err := &APIError{StatusCode: tt.status, Message: tt.message}
+
+			if got := IsPrimaryRateLimited(resp); got != tt.primary {
+				t.Errorf("IsPrimaryRateLimited() = %v, want %v", got, tt.primary)
+			}
+			if got := IsSecondaryRateLimited(resp, err); got != tt.secondary {
+				t.Errorf("IsSecondaryRateLimited() = %v, want %v", got, tt.secondary)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for value, want := range map[string]time.Duration{"30": 30 * time.Second, "": 0, "soon": 0, "-1": 0} {
+		resp := &http.Response{Header: http.Header{"Retry-After": {value}}}
+		if got := RetryAfter(resp); got != want {
+			t.Errorf("RetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+	if got := RetryAfter(nil); got != 0 {
+		t.Errorf("RetryAfter(nil) = %s, want 0", got)
+	}
+}
@@ -0,0 +1,185 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const closingIssuesQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      closingIssuesReferences { totalCount }
+    }
+  }
+}`
+
+const discussionAnswersQuery = `query($login: String!, $cursor: String) {
+  user(login: $login) {
+    repositoryDiscussionComments(onlyAnswers: true, first: 100, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        url
+        createdAt
+        discussion { repository { nameWithOwner } }
+      }
+    }
+  }
+}`
+
+const pullRequestCommitsQuery = `query($query: String!, $cursor: String) {
+  search(query: $query, type: ISSUE, first: 100, after: $cursor) {
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ... on PullRequest {
+        number
+        repository { nameWithOwner }
+        commits { totalCount }
+      }
+    }
+  }
+}`
+
+// graphql performs a GraphQL query and decodes its data into result.
+// GraphQL reports most failures in the body with a 200 status, so those are
+// returned as errors too. The API requires a token.
+func (c *APIClient) graphql(ctx context.Context, query string, variables map[string]any, result interface{}) (*http.Response, error) {
+	body, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, fmt.Errorf("encoding query: %w", err)
+	}
+
+	var response GraphQLResponse
+	resp, err := c.post(ctx, "/graphql", bytes.NewReader(body), &response)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(response.Errors) > 0 {
+		messages := make([]string, len(response.Errors))
+		for i, e := range response.Errors {
+			messages[i] = e.Message
+		}
+		return resp, fmt.Errorf("GraphQL: %s", strings.Join(messages, "; "))
+	}
+
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return resp, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CountClosingIssues returns the number of issues a pull request closes
+// (linked with "Fixes #123" or manually).
+func (c *APIClient) CountClosingIssues(ctx context.Context, owner, repo string, number int) (int, *http.Response, error) {
+	variables := map[string]any{"owner": owner, "repo": repo, "number": number}
+
+	var result struct {
+		Repository struct {
+			PullRequest struct {
+				ClosingIssuesReferences struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"closingIssuesReferences"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	resp, err := c.graphql(ctx, closingIssuesQuery, variables, &result)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return result.Repository.PullRequest.ClosingIssuesReferences.TotalCount, resp, nil
+}
+
+// ListDiscussionAnswers fetches discussion comments by the user that were
+// marked as the answer, up to limit (0 means no limit).
+func (c *APIClient) ListDiscussionAnswers(ctx context.Context, username string, limit int) ([]DiscussionAnswer, *http.Response, error) {
+	var answers []DiscussionAnswer
+	var resp *http.Response
+	var cursor *string
+
+	for {
+		variables := map[string]any{"login": username, "cursor": cursor}
+
+		var result struct {
+			User *struct {
+				Comments struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []DiscussionAnswer `json:"nodes"`
+				} `json:"repositoryDiscussionComments"`
+			} `json:"user"`
+		}
+		var err error
+		resp, err = c.graphql(ctx, discussionAnswersQuery, variables, &result)
+		if err != nil {
+			return nil, resp, err
+		}
+		if result.User == nil {
+			return nil, resp, fmt.Errorf("user %s not found", username)
+		}
+
+		answers = append(answers, result.User.Comments.Nodes...)
+
+		if limit > 0 && len(answers) >= limit {
+			return answers[:limit], resp, nil
+		}
+		if !result.User.Comments.PageInfo.HasNextPage {
+			return answers, resp, nil
+		}
+
+		endCursor := result.User.Comments.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+}
+
+// SearchPullRequestCommitCounts runs an issue search and returns the commit
+// count of every pull request found, up to limit (0 means no limit). One
+// call covers 100 pull requests, unlike fetching each one.
+func (c *APIClient) SearchPullRequestCommitCounts(ctx context.Context, query string, limit int) ([]PullRequestCommitCount, *http.Response, error) {
+	var counts []PullRequestCommitCount
+	var resp *http.Response
+	var cursor *string
+
+	for {
+		variables := map[string]any{"query": query, "cursor": cursor}
+
+		var result struct {
+			Search struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []PullRequestCommitCount `json:"nodes"`
+			} `json:"search"`
+		}
+		var err error
+		resp, err = c.graphql(ctx, pullRequestCommitsQuery, variables, &result)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		for _, node := range result.Search.Nodes {
+			// Issues match the search too but have no PR fields
+			if node.Number != 0 {
+				counts = append(counts, node)
+			}
+		}
+
+		if limit > 0 && len(counts) >= limit {
+			return counts[:limit], resp, nil
+		}
+		if !result.Search.PageInfo.HasNextPage {
+			return counts, resp, nil
+		}
+
+		endCursor := result.Search.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+}
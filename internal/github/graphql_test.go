@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClientCountClosingIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/graphql" {
+			t.Errorf("Expected POST /graphql, got %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Invalid request body: %v", err)
+		}
+		if req.Variables["owner"] != "testowner" || req.Variables["repo"] != "testrepo" || req.Variables["number"] != float64(7) {
+			t.Errorf("Unexpected variables: %v", req.Variables)
+		}
+
+		w.Write([]byte(`{"data":{"repository":{"pullRequest":{"closingIssuesReferences":{"totalCount":2}}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	count, _, err := client.CountClosingIssues(context.Background(), "testowner", "testrepo", 7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 closing issues, got %d", count)
+	}
+}
+
+func TestAPIClientGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// GraphQL reports errors with a 200 status
+		w.Write([]byte(`{"data":null,"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a Repository"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	if _, _, err := client.CountClosingIssues(context.Background(), "a", "b", 1); err == nil {
+		t.Error("Expected error for GraphQL errors in response")
+	}
+}
+
+func TestAPIClientListDiscussionAnswers(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if requests == 1 {
+			if req.Variables["cursor"] != nil {
+				t.Errorf("Expected no cursor on first page, got %v", req.Variables["cursor"])
+			}
+			w.Write([]byte(`{"data":{"user":{"repositoryDiscussionComments":{
+				"pageInfo":{"hasNextPage":true,"endCursor":"abc"},
+				"nodes":[{"url":"u1","createdAt":"2024-01-15T10:30:00Z","discussion":{"repository":{"nameWithOwner":"owner/one"}}}]
+			}}}}`))
+			return
+		}
+
+		if req.Variables["cursor"] != "abc" {
+			t.Errorf("Expected cursor abc, got %v", req.Variables["cursor"])
+		}
+		w.Write([]byte(`{"data":{"user":{"repositoryDiscussionComments":{
+			"pageInfo":{"hasNextPage":false,"endCursor":"def"},
+			"nodes":[{"url":"u2","createdAt":"2024-02-15T10:30:00Z","discussion":{"repository":{"nameWithOwner":"owner/two"}}}]
+		}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	answers, _, err := client.ListDiscussionAnswers(context.Background(), "testuser", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(answers) != 2 || requests != 2 {
+		t.Fatalf("Expected 2 answers over 2 pages, got %d answers in %d requests", len(answers), requests)
+	}
+	if answers[1].Discussion.Repository.NameWithOwner != "owner/two" {
+		t.Errorf("Unexpected second answer: %+v", answers[1])
+	}
+
+	// The limit stops pagination early
+	requests = 0
+	answers, _, err = client.ListDiscussionAnswers(context.Background(), "testuser", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(answers) != 1 || requests != 1 {
+		t.Errorf("Expected 1 answer in 1 request, got %d answers in %d requests", len(answers), requests)
+	}
+}
+
+func TestAPIClientSearchPullRequestCommitCounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["query"] != "author:testuser type:pr is:merged" {
+			t.Errorf("Unexpected query variable: %v", req.Variables["query"])
+		}
+
+		w.Write([]byte(`{"data":{"search":{
+			"pageInfo":{"hasNextPage":false},
+			"nodes":[
+				{"number":7,"repository":{"nameWithOwner":"owner/repo"},"commits":{"totalCount":3}},
+				{}
+			]
+		}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", nil)
+	client.baseURL = server.URL
+
+	counts, _, err := client.SearchPullRequestCommitCounts(context.Background(), "author:testuser type:pr is:merged", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(counts) != 1 {
+		t.Fatalf("Expected 1 PR (non-PR nodes skipped), got %d", len(counts))
+	}
+	if counts[0].Number != 7 || counts[0].Repository.NameWithOwner != "owner/repo" || counts[0].Commits.TotalCount != 3 {
+		t.Errorf("Unexpected count: %+v", counts[0])
+	}
+}
@@ -11,12 +11,33 @@ type GithubAPI interface {
 	// SearchIssues searches for issues/PRs matching the given query.
 	SearchIssues(ctx context.Context, query string, page, perPage int) (*SearchIssuesResponse, *http.Response, error)
 
+	// SearchCommits searches for commits matching the given query.
+	SearchCommits(ctx context.Context, query string, page, perPage int) (*SearchCommitsResponse, *http.Response, error)
+
 	// GetPullRequest fetches detailed information about a pull request.
 	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *http.Response, error)
 
+	// ListPullRequestFiles fetches the files changed by a pull request.
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]PullRequestFile, *http.Response, error)
+
+	// ListPullRequestCommits fetches the commits of a pull request.
+	ListPullRequestCommits(ctx context.Context, owner, repo string, number int) ([]Commit, *http.Response, error)
+
+	// CountClosingIssues returns the number of issues a pull request closes.
+	CountClosingIssues(ctx context.Context, owner, repo string, number int) (int, *http.Response, error)
+
+	// ListDiscussionAnswers fetches discussion comments by the user marked as the answer.
+	ListDiscussionAnswers(ctx context.Context, username string, limit int) ([]DiscussionAnswer, *http.Response, error)
+
+	// SearchPullRequestCommitCounts returns the commit count of every pull request matching the search.
+	SearchPullRequestCommitCounts(ctx context.Context, query string, limit int) ([]PullRequestCommitCount, *http.Response, error)
+
 	// GetRepository fetches information about a repository.
 	GetRepository(ctx context.Context, owner, repo string) (*Repository, *http.Response, error)
 
+	// GetUser fetches a user or organization by login.
+	GetUser(ctx context.Context, username string) (*User, *http.Response, error)
+
 	// GetRateLimit fetches the current rate limit status.
 	GetRateLimit(ctx context.Context) (*RateLimitResponse, error)
 }
@@ -2,10 +2,12 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,8 +18,11 @@ const (
 	// RateLimitResetHeader is the header containing the rate limit reset time
 	RateLimitResetHeader = "X-RateLimit-Reset"
 
-	// SearchAPIDelay is the delay between search API calls (30 requests/minute)
-	SearchAPIDelay = 2 * time.Second
+	// RateLimitLimitHeader is the header containing the requests allowed per window
+	RateLimitLimitHeader = "X-RateLimit-Limit"
+
+	// RateLimitResourceHeader is the header naming the rate limit a response counts against
+	RateLimitResourceHeader = "X-RateLimit-Resource"
 
 	// MaxBackoffAttempts is the maximum number of retry attempts for rate limiting
 	MaxBackoffAttempts = 5
@@ -28,6 +33,7 @@ const (
 
 // RateLimitInfo contains rate limit information from response headers.
 type RateLimitInfo struct {
+	Limit     int // 0 when the header is missing
 	Remaining int
 	Reset     time.Time
 }
@@ -51,7 +57,11 @@ func ParseRateLimitHeaders(headers http.Header) (*RateLimitInfo, error) {
 		return nil, fmt.Errorf("parsing reset: %w", err)
 	}
 
+	// The limit is informational, so a missing or bad value is not an error
+	limit, _ := strconv.Atoi(headers.Get(RateLimitLimitHeader))
+
 	return &RateLimitInfo{
+		Limit:     limit,
 		Remaining: remaining,
 		Reset:     time.Unix(resetUnix, 0),
 	}, nil
@@ -59,8 +69,45 @@ func ParseRateLimitHeaders(headers http.Header) (*RateLimitInfo, error) {
 
 // IsRateLimited checks if a response indicates rate limiting.
 func IsRateLimited(resp *http.Response) bool {
-	return resp.StatusCode == http.StatusTooManyRequests || // 429
-		resp.StatusCode == http.StatusForbidden // 403 can also indicate rate limiting
+	return resp != nil && (resp.StatusCode == http.StatusTooManyRequests || // 429
+		resp.StatusCode == http.StatusForbidden) // 403 can also indicate rate limiting
+}
+
+// IsPrimaryRateLimited reports whether resp was rejected because the rate
+// limit budget of its resource is used up
+func IsPrimaryRateLimited(resp *http.Response) bool {
+	if !IsRateLimited(resp) {
+		return false
+	}
+	info, err := ParseRateLimitHeaders(resp.Header)
+	return err == nil && info.Remaining == 0
+}
+
+// IsSecondaryRateLimited reports whether resp, which failed with err, was
+// rejected by a secondary rate limit: GitHub's limit on bursts of requests,
+// applied whatever budget is left.
+func IsSecondaryRateLimited(resp *http.Response, err error) bool {
+	if !IsRateLimited(resp) || IsPrimaryRateLimited(resp) {
+		return false
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && strings.Contains(strings.ToLower(apiErr.Message), "secondary rate limit")
+}
+
+// RetryAfter returns the wait asked for by the Retry-After header of resp,
+// or 0 without one
+func RetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 // HandleRateLimit implements exponential backoff for rate-limited requests.
@@ -106,17 +153,6 @@ func calculateBackoff(attempt int) time.Duration {
 	return time.Duration(delay)
 }
 
-// WaitForSearchAPI implements the required delay between search API calls.
-// GitHub's search API has stricter limits (30 requests/minute).
-func WaitForSearchAPI(ctx context.Context) error {
-	select {
-	case <-time.After(SearchAPIDelay):
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	}
-}
-
 // CheckRateLimit checks if we're approaching rate limits and logs a warning.
 func CheckRateLimit(info *RateLimitInfo, threshold int) bool {
 	return info != nil && info.Remaining <= threshold
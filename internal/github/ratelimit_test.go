@@ -290,33 +290,6 @@ func TestHandleRateLimitExponentialBackoff(t *testing.T) {
 	}
 }
 
-func TestWaitForSearchAPI(t *testing.T) {
-	ctx := context.Background()
-
-	start := time.Now()
-	err := WaitForSearchAPI(ctx)
-	elapsed := time.Since(start)
-
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
-	}
-
-	// Should wait for SearchAPIDelay (2 seconds)
-	if elapsed < 1900*time.Millisecond || elapsed > 2100*time.Millisecond {
-		t.Errorf("Expected ~2s delay, got %v", elapsed)
-	}
-}
-
-func TestWaitForSearchAPIContextCancellation(t *testing.T) {
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel() // Cancel immediately
-
-	err := WaitForSearchAPI(ctx)
-	if err != context.Canceled {
-		t.Errorf("Expected context.Canceled error, got %v", err)
-	}
-}
-
 func TestCheckRateLimit(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -488,6 +461,12 @@ func TestShouldRetryNilResponse(t *testing.T) {
 	}
 }
 
+func TestIsRateLimitedNilResponse(t *testing.T) {
+	if IsRateLimited(nil) {
+		t.Error("IsRateLimited(nil) should return false")
+	}
+}
+
 func TestRateLimitInfoStruct(t *testing.T) {
 	resetTime := time.Now().Add(1 * time.Hour)
 	info := &RateLimitInfo{
@@ -534,21 +513,6 @@ func TestHandleRateLimitWithPastResetTime(t *testing.T) {
 	}
 }
 
-func TestWaitForSearchAPITimeout(t *testing.T) {
-	// Create a context that times out before SearchAPIDelay
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
-	defer cancel()
-
-	err := WaitForSearchAPI(ctx)
-	if err == nil {
-		t.Error("Expected timeout error, got nil")
-	}
-
-	if err != context.DeadlineExceeded {
-		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
-	}
-}
-
 func TestHandleRateLimitTimeout(t *testing.T) {
 	// Create a context that times out quickly
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
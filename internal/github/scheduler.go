@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Resource is a GitHub API rate limit bucket. Each one has its own budget.
+type Resource string
+
+const (
+	ResourceCore    Resource = "core"
+	ResourceSearch  Resource = "search"
+	ResourceGraphQL Resource = "graphql"
+)
+
+const (
+	// DefaultConcurrency is the default maximum number of requests in flight
+	DefaultConcurrency = 5
+
+	// lowBudget is the share of a resource's limit below which concurrency
+	// is scaled down
+	lowBudget = 0.25
+
+	// resetBuffer is added to reset times, as GitHub's clock may run behind
+	resetBuffer = time.Second
+)
+
+// WaitFunc is called when requests to a resource start pausing until its
+// rate limit window resets at reset (waiting is true), and when they resume.
+type WaitFunc func(resource Resource, reset time.Time, waiting bool)
+
+// Default budgets per resource, used until a response reports the real ones
+var defaultBudgets = map[Resource]struct {
+	limit  int
+	window time.Duration
+}{
+	ResourceCore:    {5000, time.Hour},
+	ResourceSearch:  {30, time.Minute},
+	ResourceGraphQL: {5000, time.Hour},
+}
+
+// Scheduler paces every API request of a client. It runs a token bucket per
+// resource, refilled when the resource's rate limit window resets and
+// corrected from the X-RateLimit-* headers of every response. Requests pause
+// while a bucket is empty instead of failing, and the number of requests in
+// flight shrinks as a bucket runs low.
+//
+// A Scheduler is safe for concurrent use and can be shared by clients that
+// use the same token.
+type Scheduler struct {
+	maxConcurrency int
+	onWait         WaitFunc
+	now            func() time.Time
+
+	mu       sync.Mutex
+	inFlight int
+	buckets  map[Resource]*bucket
+	changed  chan struct{} // Closed and replaced when a slot or tokens free up
+}
+
+// bucket is the remaining budget of one resource in its current window
+type bucket struct {
+	limit  int
+	window time.Duration
+	tokens int
+	reset  time.Time // When tokens refill to limit, zero before the first request
+	known  bool      // Whether reset was reported by GitHub rather than assumed
+	paused bool      // Whether requests are waiting for the reset
+}
+
+// NewScheduler creates a scheduler allowing up to maxConcurrency requests in
+// flight (DefaultConcurrency when <= 0). onWait, when not nil, is told when
+// requests pause for a rate limit reset and when they resume.
+func NewScheduler(maxConcurrency int, onWait WaitFunc) *Scheduler {
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultConcurrency
+	}
+
+	buckets := make(map[Resource]*bucket, len(defaultBudgets))
+	for resource, budget := range defaultBudgets {
+		buckets[resource] = &bucket{limit: budget.limit, window: budget.window, tokens: budget.limit}
+	}
+
+	return &Scheduler{
+		maxConcurrency: maxConcurrency,
+		onWait:         onWait,
+		now:            time.Now,
+		buckets:        buckets,
+		changed:        make(chan struct{}),
+	}
+}
+
+// Acquire waits until a request to the resource may be sent: its bucket has
+// a token and fewer requests than the budget allows are in flight. The
+// returned function must be called once the response is received.
+func (s *Scheduler) Acquire(ctx context.Context, resource Resource) (func(), error) {
+	for {
+		s.mu.Lock()
+		b := s.bucket(resource)
+		now := s.now()
+		b.refill(now)
+
+		var wait time.Duration
+		if b.tokens <= 0 {
+			// Out of budget: pause until the window resets
+			wait = b.reset.Sub(now) + resetBuffer
+		} else if s.inFlight < s.concurrency(b) {
+			b.tokens--
+			s.inFlight++
+			resumed := b.paused
+			b.paused = false
+			reset := b.reset
+			s.mu.Unlock()
+
+			if resumed {
+				s.notifyWait(resource, reset, false)
+			}
+			var once sync.Once
+			return func() { once.Do(s.release) }, nil
+		}
+		paused := wait > 0 && !b.paused
+		if paused {
+			b.paused = true
+		}
+		reset := b.reset
+		changed := s.changed
+		s.mu.Unlock()
+
+		if paused {
+			s.notifyWait(resource, reset, true)
+		}
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+
+		select {
+		case <-changed:
+		case <-expired:
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return nil, ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Observe updates the resource's budget from the rate limit headers of a
+// response. Responses without the headers are ignored.
+func (s *Scheduler) Observe(resource Resource, headers http.Header) {
+	info, err := ParseRateLimitHeaders(headers)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.bucket(resource)
+	switch {
+	case b.known && info.Reset.Before(b.reset):
+		// A late response from the previous window
+		return
+	case b.known && info.Reset.Equal(b.reset):
+		// Requests still in flight aren't counted by the server yet
+		b.tokens = min(b.tokens, info.Remaining)
+	default:
+		// A new window, or the first response of one: trust the server
+		b.tokens = info.Remaining
+		b.reset = info.Reset
+		b.known = true
+	}
+	if info.Limit > 0 {
+		b.limit = info.Limit
+	}
+	s.broadcast()
+}
+
+func (s *Scheduler) notifyWait(resource Resource, reset time.Time, waiting bool) {
+	if s.onWait != nil {
+		s.onWait(resource, reset, waiting)
+	}
+}
+
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight--
+	s.broadcast()
+}
+
+// broadcast wakes every waiting Acquire. Callers must hold s.mu.
+func (s *Scheduler) broadcast() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// bucket returns the resource's bucket, creating unknown ones with the core
+// budget. Callers must hold s.mu.
+func (s *Scheduler) bucket(resource Resource) *bucket {
+	b, ok := s.buckets[resource]
+	if !ok {
+		budget := defaultBudgets[ResourceCore]
+		b = &bucket{limit: budget.limit, window: budget.window, tokens: budget.limit}
+		s.buckets[resource] = b
+	}
+	return b
+}
+
+// concurrency returns how many requests may be in flight while b is the
+// bucket being drawn from: the maximum while the budget is healthy, scaled
+// down to 1 as it runs low. Callers must hold s.mu.
+func (s *Scheduler) concurrency(b *bucket) int {
+	fraction := float64(b.tokens) / float64(max(b.limit, 1))
+	if fraction >= lowBudget {
+		return s.maxConcurrency
+	}
+	return max(1, int(math.Ceil(float64(s.maxConcurrency)*fraction/lowBudget)))
+}
+
+// refill starts a new window with a full bucket once the current one reset
+func (b *bucket) refill(now time.Time) {
+	if now.Before(b.reset) {
+		return
+	}
+	if !b.reset.IsZero() {
+		b.tokens = b.limit
+	}
+	b.reset = now.Add(b.window)
+	b.known = false
+}
+
+// resourceFor returns the rate limit resource a request path draws from
+func resourceFor(path string) Resource {
+	switch {
+	case strings.HasPrefix(path, "/search/"):
+		return ResourceSearch
+	case strings.HasPrefix(path, "/graphql"):
+		return ResourceGraphQL
+	default:
+		return ResourceCore
+	}
+}
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func rateLimitHeaders(limit, remaining int, reset time.Time) http.Header {
+	headers := http.Header{}
+	headers.Set(RateLimitLimitHeader, strconv.Itoa(limit))
+	headers.Set(RateLimitRemainingHeader, strconv.Itoa(remaining))
+	headers.Set(RateLimitResetHeader, strconv.FormatInt(reset.Unix(), 10))
+	return headers
+}
+
+func TestSchedulerLimitsConcurrency(t *testing.T) {
+	s := NewScheduler(3, nil)
+
+	var inFlight, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := s.Acquire(context.Background(), ResourceCore)
+			if err != nil {
+				t.Errorf("Acquire() error = %v", err)
+				return
+			}
+			defer release()
+
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 3 {
+		t.Errorf("peak in-flight requests = %d, want 3", got)
+	}
+	if remaining := s.buckets[ResourceCore].tokens; remaining != 5000-20 {
+		t.Errorf("core tokens = %d, want %d", remaining, 5000-20)
+	}
+}
+
+func TestSchedulerPausesWhenBudgetIsExhausted(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var waits []bool
+	s := NewScheduler(5, func(resource Resource, reset time.Time, waiting bool) {
+		if resource != ResourceSearch || !reset.Equal(now.Add(time.Minute)) {
+			t.Errorf("onWait(%s, %v) for another window", resource, reset)
+		}
+		waits = append(waits, waiting)
+	})
+	s.now = func() time.Time { return now }
+
+	s.Observe(ResourceSearch, rateLimitHeaders(30, 0, now.Add(time.Minute)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx, ResourceSearch); err != context.DeadlineExceeded {
+		t.Fatalf("Acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// Other resources keep their own budget
+	release, err := s.Acquire(context.Background(), ResourceCore)
+	if err != nil {
+		t.Fatalf("Acquire(core) error = %v", err)
+	}
+	release()
+
+	// Once the window resets, the bucket refills
+	now = now.Add(time.Minute)
+	release, err = s.Acquire(context.Background(), ResourceSearch)
+	if err != nil {
+		t.Fatalf("Acquire() after reset error = %v", err)
+	}
+	release()
+
+	if tokens := s.buckets[ResourceSearch].tokens; tokens != 29 {
+		t.Errorf("search tokens = %d, want 29", tokens)
+	}
+	if len(waits) != 2 || !waits[0] || waits[1] {
+		t.Errorf("onWait calls = %v, want [true false]", waits)
+	}
+}
+
+func TestSchedulerObserve(t *testing.T) {
+	reset := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+	s := NewScheduler(5, nil)
+
+	// The first response sets the budget, including the limit
+	s.Observe(ResourceCore, rateLimitHeaders(60, 50, reset))
+	if b := s.buckets[ResourceCore]; b.tokens != 50 || b.limit != 60 || !b.reset.Equal(reset) {
+		t.Fatalf("after first response: %+v", b)
+	}
+
+	// Within the window, the lower count wins: requests in flight may not be counted yet
+	s.buckets[ResourceCore].tokens = 40
+	s.Observe(ResourceCore, rateLimitHeaders(60, 45, reset))
+	if tokens := s.buckets[ResourceCore].tokens; tokens != 40 {
+		t.Errorf("tokens = %d, want 40", tokens)
+	}
+
+	// A late response from an earlier window is ignored
+	s.Observe(ResourceCore, rateLimitHeaders(60, 2, reset.Add(-time.Hour)))
+	if tokens := s.buckets[ResourceCore].tokens; tokens != 40 {
+		t.Errorf("tokens after late response = %d, want 40", tokens)
+	}
+
+	// A new window is trusted
+	s.Observe(ResourceCore, rateLimitHeaders(60, 59, reset.Add(time.Hour)))
+	if tokens := s.buckets[ResourceCore].tokens; tokens != 59 {
+		t.Errorf("tokens after new window = %d, want 59", tokens)
+	}
+
+	// Responses without headers are ignored
+	s.Observe(ResourceCore, http.Header{})
+	if tokens := s.buckets[ResourceCore].tokens; tokens != 59 {
+		t.Errorf("tokens after no headers = %d, want 59", tokens)
+	}
+}
+
+func TestSchedulerConcurrencyScalesWithBudget(t *testing.T) {
+	s := NewScheduler(8, nil)
+
+	tests := []struct {
+		tokens int
+		want   int
+	}{
+		{5000, 8},
+		{1250, 8}, // 25% left
+		{625, 4},
+		{100, 1},
+		{0, 1},
+	}
+
+	for _, tt := range tests {
+		b := &bucket{limit: 5000, tokens: tt.tokens}
+		if got := s.concurrency(b); got != tt.want {
+			t.Errorf("concurrency with %d tokens = %d, want %d", tt.tokens, got, tt.want)
+		}
+	}
+}
+
+func TestResourceFor(t *testing.T) {
+	tests := map[string]Resource{
+		"/search/issues?q=x":        ResourceSearch,
+		"/search/commits?q=x":       ResourceSearch,
+		"/graphql":                  ResourceGraphQL,
+		"/repos/owner/repo":         ResourceCore,
+		"/repos/owner/repo/pulls/1": ResourceCore,
+	}
+
+	for path, want := range tests {
+		if got := resourceFor(path); got != want {
+			t.Errorf("resourceFor(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestAPIClientRetriesAfterReset(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first call exhausts a window that has already reset
+		if calls.Add(1) == 1 {
+			for k, v := range rateLimitHeaders(5000, 0, time.Now().Add(-time.Minute)) {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+			return
+		}
+		for k, v := range rateLimitHeaders(5000, 4999, time.Now().Add(time.Hour)) {
+			w.Header()[k] = v
+		}
+		w.Write([]byte(`{"full_name":"owner/repo","stargazers_count":10}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", NewScheduler(1, nil))
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo, _, err := client.GetRepository(ctx, "owner", "repo")
+	if err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+	if repo.StargazersCount != 10 {
+		t.Errorf("StargazersCount = %d, want 10", repo.StargazersCount)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+}
+
+func TestAPIClientDoesNotRetryPastDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		for k, v := range rateLimitHeaders(5000, 0, time.Now().Add(time.Hour)) {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(&http.Client{}, "token", NewScheduler(1, nil))
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, resp, err := client.GetRepository(ctx, "owner", "repo")
+	if err == nil {
+		t.Fatal("GetRepository() should fail when the reset is past the deadline")
+	}
+	if resp == nil || !IsRateLimited(resp) {
+		t.Errorf("response should be rate limited, got %v", resp)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
@@ -1,6 +1,9 @@
 package github
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // SearchIssuesResponse represents the response from GitHub's search/issues API.
 // Used to find merged PRs authored by a specific user.
@@ -58,6 +61,52 @@ type PullRequest struct {
 	HTMLURL      string     `json:"html_url"`
 }
 
+// PullRequestFile represents a file changed by a pull request.
+type PullRequestFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
+
+// Commit represents a commit as listed for a pull request.
+type Commit struct {
+	SHA    string        `json:"sha"`
+	Commit CommitDetails `json:"commit"`
+	Author *User         `json:"author"` // Linked GitHub account, nil if the email isn't linked
+}
+
+// CommitDetails contains the git data of a commit.
+type CommitDetails struct {
+	Message string       `json:"message"`
+	Author  CommitAuthor `json:"author"`
+}
+
+// CommitAuthor represents the git author of a commit.
+type CommitAuthor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// SearchCommitsResponse represents the response from GitHub's search/commits API.
+// Used to find commits authored or co-authored by a user outside of their PRs.
+type SearchCommitsResponse struct {
+	TotalCount        int                `json:"total_count"`
+	IncompleteResults bool               `json:"incomplete_results"`
+	Items             []CommitSearchItem `json:"items"`
+}
+
+// CommitSearchItem is a commit returned by the search API, with its repository.
+type CommitSearchItem struct {
+	SHA        string        `json:"sha"`
+	HTMLURL    string        `json:"html_url"`
+	Commit     CommitDetails `json:"commit"`
+	Author     *User         `json:"author"` // Linked GitHub account, nil if the email isn't linked
+	Repository Repository    `json:"repository"`
+}
+
 // Repository represents a GitHub repository with metadata.
 type Repository struct {
 	Name            string     `json:"name"`
@@ -66,6 +115,8 @@ type Repository struct {
 	Description     string     `json:"description"`
 	HTMLURL         string     `json:"html_url"`
 	Fork            bool       `json:"fork"`
+	Archived        bool       `json:"archived"`
+	Topics          []string   `json:"topics"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	PushedAt        *time.Time `json:"pushed_at"`
@@ -76,6 +127,46 @@ type Repository struct {
 	DefaultBranch   string     `json:"default_branch"`
 }
 
+// DiscussionAnswer is a discussion comment marked as the answer.
+type DiscussionAnswer struct {
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Discussion struct {
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+	} `json:"discussion"`
+}
+
+// PullRequestCommitCount is the number of commits of a pull request.
+type PullRequestCommitCount struct {
+	Number     int `json:"number"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Commits struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"commits"`
+}
+
+// GraphQLRequest is the body of a GraphQL API request.
+type GraphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLResponse is the body of a GraphQL API response.
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors"`
+}
+
+// GraphQLError is an error reported in a GraphQL response.
+type GraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
 // RateLimitResponse represents the rate limit information from GitHub's API.
 type RateLimitResponse struct {
 	Resources RateLimitResources `json:"resources"`
@@ -83,8 +174,9 @@ type RateLimitResponse struct {
 
 // RateLimitResources contains rate limit information for different API resources.
 type RateLimitResources struct {
-	Core   RateLimit `json:"core"`
-	Search RateLimit `json:"search"`
+	Core    RateLimit `json:"core"`
+	Search  RateLimit `json:"search"`
+	GraphQL RateLimit `json:"graphql"`
 }
 
 // RateLimit represents rate limit information for a specific resource.
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// fetchDurationBuckets are the fetch duration histogram bounds, in seconds
+var fetchDurationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600}
+
+// Exporter publishes contribution stats per user and repository, and the
+// API calls, rate limits and fetch durations behind them. It serves the
+// metrics over HTTP in the Prometheus text format.
+type Exporter struct {
+	registry *Registry
+
+	// Stats, from the last fetch of each user
+	userProjects  *Gauge
+	userPRsMerged *Gauge
+	userCommits   *Gauge
+	userAdditions *Gauge
+	userDeletions *Gauge
+	userFetchedAt *Gauge
+	repoPRsMerged *Gauge
+	repoCommits   *Gauge
+	repoAdditions *Gauge
+	repoDeletions *Gauge
+	repoStars     *Gauge
+
+	// Operations
+	apiRequests        *Counter
+	rateLimitRemaining *Gauge
+	fetchDuration      *Histogram
+}
+
+// NewExporter creates an Exporter with no data
+func NewExporter() *Exporter {
+	r := NewRegistry()
+	return &Exporter{
+		registry: r,
+
+		userProjects:  r.Gauge("gh_oss_stats_user_projects", "External repositories the user contributed to.", "user"),
+		userPRsMerged: r.Gauge("gh_oss_stats_user_prs_merged", "Merged PRs to external repositories.", "user"),
+		userCommits:   r.Gauge("gh_oss_stats_user_commits", "Commits in merged PRs, when counted.", "user"),
+		userAdditions: r.Gauge("gh_oss_stats_user_additions", "Lines added by merged PRs.", "user"),
+		userDeletions: r.Gauge("gh_oss_stats_user_deletions", "Lines deleted by merged PRs.", "user"),
+		userFetchedAt: r.Gauge("gh_oss_stats_user_generated_timestamp_seconds", "When the user's stats were generated.", "user"),
+		repoPRsMerged: r.Gauge("gh_oss_stats_repo_prs_merged", "Merged PRs per repository.", "user", "repo"),
+		repoCommits:   r.Gauge("gh_oss_stats_repo_commits", "Commits in merged PRs per repository, when counted.", "user", "repo"),
+		repoAdditions: r.Gauge("gh_oss_stats_repo_additions", "Lines added per repository.", "user", "repo"),
+		repoDeletions: r.Gauge("gh_oss_stats_repo_deletions", "Lines deleted per repository.", "user", "repo"),
+		repoStars:     r.Gauge("gh_oss_stats_repo_stars", "Repository star count.", "user", "repo"),
+
+		apiRequests:        r.Counter("gh_oss_stats_github_requests_total", "GitHub API requests by endpoint and status.", "endpoint", "status"),
+		rateLimitRemaining: r.Gauge("gh_oss_stats_github_rate_limit_remaining", "Requests left in the current rate limit window.", "resource"),
+		fetchDuration:      r.Histogram("gh_oss_stats_fetch_duration_seconds", "Duration of fetching a user's stats, by result.", fetchDurationBuckets, "result"),
+	}
+}
+
+// ObserveStats publishes the stats of a user, replacing the previous ones
+func (e *Exporter) ObserveStats(stats *ossstats.Stats) {
+	user := stats.Username
+	for _, g := range []*Gauge{e.userCommits, e.repoPRsMerged, e.repoCommits, e.repoAdditions, e.repoDeletions, e.repoStars} {
+		g.DeleteMatching("user", user)
+	}
+
+	e.userProjects.Set(float64(stats.Summary.TotalProjects), user)
+	e.userPRsMerged.Set(float64(stats.Summary.TotalPRsMerged), user)
+	e.userAdditions.Set(float64(stats.Summary.TotalAdditions), user)
+	e.userDeletions.Set(float64(stats.Summary.TotalDeletions), user)
+	e.userFetchedAt.Set(float64(stats.GeneratedAt.Unix()), user)
+	if stats.Summary.TotalCommits != nil {
+		e.userCommits.Set(float64(*stats.Summary.TotalCommits), user)
+	}
+
+	for _, c := range stats.Contributions {
+		e.repoPRsMerged.Set(float64(c.PRsMerged), user, c.Repo)
+		e.repoAdditions.Set(float64(c.Additions), user, c.Repo)
+		e.repoDeletions.Set(float64(c.Deletions), user, c.Repo)
+		e.repoStars.Set(float64(c.Stars), user, c.Repo)
+		if c.Commits != nil {
+			e.repoCommits.Set(float64(*c.Commits), user, c.Repo)
+		}
+	}
+}
+
+// ForgetUser removes the stats series of a user, e.g. when its cached stats
+// expire, so users no longer served don't stay on the metrics page
+func (e *Exporter) ForgetUser(user string) {
+	for _, g := range []*Gauge{
+		e.userProjects, e.userPRsMerged, e.userCommits, e.userAdditions, e.userDeletions, e.userFetchedAt,
+		e.repoPRsMerged, e.repoCommits, e.repoAdditions, e.repoDeletions, e.repoStars,
+	} {
+		g.DeleteMatching("user", user)
+	}
+}
+
+// ObserveFetch records how long fetching a user's stats took, and whether
+// it succeeded, returned partial results or failed
+func (e *Exporter) ObserveFetch(d time.Duration, err error) {
+	result := "success"
+	var partialErr *ossstats.ErrPartialResults
+	if errors.As(err, &partialErr) {
+		result = "partial"
+	} else if err != nil {
+		result = "error"
+	}
+	e.fetchDuration.Observe(d.Seconds(), result)
+}
+
+// Transport returns a RoundTripper counting the GitHub API requests sent
+// through base and tracking the rate limits in their responses
+func (e *Exporter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, exporter: e}
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	e.registry.WriteText(w)
+}
+
+type transport struct {
+	base     http.RoundTripper
+	exporter *Exporter
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	endpoint := github.Endpoint(req.URL.Path)
+	if err != nil {
+		t.exporter.apiRequests.Inc(endpoint, "error")
+		return resp, err
+	}
+	t.exporter.apiRequests.Inc(endpoint, strconv.Itoa(resp.StatusCode))
+
+	if remaining, err := strconv.Atoi(resp.Header.Get(github.RateLimitRemainingHeader)); err == nil {
+		resource := resp.Header.Get(github.RateLimitResourceHeader)
+		if resource == "" {
+			resource = "core"
+		}
+		t.exporter.rateLimitRemaining.Set(float64(remaining), resource)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func intPtr(n int) *int { return &n }
+
+// scrape returns the exporter's metrics page
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestExporterObserveStats(t *testing.T) {
+	e := NewExporter()
+	e.ObserveStats(&ossstats.Stats{
+		Username:    "octocat",
+		GeneratedAt: time.Unix(1700000000, 0),
+		Summary:     ossstats.Summary{TotalProjects: 2, TotalPRsMerged: 5, TotalCommits: intPtr(9), TotalAdditions: 100},
+		Contributions: []ossstats.Contribution{
+			{Repo: "golang/go", Stars: 120000, PRsMerged: 4, Commits: intPtr(8), Additions: 90},
+			{Repo: "owner/old", Stars: 3, PRsMerged: 1},
+		},
+	})
+
+	// A refresh replaces the repos of the user
+	e.ObserveStats(&ossstats.Stats{
+		Username:    "octocat",
+		GeneratedAt: time.Unix(1700003600, 0),
+		Summary:     ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 4, TotalAdditions: 90},
+		Contributions: []ossstats.Contribution{
+			{Repo: "golang/go", Stars: 120001, PRsMerged: 4, Additions: 90},
+		},
+	})
+
+	out := scrape(t, e)
+	for _, want := range []string{
+		`gh_oss_stats_user_projects{user="octocat"} 1`,
+		`gh_oss_stats_user_prs_merged{user="octocat"} 4`,
+		`gh_oss_stats_user_generated_timestamp_seconds{user="octocat"} 1700003600`,
+		`gh_oss_stats_repo_stars{user="octocat",repo="golang/go"} 120001`,
+		`gh_oss_stats_repo_additions{user="octocat",repo="golang/go"} 90`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+	for _, gone := range []string{`repo="owner/old"`, `gh_oss_stats_user_commits{`, `gh_oss_stats_repo_commits{`} {
+		if strings.Contains(out, gone) {
+			t.Errorf("metrics should not contain %q after the refresh:\n%s", gone, out)
+		}
+	}
+}
+
+func TestExporterForgetUser(t *testing.T) {
+	e := NewExporter()
+	for _, user := range []string{"octocat", "hubot"} {
+		e.ObserveStats(&ossstats.Stats{
+			Username:      user,
+			Summary:       ossstats.Summary{TotalProjects: 1, TotalCommits: intPtr(2)},
+			Contributions: []ossstats.Contribution{{Repo: "golang/go", Commits: intPtr(2)}},
+		})
+	}
+
+	e.ForgetUser("octocat")
+
+	out := scrape(t, e)
+	if strings.Contains(out, `user="octocat"`) {
+		t.Errorf("metrics should not contain octocat's series:\n%s", out)
+	}
+	if !strings.Contains(out, `gh_oss_stats_repo_commits{user="hubot",repo="golang/go"} 2`) {
+		t.Errorf("metrics should keep hubot's series:\n%s", out)
+	}
+}
+
+func TestExporterObserveFetch(t *testing.T) {
+	e := NewExporter()
+	e.ObserveFetch(2*time.Second, nil)
+	e.ObserveFetch(time.Second, &ossstats.ErrPartialResults{})
+	e.ObserveFetch(time.Second, errors.New("boom"))
+
+	out := scrape(t, e)
+	for _, result := range []string{"success", "partial", "error"} {
+		if want := `gh_oss_stats_fetch_duration_seconds_count{result="` + result + `"} 1`; !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExporterTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(github.RateLimitRemainingHeader, "28")
+		if strings.HasPrefix(r.URL.Path, "/search/") {
+			w.Header().Set(github.RateLimitResourceHeader, "search")
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	e := NewExporter()
+	client := &http.Client{Transport: e.Transport(nil)}
+	for _, path := range []string{"/search/issues?q=x", "/repos/golang/go/pulls/1", "/repos/owner/repo/pulls/2"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	out := scrape(t, e)
+	for _, want := range []string{
+		`gh_oss_stats_github_requests_total{endpoint="/search/issues",status="200"} 1`,
+		`gh_oss_stats_github_requests_total{endpoint="/repos/{owner}/{repo}/pulls/{number}",status="404"} 2`,
+		`gh_oss_stats_github_rate_limit_remaining{resource="search"} 28`,
+		`gh_oss_stats_github_rate_limit_remaining{resource="core"} 28`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+}
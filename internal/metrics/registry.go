@@ -0,0 +1,228 @@
+// Package metrics exposes contribution stats and operational metrics in the
+// Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// metricKind is the TYPE of a metric family
+type metricKind string
+
+const (
+	kindCounter   metricKind = "counter"
+	kindGauge     metricKind = "gauge"
+	kindHistogram metricKind = "histogram"
+)
+
+// Registry holds metric families and writes them in the Prometheus text
+// format. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+}
+
+// family is a metric and its series, one per combination of label values
+type family struct {
+	name    string
+	help    string
+	kind    metricKind
+	labels  []string
+	buckets []float64 // Histogram upper bounds, ascending
+	series  map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64 // Counter and gauge value, histogram sum
+
+	bucketCounts []uint64 // Histogram observations per bucket, not cumulative
+	count        uint64
+}
+
+// Counter is a metric that only goes up
+type Counter struct {
+	r *Registry
+	f *family
+}
+
+// Gauge is a metric that is set to its current value
+type Gauge struct {
+	r *Registry
+	f *family
+}
+
+// Histogram counts observations in buckets
+type Histogram struct {
+	r *Registry
+	f *family
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(name, help string, kind metricKind, buckets []float64, labels []string) *family {
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, f)
+	return f
+}
+
+// Counter registers a counter with the given label names
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return &Counter{r: r, f: r.register(name, help, kindCounter, nil, labels)}
+}
+
+// Gauge registers a gauge with the given label names
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r: r, f: r.register(name, help, kindGauge, nil, labels)}
+}
+
+// Histogram registers a histogram with the given bucket upper bounds and
+// label names
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	buckets = slices.Sorted(slices.Values(buckets))
+	return &Histogram{r: r, f: r.register(name, help, kindHistogram, buckets, labels)}
+}
+
+// seriesLocked returns the series of labelValues, creating it if needed.
+// r.mu must be held.
+func (f *family) seriesLocked(labelValues []string) *series {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", f.name, len(f.labels), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: slices.Clone(labelValues)}
+		if f.kind == kindHistogram {
+			s.bucketCounts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Add increases the counter of labelValues by v
+func (c *Counter) Add(v float64, labelValues ...string) {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	c.f.seriesLocked(labelValues).value += v
+}
+
+// Inc increases the counter of labelValues by one
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Set sets the gauge of labelValues to v
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+	g.f.seriesLocked(labelValues).value = v
+}
+
+// DeleteMatching removes every series whose label is value
+func (g *Gauge) DeleteMatching(label, value string) {
+	i := slices.Index(g.f.labels, label)
+	if i < 0 {
+		return
+	}
+
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+	maps.DeleteFunc(g.f.series, func(_ string, s *series) bool {
+		return s.labelValues[i] == value
+	})
+}
+
+// Observe records v in the histogram of labelValues
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	s := h.f.seriesLocked(labelValues)
+	if i, _ := slices.BinarySearch(h.f.buckets, v); i < len(s.bucketCounts) {
+		s.bucketCounts[i]++
+	}
+	s.value += v
+	s.count++
+}
+
+// WriteText writes every metric in the Prometheus text format, families in
+// registration order and series sorted by label values
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range r.families {
+		fmt.Fprintf(bw, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", f.name, f.kind)
+
+		for _, key := range slices.Sorted(maps.Keys(f.series)) {
+			s := f.series[key]
+			if f.kind != kindHistogram {
+				writeSample(bw, f.name, f.labels, s.labelValues, "", s.value)
+				continue
+			}
+
+			labels := append(slices.Clone(f.labels), "le")
+			var cumulative uint64
+			for i, bound := range f.buckets {
+				cumulative += s.bucketCounts[i]
+				writeSample(bw, f.name+"_bucket", labels, append(slices.Clone(s.labelValues), formatValue(bound)), "", float64(cumulative))
+			}
+			writeSample(bw, f.name+"_bucket", labels, append(slices.Clone(s.labelValues), "+Inf"), "", float64(s.count))
+			writeSample(bw, f.name, f.labels, s.labelValues, "_sum", s.value)
+			writeSample(bw, f.name, f.labels, s.labelValues, "_count", float64(s.count))
+		}
+	}
+	return bw.Flush()
+}
+
+func writeSample(w *bufio.Writer, name string, labels, labelValues []string, suffix string, v float64) {
+	w.WriteString(name + suffix)
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label + `="` + escapeLabel(labelValues[i]) + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteString(" " + formatValue(v) + "\n")
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	r := NewRegistry()
+	requests := r.Counter("requests_total", "Requests by status.", "status")
+	stars := r.Gauge("stars", "Stars per repo.", "user", "repo")
+	duration := r.Histogram("duration_seconds", "Durations.", []float64{5, 1}, "result")
+
+	requests.Inc("200")
+	requests.Add(2, "200")
+	requests.Inc("404")
+	stars.Set(10, "octocat", "golang/go")
+	stars.Set(3, "octocat", `odd"repo`)
+	stars.Set(7, "other", "golang/go")
+	duration.Observe(0.5, "success")
+	duration.Observe(3, "success")
+	duration.Observe(60, "success")
+
+	stars.DeleteMatching("user", "other")
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	want := `# HELP requests_total Requests by status.
+# TYPE requests_total counter
+requests_total{status="200"} 3
+requests_total{status="404"} 1
+# HELP stars Stars per repo.
+# TYPE stars gauge
+stars{user="octocat",repo="golang/go"} 10
+stars{user="octocat",repo="odd\"repo"} 3
+# HELP duration_seconds Durations.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{result="success",le="1"} 1
+duration_seconds_bucket{result="success",le="5"} 2
+duration_seconds_bucket{result="success",le="+Inf"} 3
+duration_seconds_sum{result="success"} 63.5
+duration_seconds_count{result="success"} 3
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegistryLabelCountMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("setting a gauge with the wrong number of labels should panic")
+		}
+	}()
+	NewRegistry().Gauge("g", "help", "user").Set(1)
+}
@@ -0,0 +1,20 @@
+package replay
+
+import (
+	_ "embed"
+)
+
+// debugFixture is replayed in debug mode. It covers every fetch option with
+// the default filters, for a user with 17 merged PRs across 7 repositories.
+//
+//go:embed fixtures/debug.json
+var debugFixture []byte
+
+// DebugFixture returns the fixture replayed in debug mode
+func DebugFixture() *Fixture {
+	fixture, err := Parse(debugFixture)
+	if err != nil {
+		panic("replay: invalid debug fixture: " + err.Error())
+	}
+	return fixture
+}
@@ -0,0 +1,177 @@
+// Package replay records GitHub API exchanges to fixture files and replays
+// them, so tests and debug runs exercise the real API client without
+// network access.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+)
+
+// Fixture is a recorded sequence of API exchanges
+type Fixture struct {
+	// Username the fixture was recorded for. Replaying for another user
+	// rewrites it in the matched requests (see NewReplayer).
+	Username     string        `json:"username,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response it got
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request holds the parts of a request that responses are matched on
+type Request struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"` // Encoded with sorted keys
+	Body   json.RawMessage `json:"body,omitempty"`  // Compacted JSON
+}
+
+// Response is a recorded response. JSON bodies are kept as JSON so fixtures
+// stay readable; other bodies are kept as text.
+type Response struct {
+	Status   int             `json:"status"`
+	Header   http.Header     `json:"header,omitempty"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"bodyText,omitempty"`
+}
+
+// recordedHeaders are the response headers kept in fixtures. Others, such as
+// cookies, are dropped.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Retry-After",
+	"X-Github-Request-Id",
+	"X-Ratelimit-Limit",
+	"X-Ratelimit-Remaining",
+	"X-Ratelimit-Reset",
+	"X-Ratelimit-Resource",
+	"X-Ratelimit-Used",
+}
+
+// Load reads a fixture file
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+	fixture, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Parse decodes a fixture
+func Parse(data []byte) (*Fixture, error) {
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("parsing fixture: %w", err)
+	}
+	return &fixture, nil
+}
+
+// Save writes the fixture to path as indented JSON
+func (f *Fixture) Save(path string) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // Keep queries readable
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return fmt.Errorf("encoding fixture: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing fixture: %w", err)
+	}
+	return nil
+}
+
+// newRequest builds the matched parts of req, whose body has been read
+func newRequest(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  canonicalQuery(req.URL.RawQuery),
+		Body:   compactJSON(body),
+	}
+}
+
+// key identifies requests that get the same responses. Bodies are compacted
+// again since saved fixtures are indented.
+func (r Request) key() string {
+	return r.Method + " " + r.Path + "?" + r.Query + " " + string(compactJSON(r.Body))
+}
+
+// newResponse records resp with its body
+func newResponse(resp *http.Response, body []byte) Response {
+	recorded := Response{Status: resp.StatusCode, Header: make(http.Header)}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			recorded.Header[name] = slices.Clone(values)
+		}
+	}
+
+	if compact := compactJSON(body); compact != nil {
+		recorded.Body = compact
+	} else {
+		recorded.BodyText = string(body)
+	}
+	return recorded
+}
+
+// httpResponse rebuilds the recorded response to req
+func (r Response) httpResponse(req *http.Request) *http.Response {
+	body := []byte(r.BodyText)
+	if r.Body != nil {
+		body = r.Body
+	}
+
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// canonicalQuery sorts the query parameters so their order doesn't matter
+func canonicalQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	return values.Encode()
+}
+
+// compactJSON returns data without insignificant space, or nil if data is
+// empty or not JSON
+func compactJSON(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
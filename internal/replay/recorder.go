@@ -0,0 +1,130 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in recorded exchanges
+const redacted = "REDACTED"
+
+// secretParams are query parameters that are dropped from recorded requests
+var secretParams = []string{"access_token", "client_id", "client_secret"}
+
+// Recorder is an http.RoundTripper that records every exchange it sends.
+// Request headers, which carry the token, are never recorded, and the given
+// secrets are replaced wherever they appear.
+type Recorder struct {
+	base    http.RoundTripper
+	secrets []string
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder records the exchanges of requests sent through base (default:
+// http.DefaultTransport) for username
+func NewRecorder(base http.RoundTripper, username string, secrets ...string) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	r := &Recorder{base: base, fixture: Fixture{Username: username, Interactions: []Interaction{}}}
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+	return r
+}
+
+// RoundTrip sends req through the base transport and records the exchange
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+		req.Body.Close()
+
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request:  newRequest(req, reqBody),
+		Response: newResponse(resp, respBody),
+	}
+	r.scrub(&interaction)
+
+	r.mu.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Fixture returns the exchanges recorded so far
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fixture := r.fixture
+	fixture.Interactions = slices.Clone(r.fixture.Interactions)
+	return &fixture
+}
+
+// Save writes the exchanges recorded so far to path
+func (r *Recorder) Save(path string) error {
+	return r.Fixture().Save(path)
+}
+
+// scrub removes secrets from a recorded exchange
+func (r *Recorder) scrub(interaction *Interaction) {
+	req := &interaction.Request
+	if values, err := url.ParseQuery(req.Query); err == nil {
+		for _, param := range secretParams {
+			values.Del(param)
+		}
+		req.Query = values.Encode()
+	}
+
+	req.Path = r.redact(req.Path)
+	req.Query = r.redact(req.Query)
+	req.Body = []byte(r.redact(string(req.Body)))
+	if len(req.Body) == 0 {
+		req.Body = nil
+	}
+
+	resp := &interaction.Response
+	if resp.Body != nil {
+		resp.Body = []byte(r.redact(string(resp.Body)))
+	}
+	resp.BodyText = r.redact(resp.BodyText)
+}
+
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
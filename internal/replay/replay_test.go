@@ -0,0 +1,190 @@
+package replay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("Set-Cookie", "session=abc")
+		io.WriteString(w, `{"echo": "`+r.Header.Get("Authorization")+`"}`)
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(nil, "octocat", "secret-token")
+	client := &http.Client{Transport: recorder}
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/graphql?b=2&a=1&access_token=secret-token",
+		strings.NewReader(`{ "query": "q", "token": "secret-token" }`))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "secret-token") {
+		t.Errorf("caller should get the unscrubbed response, got %s", body)
+	}
+
+	fixture := recorder.Fixture()
+	if fixture.Username != "octocat" || len(fixture.Interactions) != 1 {
+		t.Fatalf("Fixture() = %+v", fixture)
+	}
+
+	interaction := fixture.Interactions[0]
+	if got := interaction.Request.Query; got != "a=1&b=2" {
+		t.Errorf("Query = %q, want sorted query without access_token", got)
+	}
+	if got := string(interaction.Request.Body); got != `{"query":"q","token":"REDACTED"}` {
+		t.Errorf("request Body = %s", got)
+	}
+	if got := string(interaction.Response.Body); got != `{"echo":"Bearer REDACTED"}` {
+		t.Errorf("response Body = %s", got)
+	}
+	if interaction.Response.Header.Get("X-RateLimit-Remaining") != "4999" {
+		t.Errorf("rate limit header not recorded: %v", interaction.Response.Header)
+	}
+	if interaction.Response.Header.Get("Set-Cookie") != "" {
+		t.Errorf("Set-Cookie should not be recorded")
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	fixture := &Fixture{
+		Username: "octocat",
+		Interactions: []Interaction{
+			{
+				Request:  Request{Method: http.MethodGet, Path: "/rate_limit"},
+				Response: Response{Status: http.StatusOK, Body: []byte(`{"n":1}`)},
+			},
+			{
+				Request:  Request{Method: http.MethodGet, Path: "/rate_limit"},
+				Response: Response{Status: http.StatusOK, Body: []byte(`{"n":2}`)},
+			},
+			{
+				Request:  Request{Method: http.MethodGet, Path: "/search/issues", Query: "page=1&q=author%3Aoctocat"},
+				Response: Response{Status: http.StatusForbidden, BodyText: "slow down"},
+			},
+			{
+				Request:  Request{Method: http.MethodPost, Path: "/graphql", Body: []byte("{\n  \"login\": \"octocat\"\n}")},
+				Response: Response{Status: http.StatusOK, Body: []byte(`{"data":{}}`)},
+			},
+		},
+	}
+
+	client := &http.Client{Transport: NewReplayer(fixture, "Someone")}
+	get := func(method, url, body string) (int, string, error) {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data), nil
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"first response", "GET", "https://api.github.com/rate_limit", "", 200, `{"n":1}`},
+		{"responses in order", "GET", "https://api.github.com/rate_limit", "", 200, `{"n":2}`},
+		{"last response repeats", "GET", "https://api.github.com/rate_limit", "", 200, `{"n":2}`},
+		{"query in any order and renamed user", "GET", "https://api.github.com/search/issues?q=author:someone&page=1", "", 403, "slow down"},
+		{"body matched on JSON", "POST", "https://api.github.com/graphql", `{"login":"Someone"}`, 200, `{"data":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body, err := get(tt.method, tt.url, tt.body)
+			if err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			if status != tt.wantStatus || body != tt.wantBody {
+				t.Errorf("got %d %q, want %d %q", status, body, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+
+	if _, _, err := get("GET", "https://api.github.com/search/issues?q=author:someone-else&page=1", ""); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("unmatched request error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayerIgnoreExclusions(t *testing.T) {
+	fixture := &Fixture{
+		Username: "octocat",
+		Interactions: []Interaction{{
+			Request:  Request{Method: http.MethodGet, Path: "/search/issues", Query: "q=author%3Aoctocat+-user%3Aoctocat"},
+			Response: Response{Status: http.StatusOK, Body: []byte(`{"items":[]}`)},
+		}},
+	}
+	query := "/search/issues?q=author:someone+-user:someone+-org:acme+-user:other"
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com"+query, nil)
+	if _, err := NewReplayer(fixture, "someone").RoundTrip(req); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("exclusions should be matched by default, got error %v", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "https://api.github.com"+query, nil)
+	resp, err := NewReplayer(fixture, "someone").IgnoreExclusions().RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	// The user's own repositories stay excluded
+	req, _ = http.NewRequest(http.MethodGet, "https://api.github.com/search/issues?q=author:someone+-org:acme", nil)
+	if _, err := NewReplayer(fixture, "someone").IgnoreExclusions().RoundTrip(req); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("a search without -user:someone should not match, got error %v", err)
+	}
+}
+
+func TestFixtureSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	fixture := &Fixture{
+		Username: "octocat",
+		Interactions: []Interaction{{
+			Request:  Request{Method: http.MethodPost, Path: "/graphql", Query: "a=1&b=2", Body: []byte(`{"query":"q"}`)},
+			Response: Response{Status: http.StatusOK, Body: []byte(`{"data":{}}`)},
+		}},
+	}
+	if err := fixture.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Username != "octocat" || len(loaded.Interactions) != 1 {
+		t.Fatalf("Load() = %+v", loaded)
+	}
+	if got, want := loaded.Interactions[0].Request.key(), fixture.Interactions[0].Request.key(); got != want {
+		t.Errorf("saved request key = %q, want %q", got, want)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() of a missing file should fail")
+	}
+}
+
+func TestDebugFixture(t *testing.T) {
+	fixture := DebugFixture()
+	if fixture.Username == "" || len(fixture.Interactions) == 0 {
+		t.Errorf("DebugFixture() = %d interactions for %q", len(fixture.Interactions), fixture.Username)
+	}
+}
@@ -0,0 +1,127 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrNoInteraction is returned for requests that have no recorded response
+var ErrNoInteraction = errors.New("no recorded response")
+
+// exclusion matches search qualifiers leaving out an owner's repositories
+var exclusion = regexp.MustCompile(`\s+-(?:org|user):\S+`)
+
+// Replayer is an http.RoundTripper answering requests with the responses of
+// a fixture, without network access. Requests are matched on method, path,
+// query (in any order) and JSON body. Requests recorded several times get
+// their responses in recorded order, the last one repeating.
+type Replayer struct {
+	responses map[string][]Response
+	rename    func(string) string
+	username  string // The user the fixture was recorded for
+
+	// ignoreExclusions drops -org: and -user: qualifiers from searches
+	ignoreExclusions bool
+
+	mu   sync.Mutex
+	sent map[string]int
+}
+
+// NewReplayer replays f for username. When it differs from the username f
+// was recorded for, requests for username are answered as if they were for
+// the recorded one, so a fixture can stand in for any user.
+func NewReplayer(f *Fixture, username string) *Replayer {
+	r := &Replayer{
+		responses: make(map[string][]Response),
+		rename:    func(s string) string { return s },
+		username:  f.Username,
+		sent:      make(map[string]int),
+	}
+	for _, interaction := range f.Interactions {
+		key := interaction.Request.key()
+		r.responses[key] = append(r.responses[key], interaction.Response)
+	}
+
+	if username != "" && f.Username != "" && username != f.Username {
+		// Only whole usernames are renamed, e.g. "author:name" but not "names"
+		pattern := regexp.MustCompile(`(?i)(^|[^A-Za-z0-9-])` + regexp.QuoteMeta(username) + `($|[^A-Za-z0-9-])`)
+		replacement := "${1}" + f.Username + "${2}"
+		r.rename = func(s string) string {
+			return pattern.ReplaceAllString(s, replacement)
+		}
+	}
+	return r
+}
+
+// IgnoreExclusions makes r answer searches as if they had no -org: or
+// -user: qualifiers but the one leaving out the user's own repositories, so
+// one fixture serves runs with any excluded organizations or owners. The
+// excluded repositories are then part of the responses.
+func (r *Replayer) IgnoreExclusions() *Replayer {
+	r.ignoreExclusions = true
+	return r
+}
+
+// RoundTrip returns the recorded response to req
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+	}
+
+	recorded := r.renamed(newRequest(req, body))
+	key := recorded.key()
+
+	r.mu.Lock()
+	responses := r.responses[key]
+	i := min(r.sent[key], len(responses)-1)
+	r.sent[key]++
+	r.mu.Unlock()
+
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+	}
+	return responses[i].httpResponse(req), nil
+}
+
+// renamed returns req with the replayed username replaced by the recorded one
+func (r *Replayer) renamed(req Request) Request {
+	req.Path = r.rename(req.Path)
+	if values, err := url.ParseQuery(req.Query); err == nil {
+		for name, list := range values {
+			for i := range list {
+				list[i] = r.rename(list[i])
+				if name == "q" && r.ignoreExclusions {
+					list[i] = r.withoutExclusions(list[i])
+				}
+			}
+		}
+		req.Query = values.Encode()
+	}
+	if req.Body != nil {
+		req.Body = []byte(r.rename(string(req.Body)))
+	}
+	return req
+}
+
+// withoutExclusions removes the exclusion qualifiers from a search query,
+// keeping the one for the recorded user's own repositories
+func (r *Replayer) withoutExclusions(query string) string {
+	return exclusion.ReplaceAllStringFunc(query, func(qualifier string) string {
+		if strings.EqualFold(strings.TrimSpace(qualifier), "-user:"+r.username) {
+			return qualifier
+		}
+		return ""
+	})
+}
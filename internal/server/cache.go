@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/metrics"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// cacheEntry holds the stats of one user and when they were fetched
+type cacheEntry struct {
+	stats     *ossstats.Stats
+	fetchedAt time.Time
+}
+
+// statsCache caches Stats per user. Entries younger than ttl are fresh. Entries
+// older than ttl but younger than ttl+staleTTL are served as-is while a single
+// background fetch revalidates them. Older entries are refetched synchronously.
+//
+// Concurrent misses for the same user share one fetch.
+type statsCache struct {
+	fetcher  StatsFetcher
+	ttl      time.Duration
+	staleTTL time.Duration
+	logger   ossstats.Logger
+	metrics  *metrics.Exporter // Optional
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	flights flightGroup
+}
+
+func newStatsCache(fetcher StatsFetcher, ttl, staleTTL time.Duration, logger ossstats.Logger) *statsCache {
+	return &statsCache{
+		fetcher:  fetcher,
+		ttl:      ttl,
+		staleTTL: staleTTL,
+		logger:   logger,
+		now:      time.Now,
+		entries:  make(map[string]*cacheEntry),
+	}
+}
+
+// get returns the stats for username and when they were fetched
+func (c *statsCache) get(ctx context.Context, username string) (*ossstats.Stats, time.Time, error) {
+	key := strings.ToLower(username)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok {
+		age := c.now().Sub(entry.fetchedAt)
+		if age < c.ttl {
+			return entry.stats, entry.fetchedAt, nil
+		}
+		if age < c.ttl+c.staleTTL {
+			c.revalidate(key, username)
+			return entry.stats, entry.fetchedAt, nil
+		}
+	}
+
+	// The fetch is shared with other requests, so it must not be canceled
+	// when this particular client goes away
+	entry, err := c.flights.do(key, func() (*cacheEntry, error) {
+		return c.fetch(context.WithoutCancel(ctx), key, username)
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return entry.stats, entry.fetchedAt, nil
+}
+
+// revalidate refreshes a stale entry in the background. Failures keep the
+// stale entry until it expires.
+func (c *statsCache) revalidate(key, username string) {
+	c.flights.doAsync(key, func() (*cacheEntry, error) {
+		entry, err := c.fetch(context.Background(), key, username)
+		if err != nil {
+			c.logger.Printf("Revalidating %s failed: %v", username, err)
+		}
+		return entry, err
+	})
+}
+
+// fetch loads stats from GitHub and stores them. Partial results are cached
+// like complete ones.
+func (c *statsCache) fetch(ctx context.Context, key, username string) (*cacheEntry, error) {
+	start := time.Now()
+	stats, err := c.fetcher.GetContributions(ctx, username)
+	if c.metrics != nil {
+		c.metrics.ObserveFetch(time.Since(start), err)
+	}
+	if err != nil {
+		var partialErr *ossstats.ErrPartialResults
+		if !errors.As(err, &partialErr) || partialErr.Stats == nil {
+			return nil, err
+		}
+		c.logger.Printf("Caching partial results for %s: %v", username, err)
+		stats = partialErr.Stats
+	}
+
+	if c.metrics != nil {
+		c.metrics.ObserveStats(stats)
+	}
+
+	entry := &cacheEntry{stats: stats, fetchedAt: c.now()}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.evictExpiredLocked()
+	c.mu.Unlock()
+
+	return entry, nil
+}
+
+// evictExpiredLocked drops entries too old to be served, and their
+// metrics. c.mu must be held.
+func (c *statsCache) evictExpiredLocked() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= c.ttl+c.staleTTL {
+			delete(c.entries, key)
+			if c.metrics != nil {
+				c.metrics.ForgetUser(entry.stats.Username)
+			}
+		}
+	}
+}
+
+// flightCall is an in-flight or completed fetch
+type flightCall struct {
+	wg    sync.WaitGroup
+	entry *cacheEntry
+	err   error
+}
+
+// flightGroup collapses concurrent fetches for the same key into one call
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call and returns its result
+func (g *flightGroup) do(key string, fn func() (*cacheEntry, error)) (*cacheEntry, error) {
+	call, started := g.begin(key)
+	if !started {
+		call.wg.Wait()
+		return call.entry, call.err
+	}
+
+	g.run(key, call, fn)
+	return call.entry, call.err
+}
+
+// doAsync runs fn for key in the background unless a call for key is
+// already in flight
+func (g *flightGroup) doAsync(key string, fn func() (*cacheEntry, error)) {
+	call, started := g.begin(key)
+	if started {
+		go g.run(key, call, fn)
+	}
+}
+
+// begin returns the in-flight call for key, or registers a new one and
+// reports that the caller must run it
+func (g *flightGroup) begin(key string) (*flightCall, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		return call, false
+	}
+
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	return call, true
+}
+
+// run executes fn for a call registered by begin and releases its waiters
+func (g *flightGroup) run(key string, call *flightCall, fn func() (*cacheEntry, error)) {
+	call.entry, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	call.wg.Done()
+}
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/metrics"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// fakeFetcher counts calls and optionally blocks until release is closed
+type fakeFetcher struct {
+	calls   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (f *fakeFetcher) GetContributions(ctx context.Context, username string) (*ossstats.Stats, error) {
+	n := f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &ossstats.Stats{
+		Username: username,
+		Summary:  ossstats.Summary{TotalProjects: int(n), TotalPRsMerged: 10 * int(n)},
+		Contributions: []ossstats.Contribution{
+			{Repo: "golang/go", Owner: "golang", RepoName: "go", Stars: 120000, PRsMerged: 3},
+		},
+	}, nil
+}
+
+// fakeClock is a manually advanced clock
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCache(fetcher StatsFetcher) (*statsCache, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	cache := newStatsCache(fetcher, time.Hour, 24*time.Hour, nopLogger{})
+	cache.now = clock.Now
+	return cache, clock
+}
+
+func TestStatsCacheFresh(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	cache, clock := newTestCache(fetcher)
+
+	for range 3 {
+		if _, _, err := cache.get(context.Background(), "octocat"); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+		clock.Advance(10 * time.Minute)
+	}
+
+	if got := fetcher.calls.Load(); got != 1 {
+		t.Errorf("fetch calls = %d, want 1", got)
+	}
+}
+
+func TestStatsCacheCaseInsensitive(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	cache, _ := newTestCache(fetcher)
+
+	cache.get(context.Background(), "OctoCat")
+	cache.get(context.Background(), "octocat")
+
+	if got := fetcher.calls.Load(); got != 1 {
+		t.Errorf("fetch calls = %d, want 1", got)
+	}
+}
+
+func TestStatsCacheStaleWhileRevalidate(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	cache, clock := newTestCache(fetcher)
+
+	first, _, _ := cache.get(context.Background(), "octocat")
+	clock.Advance(2 * time.Hour)
+
+	// Block the background refresh so the stale value is observable
+	fetcher.release = make(chan struct{})
+	stale, _, err := cache.get(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if stale != first {
+		t.Error("stale get() should return the cached stats")
+	}
+
+	close(fetcher.release)
+	waitFor(t, func() bool {
+		fresh, _, _ := cache.get(context.Background(), "octocat")
+		return fresh != first
+	})
+
+	if got := fetcher.calls.Load(); got != 2 {
+		t.Errorf("fetch calls = %d, want 2", got)
+	}
+}
+
+func TestStatsCacheExpired(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	cache, clock := newTestCache(fetcher)
+
+	first, _, _ := cache.get(context.Background(), "octocat")
+	clock.Advance(26 * time.Hour)
+
+	second, _, _ := cache.get(context.Background(), "octocat")
+	if second == first {
+		t.Error("expired entry should be refetched synchronously")
+	}
+	if len(cache.entries) != 1 {
+		t.Errorf("entries = %d, want 1", len(cache.entries))
+	}
+}
+
+func TestStatsCacheExpiredMetrics(t *testing.T) {
+	cache, clock := newTestCache(&fakeFetcher{})
+	cache.metrics = metrics.NewExporter()
+
+	cache.get(context.Background(), "octocat")
+	clock.Advance(26 * time.Hour)
+	cache.get(context.Background(), "hubot")
+
+	rec := httptest.NewRecorder()
+	cache.metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if out := rec.Body.String(); strings.Contains(out, `user="octocat"`) || !strings.Contains(out, `user="hubot"`) {
+		t.Errorf("metrics should only contain hubot after octocat expired:\n%s", out)
+	}
+}
+
+func TestStatsCacheSingleFlight(t *testing.T) {
+	fetcher := &fakeFetcher{release: make(chan struct{})}
+	cache, _ := newTestCache(fetcher)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := cache.get(context.Background(), "octocat"); err != nil {
+				t.Errorf("get() error = %v", err)
+			}
+		}()
+	}
+
+	waitFor(t, func() bool { return fetcher.calls.Load() == 1 })
+	time.Sleep(10 * time.Millisecond)
+	close(fetcher.release)
+	wg.Wait()
+
+	if got := fetcher.calls.Load(); got != 1 {
+		t.Errorf("fetch calls = %d, want 1", got)
+	}
+}
+
+func TestStatsCacheErrorNotCached(t *testing.T) {
+	fetcher := &fakeFetcher{err: errors.New("boom")}
+	cache, _ := newTestCache(fetcher)
+
+	for range 2 {
+		if _, _, err := cache.get(context.Background(), "octocat"); err == nil {
+			t.Fatal("get() should return the fetch error")
+		}
+	}
+
+	if got := fetcher.calls.Load(); got != 2 {
+		t.Errorf("fetch calls = %d, want 2", got)
+	}
+}
+
+func TestStatsCachePartialResults(t *testing.T) {
+	partial := &ossstats.Stats{Username: "octocat"}
+	fetcher := &partialFetcher{stats: partial}
+	cache, _ := newTestCache(fetcher)
+
+	stats, _, err := cache.get(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if stats != partial {
+		t.Error("partial results should be cached and returned")
+	}
+}
+
+type partialFetcher struct {
+	stats *ossstats.Stats
+}
+
+func (f *partialFetcher) GetContributions(ctx context.Context, username string) (*ossstats.Stats, error) {
+	return f.stats, &ossstats.ErrPartialResults{Stats: f.stats, Errors: []error{errors.New("boom")}}
+}
+
+// waitFor polls cond until it is true or the test times out
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
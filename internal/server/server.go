@@ -0,0 +1,330 @@
+// Package server serves live badges and stats JSON over HTTP, caching the
+// GitHub data per user so a self-hosted instance stays within rate limits.
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/metrics"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+var (
+	// DefaultTTL is how long fetched stats are considered fresh
+	DefaultTTL = 1 * time.Hour
+
+	// DefaultStaleTTL is how long stats may be served past DefaultTTL while
+	// they are refreshed in the background
+	DefaultStaleTTL = 24 * time.Hour
+)
+
+// usernamePattern matches valid GitHub logins: alphanumerics and single
+// hyphens, at most 39 characters
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38}$`)
+
+// StatsFetcher fetches the stats of a user. *ossstats.Client implements it.
+type StatsFetcher interface {
+	GetContributions(ctx context.Context, username string) (*ossstats.Stats, error)
+}
+
+// Config configures a Server
+type Config struct {
+	TTL          time.Duration      // Default: DefaultTTL
+	StaleTTL     time.Duration      // Default: DefaultStaleTTL, negative disables stale serving
+	BadgeOptions badge.BadgeOptions // Defaults for badge query parameters
+	Logger       ossstats.Logger    // Default: discards all messages
+
+	// AllowUsers, when set, are the only users whose stats are served
+	// (case-insensitive). Others get 403 without a GitHub fetch. Default: any
+	// user, so a public server can be made to fetch and cache anyone.
+	AllowUsers []string
+
+	// Metrics, when set, is served at /metrics and receives the stats and
+	// duration of every fetch
+	Metrics *metrics.Exporter
+}
+
+// Server serves:
+//
+//	GET /u/{user}/badge.svg?style=&variant=&theme=&sort=&limit=&locale=
+//	GET /u/{user}/stats.json
+//	GET /healthz
+//	GET /metrics (when Config.Metrics is set)
+type Server struct {
+	cache    *statsCache
+	allowed  map[string]bool // Lowercased AllowUsers, nil allows any user
+	defaults badge.BadgeOptions
+	logger   ossstats.Logger
+	mux      *http.ServeMux
+}
+
+// New creates a Server that fetches stats with fetcher
+func New(fetcher StatsFetcher, conf Config) *Server {
+	if conf.TTL <= 0 {
+		conf.TTL = DefaultTTL
+	}
+	if conf.StaleTTL < 0 {
+		conf.StaleTTL = 0
+	} else if conf.StaleTTL == 0 {
+		conf.StaleTTL = DefaultStaleTTL
+	}
+	if conf.Logger == nil {
+		conf.Logger = nopLogger{}
+	}
+	if conf.BadgeOptions.Style == "" {
+		conf.BadgeOptions.Style = badge.DefaultBadgeStyle
+	}
+	if conf.BadgeOptions.Variant == "" {
+		conf.BadgeOptions.Variant = badge.DefaultBadgeVariant
+	}
+	if conf.BadgeOptions.Theme == "" {
+		conf.BadgeOptions.Theme = badge.DefaultBadgeTheme
+	}
+
+	s := &Server{
+		cache:    newStatsCache(fetcher, conf.TTL, conf.StaleTTL, conf.Logger),
+		defaults: conf.BadgeOptions,
+		logger:   conf.Logger,
+		mux:      http.NewServeMux(),
+	}
+	for _, user := range conf.AllowUsers {
+		if s.allowed == nil {
+			s.allowed = make(map[string]bool, len(conf.AllowUsers))
+		}
+		s.allowed[strings.ToLower(user)] = true
+	}
+
+	s.mux.HandleFunc("GET /u/{user}/badge.svg", s.handleBadge)
+	s.mux.HandleFunc("GET /u/{user}/stats.json", s.handleStats)
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+
+	if conf.Metrics != nil {
+		s.cache.metrics = conf.Metrics
+		s.mux.Handle("GET /metrics", conf.Metrics)
+	}
+
+	return s
+}
+
+// Track keeps the stats of users cached, fetching them now and then every
+// interval until ctx is done, so their metrics stay current without badge
+// or stats requests
+func (s *Server) Track(ctx context.Context, users []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, username := range users {
+			if _, _, err := s.cache.get(ctx, username); err != nil {
+				s.logger.Printf("Fetching %s failed: %v", username, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprintln(w, "ok")
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	stats, fetchedAt, ok := s.loadStats(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		http.Error(w, "failed to encode stats", http.StatusInternalServerError)
+		return
+	}
+
+	s.write(w, r, "application/json; charset=utf-8", body, fetchedAt)
+}
+
+func (s *Server) handleBadge(w http.ResponseWriter, r *http.Request) {
+	opts, err := s.badgeOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, fetchedAt, ok := s.loadStats(w, r)
+	if !ok {
+		return
+	}
+
+	svg, err := badge.RenderSVG(stats, opts)
+	if err != nil {
+		http.Error(w, "failed to render badge", http.StatusInternalServerError)
+		return
+	}
+
+	s.write(w, r, "image/svg+xml; charset=utf-8", []byte(svg), fetchedAt)
+}
+
+// loadStats validates the user path value and returns the cached stats. On
+// failure the error response has already been written.
+func (s *Server) loadStats(w http.ResponseWriter, r *http.Request) (*ossstats.Stats, time.Time, bool) {
+	username := r.PathValue("user")
+	if !usernamePattern.MatchString(username) {
+		http.Error(w, "invalid GitHub username", http.StatusBadRequest)
+		return nil, time.Time{}, false
+	}
+	if s.allowed != nil && !s.allowed[strings.ToLower(username)] {
+		http.Error(w, fmt.Sprintf("user not served: %s", username), http.StatusForbidden)
+		return nil, time.Time{}, false
+	}
+
+	stats, fetchedAt, err := s.cache.get(r.Context(), username)
+	if err != nil {
+		s.writeError(w, username, err)
+		return nil, time.Time{}, false
+	}
+
+	return stats, fetchedAt, true
+}
+
+// badgeOptions builds badge options from the query string, falling back to
+// the server defaults for missing parameters
+func (s *Server) badgeOptions(r *http.Request) (badge.BadgeOptions, error) {
+	opts := s.defaults
+	query := r.URL.Query()
+
+	var err error
+	if v := query.Get("style"); v != "" {
+		if opts.Style, err = badge.BadgeStyleFromName(v); err != nil {
+			return opts, err
+		}
+	}
+	if v := query.Get("variant"); v != "" {
+		if opts.Variant, err = badge.BadgeVariantFromName(v); err != nil {
+			return opts, err
+		}
+	}
+	if v := query.Get("theme"); v != "" {
+		if opts.Theme, err = badge.BadgeThemeFromName(v); err != nil {
+			return opts, err
+		}
+	}
+	if v := query.Get("sort"); v != "" {
+		if opts.SortBy, err = badge.SortByFromName(v); err != nil {
+			return opts, err
+		}
+	}
+	if v := query.Get("locale"); v != "" {
+		if opts.Locale, err = badge.BadgeLocaleFromName(v); err != nil {
+			return opts, err
+		}
+	}
+	if v := query.Get("acceptance"); v != "" {
+		if opts.ShowAcceptanceRate, err = strconv.ParseBool(v); err != nil {
+			return opts, fmt.Errorf("invalid acceptance: %s (must be true or false)", v)
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > 25 {
+			return opts, fmt.Errorf("invalid limit: %s (must be between 1 and 25)", v)
+		}
+		opts.Limit = limit
+	}
+
+	// Avatars need extra downloads per request, so they are never served live
+	opts.ShowAvatars = false
+	opts.Avatars = nil
+
+	return opts, nil
+}
+
+// write sends body with caching headers derived from its content and age,
+// answering conditional requests with 304 Not Modified
+func (s *Server) write(w http.ResponseWriter, r *http.Request, contentType string, body []byte, fetchedAt time.Time) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := w.Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", s.cacheControl(fetchedAt))
+	header.Set("Last-Modified", fetchedAt.UTC().Format(http.TimeFormat))
+
+	if match := r.Header.Get("If-None-Match"); match != "" && (match == etag || match == "*") {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.Write(body)
+}
+
+// cacheControl lets downstream caches (GitHub's camo, CDNs) keep a response
+// until the cached stats go stale, then serve it while they revalidate
+func (s *Server) cacheControl(fetchedAt time.Time) string {
+	fresh := s.cache.ttl - s.cache.now().Sub(fetchedAt)
+	maxAge := max(int(math.Ceil(fresh.Seconds())), 0)
+	swr := int(s.cache.staleTTL.Seconds())
+
+	return fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", maxAge, swr)
+}
+
+// writeError maps fetch errors to HTTP status codes
+func (s *Server) writeError(w http.ResponseWriter, username string, err error) {
+	var (
+		notFoundErr  *ossstats.ErrUserNotFound
+		rateLimitErr *ossstats.ErrRateLimited
+		secondaryErr *ossstats.ErrSecondaryRateLimit
+		authErr      *ossstats.ErrAuthentication
+		forbiddenErr *ossstats.ErrForbidden
+	)
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, fmt.Sprintf("user not found: %s", username), http.StatusNotFound)
+	case errors.As(err, &rateLimitErr):
+		if wait := time.Until(rateLimitErr.ResetAt); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		}
+		http.Error(w, "GitHub rate limit exceeded, try again later", http.StatusServiceUnavailable)
+	case errors.As(err, &secondaryErr):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(secondaryErr.RetryAfter.Seconds()))))
+		http.Error(w, "GitHub rate limit exceeded, try again later", http.StatusServiceUnavailable)
+	case errors.As(err, &authErr), errors.As(err, &forbiddenErr):
+		s.logger.Printf("Fetching %s failed: %v", username, err)
+		http.Error(w, "server is not authorized to query GitHub", http.StatusBadGateway)
+	default:
+		s.logger.Printf("Fetching %s failed: %v", username, err)
+		http.Error(w, "failed to fetch stats from GitHub", http.StatusBadGateway)
+	}
+}
+
+// nopLogger discards all log messages
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, v ...any) {}
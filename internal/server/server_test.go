@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/metrics"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func newTestServer(fetcher StatsFetcher) (*Server, *fakeClock) {
+	s := New(fetcher, Config{TTL: time.Hour, StaleTTL: 24 * time.Hour})
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.cache.now = clock.Now
+	return s, clock
+}
+
+func doRequest(s *Server, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServerHealth(t *testing.T) {
+	s, _ := newTestServer(&fakeFetcher{})
+
+	rec := doRequest(s, "/healthz", nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestServerMetrics(t *testing.T) {
+	s, _ := newTestServer(&fakeFetcher{})
+	if rec := doRequest(s, "/metrics", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("status without metrics = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	s = New(&fakeFetcher{}, Config{Metrics: metrics.NewExporter()})
+	doRequest(s, "/u/octocat/stats.json", nil)
+
+	rec := doRequest(s, "/metrics", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	for _, want := range []string{
+		`gh_oss_stats_user_prs_merged{user="octocat"} 10`,
+		`gh_oss_stats_repo_stars{user="octocat",repo="golang/go"} 120000`,
+		`gh_oss_stats_fetch_duration_seconds_count{result="success"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("metrics missing %q:\n%s", want, rec.Body)
+		}
+	}
+}
+
+func TestServerTrack(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	s := New(fetcher, Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Track(ctx, []string{"octocat", "other"}, time.Hour)
+
+	if n := fetcher.calls.Load(); n != 2 {
+		t.Errorf("fetches = %d, want 2", n)
+	}
+}
+
+func TestServerBadge(t *testing.T) {
+	s, clock := newTestServer(&fakeFetcher{})
+
+	rec := doRequest(s, "/u/octocat/badge.svg?style=compact&theme=light", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "image/svg+xml") {
+		t.Errorf("Content-Type = %q, want image/svg+xml", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "<svg") {
+		t.Error("body should contain an SVG")
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=3600, stale-while-revalidate=86400" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+
+	clock.Advance(15 * time.Minute)
+	rec = doRequest(s, "/u/octocat/badge.svg?style=compact&theme=light", nil)
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=2700, stale-while-revalidate=86400" {
+		t.Errorf("Cache-Control after 15m = %q", cc)
+	}
+}
+
+func TestServerStats(t *testing.T) {
+	s, _ := newTestServer(&fakeFetcher{})
+
+	rec := doRequest(s, "/u/octocat/stats.json", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `"username": "octocat"`) {
+		t.Errorf("body should contain the username, got %s", rec.Body)
+	}
+}
+
+func TestServerETag(t *testing.T) {
+	s, _ := newTestServer(&fakeFetcher{})
+
+	rec := doRequest(s, "/u/octocat/stats.json", nil)
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag header missing")
+	}
+
+	rec = doRequest(s, "/u/octocat/stats.json", http.Header{"If-None-Match": {etag}})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+	if rec.Body.Len() != 0 {
+		t.Error("304 response should have no body")
+	}
+
+	// Different badge options produce a different ETag
+	a := doRequest(s, "/u/octocat/badge.svg?theme=dark", nil).Header().Get("ETag")
+	b := doRequest(s, "/u/octocat/badge.svg?theme=light", nil).Header().Get("ETag")
+	if a == b {
+		t.Error("ETag should differ between themes")
+	}
+}
+
+func TestServerBadRequests(t *testing.T) {
+	s, _ := newTestServer(&fakeFetcher{})
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"invalid username", "/u/-bad-/badge.svg"},
+		{"username too long", "/u/" + strings.Repeat("a", 40) + "/stats.json"},
+		{"invalid style", "/u/octocat/badge.svg?style=huge"},
+		{"invalid theme", "/u/octocat/badge.svg?theme=neon"},
+		{"invalid limit", "/u/octocat/badge.svg?limit=0"},
+		{"limit too large", "/u/octocat/badge.svg?limit=1000"},
+		{"invalid acceptance", "/u/octocat/badge.svg?acceptance=maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(s, tt.path, nil)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestServerAllowUsers(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	s := New(fetcher, Config{AllowUsers: []string{"OctoCat"}})
+
+	if rec := doRequest(s, "/u/octocat/badge.svg", nil); rec.Code != http.StatusOK {
+		t.Errorf("status for an allowed user = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec := doRequest(s, "/u/someone/stats.json", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("status for another user = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if n := fetcher.calls.Load(); n != 1 {
+		t.Errorf("fetches = %d, want 1 (only the allowed user)", n)
+	}
+}
+
+func TestServerFetchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", &ossstats.ErrNotFound{Username: "ghost"}, http.StatusNotFound},
+		{"rate limited", &ossstats.ErrRateLimited{ResetAt: time.Now().Add(time.Minute)}, http.StatusServiceUnavailable},
+		{"secondary rate limit", &ossstats.ErrSecondaryRateLimit{RetryAfter: time.Minute}, http.StatusServiceUnavailable},
+		{"authentication", &ossstats.ErrAuthentication{}, http.StatusBadGateway},
+		{"forbidden", fmt.Errorf("searching issues: %w", &ossstats.ErrForbidden{}), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer(&fakeFetcher{err: tt.err})
+
+			rec := doRequest(s, "/u/ghost/badge.svg", nil)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if cc := rec.Header().Get("Cache-Control"); cc != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", cc)
+			}
+		})
+	}
+}
+
+func TestServerUnknownRoute(t *testing.T) {
+	s, _ := newTestServer(&fakeFetcher{})
+
+	rec := doRequest(s, "/u/octocat/other.png", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
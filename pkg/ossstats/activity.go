@@ -0,0 +1,193 @@
+package ossstats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// activityKind is a contribution kind other than merged PRs
+type activityKind int
+
+const (
+	activityReview activityKind = iota
+	activityIssueOpened
+	activityDiscussionAnswer
+	activityPROpen
+	activityPRClosedUnmerged
+	activityCommit
+)
+
+// activity is a single non-PR contribution to an external repository
+type activity struct {
+	owner string
+	repo  string
+	kind  activityKind
+	at    time.Time
+}
+
+// fetchActivity collects the optional contribution kinds enabled on the
+// client: open and closed-unmerged PRs, PR reviews, opened issues and
+// discussion answers. A failing kind is reported as an error without
+// stopping the others.
+func (c *Client) fetchActivity(ctx context.Context, api github.GithubAPI, username string) ([]activity, []error) {
+	var activities []activity
+	var errors []error
+
+	if c.includePRStates {
+		c.logger.Info("Searching for open and closed-unmerged PRs")
+		query := fmt.Sprintf("author:%s type:pr is:open", username) + c.searchExclusions(username)
+		issues, err := c.searchAll(ctx, api, query, username)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("searching open PRs: %w", err))
+		}
+		activities = append(activities, issueActivities(issues, activityPROpen)...)
+
+		query = fmt.Sprintf("author:%s type:pr is:closed is:unmerged", username) + c.searchExclusions(username)
+		issues, err = c.searchAll(ctx, api, query, username)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("searching closed PRs: %w", err))
+		}
+		activities = append(activities, issueActivities(issues, activityPRClosedUnmerged)...)
+	}
+
+	if c.includeReviews {
+		c.logger.Info("Searching for PR reviews")
+		// Reviews on the user's own PRs are not contributions
+		query := fmt.Sprintf("type:pr reviewed-by:%s -author:%s", username, username) + c.searchExclusions(username)
+		issues, err := c.searchAll(ctx, api, query, username)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("searching reviews: %w", err))
+		}
+		activities = append(activities, issueActivities(issues, activityReview)...)
+	}
+
+	if c.includeIssues {
+		c.logger.Info("Searching for opened issues")
+		query := fmt.Sprintf("type:issue author:%s", username) + c.searchExclusions(username)
+		issues, err := c.searchAll(ctx, api, query, username)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("searching issues: %w", err))
+		}
+		activities = append(activities, issueActivities(issues, activityIssueOpened)...)
+	}
+
+	if c.includeDiscussions {
+		c.logger.Info("Fetching discussion answers")
+		answers, _, err := api.ListDiscussionAnswers(ctx, username, c.maxPRs)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("fetching discussion answers: %w", err))
+		}
+		for _, answer := range answers {
+			owner, repo, ok := strings.Cut(answer.Discussion.Repository.NameWithOwner, "/")
+			if !ok || c.isExcludedOwner(owner, username) {
+				continue
+			}
+			activities = append(activities, activity{owner: owner, repo: repo, kind: activityDiscussionAnswer, at: answer.CreatedAt})
+		}
+	}
+
+	return activities, errors
+}
+
+// issueActivities converts search results into activities of the given kind
+func issueActivities(issues []github.Issue, kind activityKind) []activity {
+	activities := make([]activity, 0, len(issues))
+	for _, iss := range issues {
+		owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
+		if err != nil {
+			continue
+		}
+		at := iss.CreatedAt
+		switch {
+		case kind == activityReview:
+			at = iss.UpdatedAt // The search doesn't return when the review was given
+		case kind == activityPRClosedUnmerged && iss.ClosedAt != nil:
+			at = *iss.ClosedAt
+		}
+		activities = append(activities, activity{owner: owner, repo: repo, kind: kind, at: at})
+	}
+	return activities
+}
+
+// isExcludedOwner reports whether the owner's repos are left out of the
+// search (see searchExclusions). Used for results that don't come from search.
+func (c *Client) isExcludedOwner(owner, username string) bool {
+	if strings.EqualFold(owner, username) || containsFold(c.excludeOrgs, owner) {
+		return true
+	}
+	for _, filter := range c.filters {
+		if containsFold(filter.ExcludeOwners, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeActivity adds the activities to the contributions, creating entries
+// for repositories the user has no merged PRs in.
+func mergeActivity(contributions []Contribution, activities []activity) []Contribution {
+	index := make(map[string]int, len(contributions))
+	for i, contrib := range contributions {
+		index[contrib.Repo] = i
+	}
+
+	for _, act := range activities {
+		repoKey := act.owner + "/" + act.repo
+		i, exists := index[repoKey]
+		if !exists {
+			contributions = append(contributions, Contribution{
+				Repo:              repoKey,
+				Owner:             act.owner,
+				RepoName:          act.repo,
+				FirstContribution: act.at,
+				LastContribution:  act.at,
+			})
+			i = len(contributions) - 1
+			index[repoKey] = i
+		}
+
+		contrib := &contributions[i]
+		switch act.kind {
+		case activityReview:
+			contrib.Reviews++
+		case activityIssueOpened:
+			contrib.IssuesOpened++
+		case activityDiscussionAnswer:
+			contrib.DiscussionAnswers++
+		case activityPROpen:
+			contrib.PRsOpen++
+		case activityPRClosedUnmerged:
+			contrib.PRsClosedUnmerged++
+		case activityCommit:
+			// A repo whose PR commits weren't counted stays unknown
+			if contrib.Commits != nil || contrib.PRsMerged == 0 {
+				contrib.addCommits(1)
+			}
+		}
+
+		if act.at.Before(contrib.FirstContribution) {
+			contrib.FirstContribution = act.at
+		}
+		if act.at.After(contrib.LastContribution) {
+			contrib.LastContribution = act.at
+		}
+	}
+
+	return contributions
+}
+
+// acceptanceRate returns the share of decided PRs that were merged, from 0
+// to 1. Open PRs are not decided yet and don't count. Returns nil when no PR
+// was decided.
+func acceptanceRate(merged, closedUnmerged int) *float64 {
+	decided := merged + closedUnmerged
+	if decided == 0 {
+		return nil
+	}
+	rate := float64(merged) / float64(decided)
+	return &rate
+}
@@ -0,0 +1,303 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestMergeActivity(t *testing.T) {
+	first := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	earlier := first.AddDate(0, -1, 0)
+	later := first.AddDate(0, 1, 0)
+
+	contributions := []Contribution{
+		{Repo: "a/one", Owner: "a", RepoName: "one", PRsMerged: 1, FirstContribution: first, LastContribution: first},
+	}
+	activities := []activity{
+		{owner: "a", repo: "one", kind: activityReview, at: earlier},
+		{owner: "a", repo: "one", kind: activityIssueOpened, at: later},
+		{owner: "b", repo: "two", kind: activityDiscussionAnswer, at: first},
+		{owner: "b", repo: "two", kind: activityReview, at: later},
+	}
+
+	merged := mergeActivity(contributions, activities)
+	if len(merged) != 2 {
+		t.Fatalf("len(merged) = %d, want 2", len(merged))
+	}
+
+	one := merged[0]
+	if one.Reviews != 1 || one.IssuesOpened != 1 || one.PRsMerged != 1 {
+		t.Errorf("a/one = %+v", one)
+	}
+	if !one.FirstContribution.Equal(earlier) || !one.LastContribution.Equal(later) {
+		t.Errorf("a/one range = %v..%v, want %v..%v", one.FirstContribution, one.LastContribution, earlier, later)
+	}
+
+	two := merged[1]
+	if two.Repo != "b/two" || two.Owner != "b" || two.RepoName != "two" {
+		t.Errorf("b/two = %+v", two)
+	}
+	if two.DiscussionAnswers != 1 || two.Reviews != 1 || two.PRsMerged != 0 {
+		t.Errorf("b/two counts = %+v", two)
+	}
+}
+
+func TestGetContributionsWithActivity(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	pr := github.Issue{
+		Number:        1,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		PullRequest:   &github.PullRequestRef{MergedAt: &at},
+	}
+	reviewed := github.Issue{Number: 2, RepositoryURL: "https://api.github.com/repos/owner/repo", UpdatedAt: at}
+	issue := github.Issue{Number: 3, RepositoryURL: "https://api.github.com/repos/other/tool", CreatedAt: at}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+
+			var items []github.Issue
+			switch {
+			case strings.Contains(q, "is:merged"):
+				items = []github.Issue{pr}
+			case strings.Contains(q, "reviewed-by:testuser"):
+				items = []github.Issue{reviewed}
+			case strings.Contains(q, "type:issue"):
+				items = []github.Issue{issue}
+			}
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: len(items), Items: items})
+
+		case r.URL.Path == "/graphql":
+			var req github.GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if strings.Contains(req.Query, "closingIssuesReferences") {
+				w.Write([]byte(`{"data":{"repository":{"pullRequest":{"closingIssuesReferences":{"totalCount":2}}}}}`))
+				return
+			}
+			w.Write([]byte(`{"data":{"user":{"repositoryDiscussionComments":{
+				"pageInfo":{"hasNextPage":false},
+				"nodes":[
+					{"createdAt":"2024-03-02T00:00:00Z","discussion":{"repository":{"nameWithOwner":"owner/repo"}}},
+					{"createdAt":"2024-03-02T00:00:00Z","discussion":{"repository":{"nameWithOwner":"testuser/own-repo"}}}
+				]
+			}}}}`))
+
+		case strings.HasPrefix(r.URL.Path, "/repos/"):
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(
+		WithToken("test-token"),
+		WithReviews(true),
+		WithIssues(true),
+		WithClosedIssues(true),
+		WithDiscussionAnswers(true),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := Summary{
+		TotalProjects:          2,
+		TotalPRsMerged:         1,
+		TotalCommits:           nil, // Not fetched without LOC or WithCommitCounts
+		TotalReviews:           1,
+		TotalIssuesOpened:      1,
+		TotalIssuesClosed:      2,
+		TotalDiscussionAnswers: 1, // The answer in the user's own repo doesn't count
+		Accuracy:               Accuracy{Commits: AccuracyUnknown, Lines: AccuracyUnknown},
+	}
+	if stats.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", stats.Summary, want)
+	}
+
+	for _, q := range queries {
+		if !strings.Contains(q, "-user:testuser") {
+			t.Errorf("query %q should exclude the user's own repos", q)
+		}
+	}
+	if len(queries) != 3 {
+		t.Errorf("searches = %d, want 3 (PRs, reviews, issues)", len(queries))
+	}
+}
+
+func TestGetContributionsWithReviewsAndLOC(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	pr := func(number int) github.Issue {
+		return github.Issue{
+			Number:        number,
+			RepositoryURL: "https://api.github.com/repos/owner/repo",
+			PullRequest:   &github.PullRequestRef{MergedAt: &at},
+		}
+	}
+	reviewed := github.Issue{Number: 9, RepositoryURL: "https://api.github.com/repos/other/tool", UpdatedAt: at}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			items := []github.Issue{pr(1), pr(2)}
+			if strings.Contains(r.URL.Query().Get("q"), "reviewed-by:testuser") {
+				items = []github.Issue{reviewed}
+			}
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: len(items), Items: items})
+
+		case strings.Contains(r.URL.Path, "/pulls/1"):
+			json.NewEncoder(w).Encode(github.PullRequest{Number: 1, Merged: true, Commits: 1, Additions: 10})
+
+		case strings.Contains(r.URL.Path, "/pulls/2"):
+			json.NewEncoder(w).Encode(github.PullRequest{Number: 2, Merged: true, Commits: 2, Additions: 5})
+
+		default:
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithReviews(true), WithLOC(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The review-only repo has no PR commits, so the total stays known
+	if n := stats.Summary.TotalCommits; n == nil || *n != 3 {
+		t.Errorf("TotalCommits = %s, want 3", fmtInt(n))
+	}
+	if stats.Summary.Accuracy.Commits != AccuracyExact {
+		t.Errorf("Accuracy.Commits = %s, want %s", stats.Summary.Accuracy.Commits, AccuracyExact)
+	}
+	if stats.Summary.TotalProjects != 2 || stats.Summary.TotalReviews != 1 {
+		t.Errorf("Summary = %+v, want 2 projects and 1 review", stats.Summary)
+	}
+}
+
+func TestGetContributionsActivityDisabledByDefault(t *testing.T) {
+	searches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphql" {
+			t.Error("GraphQL should not be called when no optional kind is enabled")
+		}
+		if strings.HasPrefix(r.URL.Path, "/search/issues") {
+			searches++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(github.SearchIssuesResponse{})
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	if _, err := client.GetContributions(context.Background(), "testuser"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if searches != 1 {
+		t.Errorf("searches = %d, want 1", searches)
+	}
+}
+
+func TestAcceptanceRate(t *testing.T) {
+	if rate := acceptanceRate(0, 0); rate != nil {
+		t.Errorf("acceptanceRate(0, 0) = %v, want nil", *rate)
+	}
+	if rate := acceptanceRate(3, 1); rate == nil || *rate != 0.75 {
+		t.Errorf("acceptanceRate(3, 1) = %v, want 0.75", rate)
+	}
+	if rate := acceptanceRate(0, 2); rate == nil || *rate != 0 {
+		t.Errorf("acceptanceRate(0, 2) = %v, want 0", rate)
+	}
+}
+
+func TestGetContributionsWithPRStates(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repoPR := func(number int, repo string) github.Issue {
+		return github.Issue{
+			Number:        number,
+			RepositoryURL: "https://api.github.com/repos/" + repo,
+			CreatedAt:     at,
+			ClosedAt:      &at,
+			PullRequest:   &github.PullRequestRef{MergedAt: &at},
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+			return
+		}
+
+		var items []github.Issue
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, "is:merged"):
+			items = []github.Issue{repoPR(1, "owner/repo"), repoPR(2, "owner/repo"), repoPR(3, "owner/repo")}
+		case strings.Contains(q, "is:open"):
+			open := repoPR(4, "other/tool")
+			open.PullRequest.MergedAt = nil
+			items = []github.Issue{open}
+		case strings.Contains(q, "is:unmerged"):
+			closed := repoPR(5, "owner/repo")
+			closed.PullRequest.MergedAt = nil
+			items = []github.Issue{closed}
+		}
+		json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: len(items), Items: items})
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithPRStates(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stats.Summary.TotalPRsMerged != 3 || stats.Summary.TotalPRsOpen != 1 || stats.Summary.TotalPRsClosedUnmerged != 1 {
+		t.Errorf("Summary = %+v", stats.Summary)
+	}
+	if rate := stats.Summary.AcceptanceRate; rate == nil || *rate != 0.75 {
+		t.Errorf("Summary.AcceptanceRate = %v, want 0.75", rate)
+	}
+
+	for _, contrib := range stats.Contributions {
+		switch contrib.Repo {
+		case "owner/repo":
+			if contrib.PRsClosedUnmerged != 1 || contrib.AcceptanceRate == nil || *contrib.AcceptanceRate != 0.75 {
+				t.Errorf("owner/repo = %+v", contrib)
+			}
+		case "other/tool":
+			// Only open PRs, nothing decided yet
+			if contrib.PRsOpen != 1 || contrib.AcceptanceRate != nil {
+				t.Errorf("other/tool = %+v", contrib)
+			}
+		default:
+			t.Errorf("unexpected contribution %s", contrib.Repo)
+		}
+	}
+}
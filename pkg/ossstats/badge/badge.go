@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"text/template"
 
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
@@ -13,6 +14,14 @@ import (
 
 var DefaultPRsLimit = 5
 
+const (
+	// compactPaddingX is the horizontal padding on each side of the compact badge text
+	compactPaddingX = 16
+
+	// avatarSpace is the width reserved next to repo names for owner avatars
+	avatarSpace = 40
+)
+
 // templateData holds the data passed to SVG templates
 type templateData struct {
 	Stats            *ossstats.Stats
@@ -22,14 +31,35 @@ type templateData struct {
 	TotalCommits     string
 	TotalLines       string
 	CompactText      string // For compact badge: "n projects | m PRs"
+	CompactWidth     int    // For compact badge: canvas width fitted to the text
 	TopContributions []contributionData
+	AcceptanceRate   string // For detailed badge: "75%", empty when hidden
+
+	// Localization
+	Labels    Labels
+	Direction string // "ltr" or "rtl", set on the root <svg>
+
+	// Avatars
+	ShowAvatars bool
+	AvatarSpace int // Horizontal space reserved for the avatar, 0 when hidden
+
+	// Accessibility, already XML-escaped
+	A11yID    string // Prefix of the <title> and <desc> ids
+	A11yTitle string // Content of the <title> element
+	A11yDesc  string // Content of the <desc> element
 }
 
 // contributionData holds formatted contribution data for templates
 type contributionData struct {
 	RepoName string
+	Owner    string
 	Stars    string
 	PRs      string
+
+	// Avatar, only used when BadgeOptions.ShowAvatars is set
+	AvatarURI   string // Image data URI, empty to fall back to initials
+	Initials    string
+	AvatarColor string
 }
 
 // RenderSVG generates an SVG badge from the given stats
@@ -45,26 +75,56 @@ func RenderSVG(stats *ossstats.Stats, opts BadgeOptions) (string, error) {
 	if opts.Limit == 0 {
 		opts.Limit = DefaultPRsLimit
 	}
+	if opts.Locale == "" {
+		opts.Locale = DefaultBadgeLocale
+	}
 
-	// Get theme colors
+	// Get theme colors and locale
 	colors := GetThemeColors(opts.Theme)
+	lf := localeFor(opts.Locale)
+
+	totalLines := stats.Summary.TotalAdditions + stats.Summary.TotalDeletions
 
 	// Prepare base template data
 	data := templateData{
 		Stats:         stats,
 		Colors:        colors,
-		TotalProjects: formatNumber(stats.Summary.TotalProjects),
-		TotalPRs:      formatNumber(stats.Summary.TotalPRsMerged),
-		TotalCommits:  formatNumber(stats.Summary.TotalCommits),
-		TotalLines:    formatNumber(stats.Summary.TotalAdditions + stats.Summary.TotalDeletions),
-		CompactText:   fmt.Sprintf("%s projects | %s PRs", formatNumber(stats.Summary.TotalProjects), formatNumber(stats.Summary.TotalPRsMerged)),
+		TotalProjects: lf.formatNumber(stats.Summary.TotalProjects),
+		TotalPRs:      lf.formatNumber(stats.Summary.TotalPRsMerged),
+		TotalCommits:  lf.formatTotal(stats.Summary.TotalCommits, stats.Summary.Accuracy.Commits),
+		TotalLines:    lf.formatTotal(&totalLines, stats.Summary.Accuracy.Lines),
+		CompactText: fmt.Sprintf("%s %s | %s %s",
+			lf.formatNumber(stats.Summary.TotalProjects), lf.labels.ProjectsUnit,
+			lf.formatNumber(stats.Summary.TotalPRsMerged), lf.labels.PRsUnit),
+		Labels:    lf.labels,
+		Direction: lf.direction(),
+	}
+
+	if opts.Style == StyleCompact {
+		data.CompactWidth = compactWidth(opts.Variant, data)
 	}
 
 	// Add top contributions for detailed view
+	topContributions := getTopContributions(stats, opts.SortBy, opts.Limit, lf)
 	if opts.Style == StyleDetailed {
-		data.TopContributions = getTopContributions(stats, opts.SortBy, opts.Limit)
+		data.TopContributions = topContributions
+		if opts.ShowAcceptanceRate && stats.Summary.AcceptanceRate != nil {
+			data.AcceptanceRate = lf.formatPercent(*stats.Summary.AcceptanceRate)
+		}
+		if opts.ShowAvatars {
+			data.ShowAvatars = true
+			data.AvatarSpace = avatarSpace
+			for i := range data.TopContributions {
+				data.TopContributions[i].AvatarURI = opts.Avatars[data.TopContributions[i].Owner]
+			}
+		}
 	}
 
+	// Every style carries the same accessible text summary
+	data.A11yID = accessibleID(opts.Style, opts.Variant, stats.Username)
+	data.A11yTitle = escapeXML(accessibleTitle(stats, lf))
+	data.A11yDesc = escapeXML(accessibleDescription(stats, topContributions, lf))
+
 	// Select template based on style
 	tmplStr, err := getTemplateStr(opts.Style, opts.Variant)
 	if err != nil {
@@ -78,6 +138,33 @@ func RenderSVG(stats *ossstats.Stats, opts BadgeOptions) (string, error) {
 		"mul": func(a, b int) int { return a * b },
 		"mod": func(a, b int) int { return a % b },
 		"div": func(a, b int) int { return a / b },
+		// flipX mirrors an x coordinate across a canvas of the given width
+		// for right-to-left locales
+		"flipX": func(width, x int) int {
+			if lf.rtl {
+				return width - x
+			}
+			return x
+		},
+		// flipRect mirrors the left edge of a box of width w
+		"flipRect": func(width, x, w int) int {
+			if lf.rtl {
+				return width - x - w
+			}
+			return x
+		},
+		// dirX negates a relative x offset for right-to-left locales
+		"dirX": func(x int) int {
+			if lf.rtl {
+				return -x
+			}
+			return x
+		},
+		"upper": strings.ToUpper,
+		// fitText truncates s with an ellipsis so it renders within maxWidth pixels
+		"fitText": func(s string, maxWidth, fontSize int, bold bool) string {
+			return fitText(s, float64(maxWidth), float64(fontSize), bold)
+		},
 	}).Parse(tmplStr)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
@@ -93,22 +180,72 @@ func RenderSVG(stats *ossstats.Stats, opts BadgeOptions) (string, error) {
 
 // formatNumber formats an integer with appropriate suffix (K, M)
 func formatNumber(n int) string {
-	if n >= 1_000_000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	return localeFor(LocaleEnglish).formatNumber(n)
+}
+
+// formatTotal formats a summary total by its accuracy: estimates are marked
+// with "~" and unknown values are shown as "–" instead of a made-up number
+func (lf localeFormat) formatTotal(n *int, accuracy string) string {
+	switch {
+	case n == nil || accuracy == ossstats.AccuracyUnknown:
+		return "–"
+	case accuracy == ossstats.AccuracyEstimated:
+		return "~" + lf.formatNumber(*n)
 	}
-	if n >= 1_000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1_000)
+	return lf.formatNumber(*n)
+}
+
+// commitCount returns the contribution's commits, 0 when unknown
+func commitCount(c ossstats.Contribution) int {
+	if c.Commits == nil {
+		return 0
+	}
+	return *c.Commits
+}
+
+// compactWidth returns the compact badge width needed to fit its text,
+// the way shields.io sizes its badges
+func compactWidth(variant BadgeVariant, data templateData) int {
+	if variant == VariantTextBased {
+		// 11px, with bold numbers
+		w := textWidth(data.Labels.OSS, 11, true) +
+			textWidth(" · ", 11, false) +
+			textWidth(data.TotalProjects, 11, true) +
+			textWidth(" "+data.Labels.ProjectsUnit+" · ", 11, false) +
+			textWidth(data.TotalPRs, 11, true) +
+			textWidth(" "+data.Labels.PRsMergedTitle, 11, false)
+		return roundUp(w) + 2*compactPaddingX
 	}
-	return fmt.Sprintf("%d", n)
+
+	// 12px bold
+	w := textWidth(data.Labels.OSS+" · "+data.CompactText, 12, true)
+	return roundUp(w) + 2*compactPaddingX
 }
 
-// formatStars formats a star count with appropriate suffix
-func formatStars(n int) string {
-	return formatNumber(n)
+// TopOwners returns the owners of the contributions shown on a detailed badge
+// with the given options, in display order. Use it to know which avatars to
+// fetch for BadgeOptions.Avatars.
+func TopOwners(stats *ossstats.Stats, opts BadgeOptions) []string {
+	if stats == nil {
+		return nil
+	}
+	if opts.SortBy == "" {
+		opts.SortBy = DefaultSortBy
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultPRsLimit
+	}
+
+	top := getTopContributions(stats, opts.SortBy, opts.Limit, localeFor(LocaleEnglish))
+	owners := make([]string, len(top))
+	for i, c := range top {
+		owners[i] = c.Owner
+	}
+	return owners
 }
 
 // getTopContributions returns the top N contributions sorted by the specified criteria
-func getTopContributions(stats *ossstats.Stats, sortBy SortBy, limit int) []contributionData {
+func getTopContributions(stats *ossstats.Stats, sortBy SortBy, limit int, lf localeFormat) []contributionData {
 	// Make a copy of contributions for sorting
 	contributions := make([]ossstats.Contribution, len(stats.Contributions))
 	copy(contributions, stats.Contributions)
@@ -119,7 +256,7 @@ func getTopContributions(stats *ossstats.Stats, sortBy SortBy, limit int) []cont
 		case SortByStars:
 			return contributions[i].Stars > contributions[j].Stars
 		case SortByCommits:
-			return contributions[i].Commits > contributions[j].Commits
+			return commitCount(contributions[i]) > commitCount(contributions[j])
 		case SortByPRs:
 			fallthrough
 		default:
@@ -136,9 +273,12 @@ func getTopContributions(stats *ossstats.Stats, sortBy SortBy, limit int) []cont
 	result := make([]contributionData, limit)
 	for i := 0; i < limit; i++ {
 		result[i] = contributionData{
-			RepoName: contributions[i].RepoName,
-			Stars:    formatStars(contributions[i].Stars),
-			PRs:      formatNumber(contributions[i].PRsMerged),
+			RepoName:    contributions[i].RepoName,
+			Owner:       contributions[i].Owner,
+			Initials:    ownerInitials(contributions[i].Owner),
+			AvatarColor: ownerColor(contributions[i].Owner),
+			Stars:       lf.formatNumber(contributions[i].Stars),
+			PRs:         lf.formatNumber(contributions[i].PRsMerged),
 		}
 	}
 
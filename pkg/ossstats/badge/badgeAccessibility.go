@@ -0,0 +1,67 @@
+package badge
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// accessibleID returns the prefix of the <title> and <desc> ids. Badges
+// inlined in one HTML page share its ids, so they differ per variant, style
+// and user. Characters not allowed in an id are replaced with "_".
+func accessibleID(style BadgeStyle, variant BadgeVariant, username string) string {
+	id := []byte("badge-" + string(variant) + "-" + string(style) + "-")
+	for _, c := range []byte(strings.ToLower(username)) {
+		if c == '-' || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			id = append(id, c)
+		} else {
+			id = append(id, '_')
+		}
+	}
+	return string(id)
+}
+
+// accessibleTitle returns the short text used for the SVG <title> element
+func accessibleTitle(stats *ossstats.Stats, lf localeFormat) string {
+	return fmt.Sprintf("@%s · %s", stats.Username, lf.labels.OpenSourceContributions)
+}
+
+// accessibleDescription returns a plain-text summary of the stats and top
+// repositories, used for the SVG <desc> element so screen readers have
+// something meaningful to announce
+func accessibleDescription(stats *ossstats.Stats, top []contributionData, lf localeFormat) string {
+	var sb strings.Builder
+
+	totalLines := stats.Summary.TotalAdditions + stats.Summary.TotalDeletions
+
+	fmt.Fprintf(&sb, "%s: %s, %s: %s, %s: %s, %s: %s.",
+		lf.labels.Projects, lf.formatNumber(stats.Summary.TotalProjects),
+		lf.labels.PRsMerged, lf.formatNumber(stats.Summary.TotalPRsMerged),
+		lf.labels.Commits, lf.formatTotal(stats.Summary.TotalCommits, stats.Summary.Accuracy.Commits),
+		lf.labels.LinesChanged, lf.formatTotal(&totalLines, stats.Summary.Accuracy.Lines),
+	)
+
+	if len(top) > 0 {
+		repos := make([]string, len(top))
+		for i, r := range top {
+			repos[i] = fmt.Sprintf("%s (%s %s, %s %s)", r.RepoName, r.Stars, lf.labels.Stars, r.PRs, lf.labels.PRsUnit)
+		}
+		fmt.Fprintf(&sb, " %s: %s.", lf.labels.TopRepositories, strings.Join(repos, ", "))
+	}
+
+	if !stats.GeneratedAt.IsZero() {
+		fmt.Fprintf(&sb, " %s: %s.", lf.labels.Updated, lf.formatDate(stats.GeneratedAt))
+	}
+
+	return sb.String()
+}
+
+// escapeXML escapes s so it can be embedded as SVG text content
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
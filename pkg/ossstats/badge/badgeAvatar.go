@@ -0,0 +1,268 @@
+package badge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"image"
+	_ "image/gif" // Register decoders for the formats GitHub serves avatars in
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultAvatarSize is the edge length in pixels of embedded avatars
+	DefaultAvatarSize = 64
+
+	// AvatarBaseURL serves GitHub avatars by login
+	AvatarBaseURL = "https://avatars.githubusercontent.com"
+
+	// DefaultAvatarTimeout bounds a single avatar download
+	DefaultAvatarTimeout = 10 * time.Second
+
+	// maxAvatarBytes caps the size of a downloaded avatar
+	maxAvatarBytes = 2 << 20
+
+	// maxAvatarEdge caps the width and height of a downloaded avatar. A small
+	// compressed file can decode into a huge image, so this is checked before
+	// decoding the pixels.
+	maxAvatarEdge = 2048
+)
+
+// defaultAvatarClient is used when AvatarFetcher.HTTPClient is nil
+var defaultAvatarClient = &http.Client{Timeout: DefaultAvatarTimeout}
+
+// avatarColors are background colors for the initials fallback. All of them
+// keep white initials above WCAG AA contrast.
+var avatarColors = []string{
+	"#1f6feb", "#8250df", "#bf3989", "#cf222e",
+	"#bc4c00", "#1a7f37", "#0e7490", "#57606a",
+}
+
+// AvatarFetcher downloads repository owner avatars and converts them into
+// base64 PNG data URIs that can be embedded in a badge. GitHub's camo proxy
+// blocks external images inside SVGs, so avatars must be inlined.
+//
+// Avatars are cached on disk so repeated builds don't hit the network and
+// stay reproducible. Owners whose avatar can't be loaded are left out of the
+// result, and the badge falls back to colored initials for them.
+type AvatarFetcher struct {
+	HTTPClient *http.Client // Default: a client with DefaultAvatarTimeout
+	BaseURL    string       // Default: AvatarBaseURL
+	CacheDir   string       // Empty disables the disk cache
+	Size       int          // Edge length in pixels (default: DefaultAvatarSize)
+	Offline    bool         // Only read from the cache, never download
+}
+
+// NewAvatarFetcher creates an AvatarFetcher with default settings that caches
+// avatars in cacheDir.
+func NewAvatarFetcher(cacheDir string) *AvatarFetcher {
+	return &AvatarFetcher{
+		HTTPClient: defaultAvatarClient,
+		BaseURL:    AvatarBaseURL,
+		CacheDir:   cacheDir,
+		Size:       DefaultAvatarSize,
+	}
+}
+
+// DefaultAvatarCacheDir returns the per-user cache directory for avatars,
+// e.g. ~/.cache/gh-oss-stats/avatars on Linux.
+func DefaultAvatarCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-oss-stats", "avatars")
+}
+
+// Fetch returns a map of owner login to PNG data URI for every owner whose
+// avatar could be loaded. Failures are not fatal: the returned errors describe
+// owners that will be rendered with the initials fallback.
+func (f *AvatarFetcher) Fetch(ctx context.Context, owners []string) (map[string]string, []error) {
+	avatars := make(map[string]string, len(owners))
+	var errs []error
+
+	for _, owner := range owners {
+		if owner == "" {
+			continue
+		}
+		if _, done := avatars[owner]; done {
+			continue
+		}
+
+		png, err := f.load(ctx, owner)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("avatar %s: %w", owner, err))
+			continue
+		}
+		avatars[owner] = "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+	}
+
+	return avatars, errs
+}
+
+// load returns the resized PNG for owner, from the cache if possible
+func (f *AvatarFetcher) load(ctx context.Context, owner string) ([]byte, error) {
+	size := f.Size
+	if size <= 0 {
+		size = DefaultAvatarSize
+	}
+
+	cachePath := ""
+	if f.CacheDir != "" {
+		cachePath = filepath.Join(f.CacheDir, fmt.Sprintf("%s-%d.png", url.PathEscape(owner), size))
+		if data, err := os.ReadFile(cachePath); err == nil {
+			return data, nil
+		}
+	}
+
+	if f.Offline {
+		return nil, fmt.Errorf("not cached and offline mode is enabled")
+	}
+
+	raw, err := f.download(ctx, owner, size)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+	if config.Width > maxAvatarEdge || config.Height > maxAvatarEdge {
+		return nil, fmt.Errorf("image too large: %dx%d (max %dx%d)",
+			config.Width, config.Height, maxAvatarEdge, maxAvatarEdge)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, resizeImage(img, size)); err != nil {
+		return nil, fmt.Errorf("encoding image: %w", err)
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(f.CacheDir, 0o755); err == nil {
+			_ = os.WriteFile(cachePath, buf.Bytes(), 0o644)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// download fetches the raw avatar image for owner
+func (f *AvatarFetcher) download(ctx context.Context, owner string, size int) ([]byte, error) {
+	baseURL := f.BaseURL
+	if baseURL == "" {
+		baseURL = AvatarBaseURL
+	}
+	httpClient := f.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultAvatarClient
+	}
+
+	// Ask for twice the size so downscaling keeps it sharp
+	avatarURL := fmt.Sprintf("%s/%s?s=%d", strings.TrimSuffix(baseURL, "/"), url.PathEscape(owner), size*2)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, avatarURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading: HTTP %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxAvatarBytes))
+}
+
+// resizeImage scales img to size x size using box filtering. Non-square
+// images are center-cropped first.
+func resizeImage(img image.Image, size int) image.Image {
+	b := img.Bounds()
+
+	// Center crop to a square
+	edge := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-edge)/2
+	y0 := b.Min.Y + (b.Dy()-edge)/2
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		sy0 := y0 + y*edge/size
+		sy1 := max(y0+(y+1)*edge/size, sy0+1)
+		for x := 0; x < size; x++ {
+			sx0 := x0 + x*edge/size
+			sx1 := max(x0+(x+1)*edge/size, sx0+1)
+
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			if a == 0 {
+				continue
+			}
+			// Un-premultiply the averaged color
+			dst.Pix[i+0] = uint8(r * 0xff / a)
+			dst.Pix[i+1] = uint8(g * 0xff / a)
+			dst.Pix[i+2] = uint8(bl * 0xff / a)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+
+	return dst
+}
+
+// ownerInitials returns up to two uppercase initials for an owner login,
+// e.g. "kubernetes" -> "K", "ibad-al-rahman" -> "IA"
+func ownerInitials(owner string) string {
+	words := strings.FieldsFunc(owner, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+
+	var initials []rune
+	for _, w := range words {
+		r, _ := utf8.DecodeRuneInString(w)
+		initials = append(initials, unicode.ToUpper(r))
+		if len(initials) == 2 {
+			break
+		}
+	}
+
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// ownerColor returns a stable background color for an owner's initials
+func ownerColor(owner string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(owner)))
+	return avatarColors[h.Sum32()%uint32(len(avatarColors))]
+}
@@ -0,0 +1,228 @@
+package badge
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func newAvatarServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+
+	// 100x80 red image, to exercise cropping and resizing
+	img := image.NewRGBA(image.Rect(0, 0, 100, 80))
+	for y := 0; y < 80; y++ {
+		for x := 0; x < 100; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encoding test image: %v", err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	}))
+}
+
+func TestAvatarFetcherFetch(t *testing.T) {
+	var hits int32
+	server := newAvatarServer(t, &hits)
+	defer server.Close()
+
+	fetcher := NewAvatarFetcher(t.TempDir())
+	fetcher.BaseURL = server.URL
+	fetcher.Size = 16
+
+	avatars, errs := fetcher.Fetch(context.Background(), []string{"kubernetes", "missing", "kubernetes"})
+
+	if len(errs) != 1 {
+		t.Fatalf("Fetch() errors = %v, want 1 error for missing owner", errs)
+	}
+	if _, ok := avatars["missing"]; ok {
+		t.Error("Fetch() should not return an avatar for missing owner")
+	}
+
+	uri, ok := avatars["kubernetes"]
+	if !ok {
+		t.Fatal("Fetch() missing avatar for kubernetes")
+	}
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Errorf("avatar URI = %.40s..., want PNG data URI", uri)
+	}
+
+	// Decoded avatar is resized to a square
+	cached, err := fetcher.load(context.Background(), "kubernetes")
+	if err != nil {
+		t.Fatalf("load() unexpected error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(cached))
+	if err != nil {
+		t.Fatalf("decoding cached avatar: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 16 || b.Dy() != 16 {
+		t.Errorf("avatar size = %dx%d, want 16x16", b.Dx(), b.Dy())
+	}
+	if r, _, _, _ := img.At(8, 8).RGBA(); r>>8 != 255 {
+		t.Errorf("avatar pixel red = %d, want 255", r>>8)
+	}
+
+	// Second fetch is served from the cache
+	before := atomic.LoadInt32(&hits)
+	if _, errs := fetcher.Fetch(context.Background(), []string{"kubernetes"}); len(errs) != 0 {
+		t.Errorf("Fetch() from cache errors = %v", errs)
+	}
+	if atomic.LoadInt32(&hits) != before {
+		t.Error("Fetch() should use the disk cache instead of downloading again")
+	}
+}
+
+func TestAvatarFetcherOffline(t *testing.T) {
+	var hits int32
+	server := newAvatarServer(t, &hits)
+	defer server.Close()
+
+	fetcher := NewAvatarFetcher(t.TempDir())
+	fetcher.BaseURL = server.URL
+	fetcher.Offline = true
+
+	avatars, errs := fetcher.Fetch(context.Background(), []string{"kubernetes"})
+	if len(avatars) != 0 || len(errs) != 1 {
+		t.Errorf("Fetch() offline = %d avatars, %d errors, want 0 and 1", len(avatars), len(errs))
+	}
+	if hits != 0 {
+		t.Errorf("offline fetch made %d requests, want 0", hits)
+	}
+}
+
+func TestAvatarFetcherTooLarge(t *testing.T) {
+	// Compresses to a few bytes but decodes to more than maxAvatarEdge pixels wide
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, maxAvatarEdge+1, 1))); err != nil {
+		t.Fatalf("encoding test image: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	fetcher := NewAvatarFetcher("")
+	fetcher.BaseURL = server.URL
+
+	avatars, errs := fetcher.Fetch(context.Background(), []string{"kubernetes"})
+	if len(avatars) != 0 || len(errs) != 1 {
+		t.Fatalf("Fetch() = %d avatars, %v, want 0 avatars and 1 error", len(avatars), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "too large") {
+		t.Errorf("Fetch() error = %v, want image too large", errs[0])
+	}
+}
+
+func TestOwnerInitials(t *testing.T) {
+	tests := map[string]string{
+		"kubernetes":     "K",
+		"ibad-al-rahman": "IA",
+		"foo_bar.baz":    "FB",
+		"":               "?",
+		"---":            "?",
+	}
+
+	for owner, want := range tests {
+		if got := ownerInitials(owner); got != want {
+			t.Errorf("ownerInitials(%q) = %q, want %q", owner, got, want)
+		}
+	}
+}
+
+func TestOwnerColorContrast(t *testing.T) {
+	for _, c := range avatarColors {
+		ratio, err := ContrastRatio("#ffffff", c)
+		if err != nil {
+			t.Fatalf("ContrastRatio() unexpected error: %v", err)
+		}
+		if ratio < MinContrastAA {
+			t.Errorf("white on %s = %.2f, want >= %.1f", c, ratio, MinContrastAA)
+		}
+	}
+
+	if ownerColor("Kubernetes") != ownerColor("kubernetes") {
+		t.Error("ownerColor() should be case insensitive")
+	}
+}
+
+func TestRenderSVG_Avatars(t *testing.T) {
+	stats := &ossstats.Stats{
+		Username: "testuser",
+		Contributions: []ossstats.Contribution{
+			{Owner: "kubernetes", RepoName: "kubernetes", PRsMerged: 5},
+			{Owner: "facebook", RepoName: "react", PRsMerged: 3},
+		},
+	}
+
+	for _, variant := range []BadgeVariant{VariantDefault, VariantTextBased} {
+		t.Run(string(variant), func(t *testing.T) {
+			opts := BadgeOptions{
+				Style:       StyleDetailed,
+				Variant:     variant,
+				ShowAvatars: true,
+				Avatars:     map[string]string{"kubernetes": "data:image/png;base64,AAAA"},
+			}
+
+			svg, err := RenderSVG(stats, opts)
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+
+			if !strings.Contains(svg, `href="data:image/png;base64,AAAA"`) {
+				t.Error("detailed badge missing embedded avatar")
+			}
+			if !strings.Contains(svg, `class="avatar-initials">F</text>`) {
+				t.Error("detailed badge missing initials fallback for facebook")
+			}
+
+			opts.ShowAvatars = false
+			svg, err = RenderSVG(stats, opts)
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+			if strings.Contains(svg, "<image") || strings.Contains(svg, `class="avatar-initials">`) {
+				t.Error("detailed badge should not render avatars when ShowAvatars is false")
+			}
+		})
+	}
+}
+
+func TestTopOwners(t *testing.T) {
+	stats := &ossstats.Stats{
+		Contributions: []ossstats.Contribution{
+			{Owner: "a", PRsMerged: 1},
+			{Owner: "b", PRsMerged: 3},
+			{Owner: "c", PRsMerged: 2},
+		},
+	}
+
+	got := TopOwners(stats, BadgeOptions{Limit: 2})
+	if strings.Join(got, ",") != "b,c" {
+		t.Errorf("TopOwners() = %v, want [b c]", got)
+	}
+	if TopOwners(nil, BadgeOptions{}) != nil {
+		t.Error("TopOwners(nil) should return nil")
+	}
+}
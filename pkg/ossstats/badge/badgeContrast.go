@@ -0,0 +1,114 @@
+package badge
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MinContrastAA is the minimum WCAG 2.x contrast ratio for normal-sized text
+// to meet level AA. Badge labels are 11-13px, so the large-text ratio does
+// not apply.
+const MinContrastAA = 4.5
+
+// ContrastCheck is the result of comparing one text color against one
+// background color of a theme
+type ContrastCheck struct {
+	Foreground string  // Name of the text color field, e.g. "Text"
+	Background string  // Name of the background color field, e.g. "BackgroundAlt"
+	Ratio      float64 // WCAG contrast ratio, between 1 and 21
+	PassesAA   bool
+}
+
+func (c ContrastCheck) String() string {
+	status := "ok"
+	if !c.PassesAA {
+		status = "FAIL"
+	}
+	return fmt.Sprintf("%-13s on %-13s %5.2f:1  %s", c.Foreground, c.Background, c.Ratio, status)
+}
+
+// CheckThemeContrast computes WCAG contrast ratios between Text/TextSecondary
+// and Background/BackgroundAlt for the given colors.
+// Returns an error if any of the colors cannot be parsed.
+func CheckThemeContrast(colors ThemeColors) ([]ContrastCheck, error) {
+	foregrounds := []struct{ name, color string }{
+		{"Text", colors.Text},
+		{"TextSecondary", colors.TextSecondary},
+	}
+	backgrounds := []struct{ name, color string }{
+		{"Background", colors.Background},
+		{"BackgroundAlt", colors.BackgroundAlt},
+	}
+
+	checks := make([]ContrastCheck, 0, len(foregrounds)*len(backgrounds))
+	for _, fg := range foregrounds {
+		for _, bg := range backgrounds {
+			ratio, err := ContrastRatio(fg.color, bg.color)
+			if err != nil {
+				return nil, fmt.Errorf("%s on %s: %w", fg.name, bg.name, err)
+			}
+			checks = append(checks, ContrastCheck{
+				Foreground: fg.name,
+				Background: bg.name,
+				Ratio:      ratio,
+				PassesAA:   ratio >= MinContrastAA,
+			})
+		}
+	}
+
+	return checks, nil
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio between two hex colors
+// ("#rgb" or "#rrggbb")
+func ContrastRatio(a, b string) (float64, error) {
+	la, err := relativeLuminance(a)
+	if err != nil {
+		return 0, err
+	}
+	lb, err := relativeLuminance(b)
+	if err != nil {
+		return 0, err
+	}
+
+	lighter, darker := math.Max(la, lb), math.Min(la, lb)
+	return (lighter + 0.05) / (darker + 0.05), nil
+}
+
+// relativeLuminance computes the WCAG relative luminance of a hex color
+func relativeLuminance(hex string) (float64, error) {
+	r, g, b, err := parseHexColor(hex)
+	if err != nil {
+		return 0, err
+	}
+
+	linear := func(c uint8) float64 {
+		v := float64(c) / 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b), nil
+}
+
+// parseHexColor parses "#rgb" or "#rrggbb" into its components
+func parseHexColor(hex string) (r, g, b uint8, err error) {
+	s := strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return 0, 0, 0, fmt.Errorf("invalid hex color: %q", hex)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hex color: %q", hex)
+	}
+
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), nil
+}
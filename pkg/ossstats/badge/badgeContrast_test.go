@@ -0,0 +1,83 @@
+package badge
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "black_on_white", a: "#000000", b: "#ffffff", want: 21},
+		{name: "white_on_black", a: "#fff", b: "#000", want: 21},
+		{name: "same_color", a: "#777777", b: "#777777", want: 1},
+		{name: "github_light_secondary", a: "#656d76", b: "#ffffff", want: 5.25},
+		{name: "invalid_length", a: "#12345", b: "#ffffff", wantErr: true},
+		{name: "invalid_hex", a: "#zzzzzz", b: "#ffffff", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContrastRatio(tt.a, tt.b)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ContrastRatio(%q, %q) expected error, got nil", tt.a, tt.b)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContrastRatio(%q, %q) unexpected error: %v", tt.a, tt.b, err)
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio(%q, %q) = %.2f, want %.2f", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckThemeContrast(t *testing.T) {
+	// Every built-in theme passes, so validate-theme can gate CI
+	for _, theme := range BadgeThemes {
+		checks, err := CheckThemeContrast(GetThemeColors(theme))
+		if err != nil {
+			t.Fatalf("CheckThemeContrast(%s) unexpected error: %v", theme, err)
+		}
+		if len(checks) != 4 {
+			t.Fatalf("CheckThemeContrast(%s) returned %d checks, want 4", theme, len(checks))
+		}
+		for _, c := range checks {
+			if !c.PassesAA {
+				t.Errorf("%s theme %s on %s = %.2f, want >= %.1f", theme, c.Foreground, c.Background, c.Ratio, MinContrastAA)
+			}
+		}
+	}
+
+	// Low contrast secondary text must be reported
+	colors := GetThemeColors(ThemeGithubDark)
+	colors.TextSecondary = "#30363d"
+	checks, err := CheckThemeContrast(colors)
+	if err != nil {
+		t.Fatalf("CheckThemeContrast() unexpected error: %v", err)
+	}
+	failed := 0
+	for _, c := range checks {
+		if !c.PassesAA {
+			failed++
+			if c.Foreground != "TextSecondary" {
+				t.Errorf("unexpected failing check: %s", c)
+			}
+		}
+	}
+	if failed != 2 {
+		t.Errorf("failed checks = %d, want 2", failed)
+	}
+
+	colors.Background = "not-a-color"
+	if _, err := CheckThemeContrast(colors); err == nil {
+		t.Error("CheckThemeContrast() expected error for invalid color, got nil")
+	}
+}
@@ -0,0 +1,322 @@
+package badge
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var DefaultBadgeLocale = LocaleEnglish
+
+// BadgeLocale represents the language used for badge labels and number formatting
+type BadgeLocale string
+
+const (
+	LocaleEnglish BadgeLocale = "en"
+	LocaleGerman  BadgeLocale = "de"
+	LocaleFrench  BadgeLocale = "fr"
+	LocaleSpanish BadgeLocale = "es"
+	LocaleArabic  BadgeLocale = "ar"
+	LocaleHebrew  BadgeLocale = "he"
+)
+
+func BadgeLocaleFromName(name string) (BadgeLocale, error) {
+	switch strings.ToLower(name) {
+	case "en":
+		return LocaleEnglish, nil
+	case "de":
+		return LocaleGerman, nil
+	case "fr":
+		return LocaleFrench, nil
+	case "es":
+		return LocaleSpanish, nil
+	case "ar":
+		return LocaleArabic, nil
+	case "he":
+		return LocaleHebrew, nil
+	}
+	err := fmt.Errorf("invalid badge locale: %s (must be: en, de, fr, es, ar, he)", name)
+	return DefaultBadgeLocale, err
+}
+
+// Labels holds the translated strings shown on badges
+type Labels struct {
+	OSS                     string // Short prefix on compact badges
+	OpenSourceContributions string // Subtitle under the username
+	Projects                string // Stat label
+	PRsMerged               string // Stat label
+	LinesChanged            string // Stat label
+	Commits                 string // Stat label
+	Stars                   string // Star count label
+	StarsReached            string // Combined stars of contributed repos
+	TopRepositories         string // Section title in detailed view
+	ProjectsUnit            string // Lowercase unit after a count: "42 projects"
+	PRsUnit                 string // Unit after a count: "156 PRs"
+	Updated                 string // Prefix for the generation date
+	Accepted                string // Suffix after the PR acceptance rate: "75% accepted"
+
+	// Other casings and wordings some badge designs use. Locales may leave
+	// them empty to reuse the labels above (see withDefaults).
+	OpenSource        string // Title suffix in the default detailed view: "octocat · Open Source"
+	OpenSourceTitle   string // Title-case subtitle: "Open Source Contributions"
+	OpenSourceLower   string // Lowercase subtitle: "open source contributions"
+	PRsMergedTitle    string // Title-case stat label: "PRs Merged"
+	PRsMergedUpper    string // Uppercase stat label keeping the abbreviation: "PRs MERGED"
+	LinesChangedTitle string // Title-case stat label: "Lines Changed"
+	TopContributions  string // Section title in the default detailed view
+}
+
+// withDefaults fills the optional labels from the ones they vary
+func (l Labels) withDefaults() Labels {
+	fill := func(label *string, fallback string) {
+		if *label == "" {
+			*label = fallback
+		}
+	}
+	fill(&l.OpenSource, l.OpenSourceContributions)
+	fill(&l.OpenSourceTitle, l.OpenSourceContributions)
+	fill(&l.OpenSourceLower, l.OpenSourceContributions)
+	fill(&l.PRsMergedTitle, l.PRsMerged)
+	fill(&l.PRsMergedUpper, strings.ToUpper(l.PRsMerged))
+	fill(&l.LinesChangedTitle, l.LinesChanged)
+	fill(&l.TopContributions, l.TopRepositories)
+	return l
+}
+
+// localeFormat holds everything needed to render a badge in one locale
+type localeFormat struct {
+	labels   Labels
+	decimal  string     // Decimal separator
+	percent  string     // Format for a percentage, e.g. "%s%%"
+	thousand string     // Suffix for thousands, including any leading space
+	million  string     // Suffix for millions, including any leading space
+	digits   *[10]rune  // Native digits, nil for ASCII digits
+	months   [12]string // Abbreviated month names
+	date     func(day int, month string, year int) string
+	rtl      bool
+}
+
+var arabicIndicDigits = [10]rune{'٠', '١', '٢', '٣', '٤', '٥', '٦', '٧', '٨', '٩'}
+
+var localeFormats = map[BadgeLocale]localeFormat{
+	LocaleEnglish: {
+		labels: Labels{
+			OSS:                     "OSS",
+			OpenSourceContributions: "Open source contributions",
+			Projects:                "Projects",
+			PRsMerged:               "PRs merged",
+			LinesChanged:            "Lines changed",
+			Commits:                 "Commits",
+			Stars:                   "stars",
+			StarsReached:            "Stars reached",
+			TopRepositories:         "Top repositories",
+			ProjectsUnit:            "projects",
+			PRsUnit:                 "PRs",
+			Updated:                 "Updated",
+			Accepted:                "accepted",
+			OpenSource:              "Open Source",
+			OpenSourceTitle:         "Open Source Contributions",
+			OpenSourceLower:         "open source contributions",
+			PRsMergedTitle:          "PRs Merged",
+			PRsMergedUpper:          "PRs MERGED",
+			LinesChangedTitle:       "Lines Changed",
+			TopContributions:        "Top Contributions",
+		},
+		decimal:  ".",
+		percent:  "%s%%",
+		thousand: "K",
+		million:  "M",
+		months:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		date: func(day int, month string, year int) string {
+			return fmt.Sprintf("%s %d, %d", month, day, year)
+		},
+	},
+	LocaleGerman: {
+		labels: Labels{
+			OSS:                     "OSS",
+			OpenSourceContributions: "Open-Source-Beiträge",
+			Projects:                "Projekte",
+			PRsMerged:               "PRs gemergt",
+			LinesChanged:            "Geänderte Zeilen",
+			Commits:                 "Commits",
+			Stars:                   "Sterne",
+			StarsReached:            "Erreichte Sterne",
+			TopRepositories:         "Top-Repositories",
+			ProjectsUnit:            "Projekte",
+			PRsUnit:                 "PRs",
+			Updated:                 "Aktualisiert",
+			Accepted:                "angenommen",
+		},
+		decimal:  ",",
+		percent:  "%s %%",
+		thousand: " Tsd.",
+		million:  " Mio.",
+		months:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		date: func(day int, month string, year int) string {
+			return fmt.Sprintf("%d. %s %d", day, month, year)
+		},
+	},
+	LocaleFrench: {
+		labels: Labels{
+			OSS:                     "OSS",
+			OpenSourceContributions: "Contributions open source",
+			Projects:                "Projets",
+			PRsMerged:               "PR fusionnées",
+			LinesChanged:            "Lignes modifiées",
+			Commits:                 "Commits",
+			Stars:                   "étoiles",
+			StarsReached:            "Étoiles cumulées",
+			TopRepositories:         "Dépôts principaux",
+			ProjectsUnit:            "projets",
+			PRsUnit:                 "PR",
+			Updated:                 "Mis à jour",
+			Accepted:                "acceptées",
+		},
+		decimal:  ",",
+		percent:  "%s %%",
+		thousand: " k",
+		million:  " M",
+		months:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		date: func(day int, month string, year int) string {
+			return fmt.Sprintf("%d %s %d", day, month, year)
+		},
+	},
+	LocaleSpanish: {
+		labels: Labels{
+			OSS:                     "OSS",
+			OpenSourceContributions: "Contribuciones de código abierto",
+			Projects:                "Proyectos",
+			PRsMerged:               "PRs fusionados",
+			LinesChanged:            "Líneas cambiadas",
+			Commits:                 "Commits",
+			Stars:                   "estrellas",
+			StarsReached:            "Estrellas alcanzadas",
+			TopRepositories:         "Repositorios destacados",
+			ProjectsUnit:            "proyectos",
+			PRsUnit:                 "PRs",
+			Updated:                 "Actualizado",
+			Accepted:                "aceptados",
+		},
+		decimal:  ",",
+		percent:  "%s %%",
+		thousand: " mil",
+		million:  " M",
+		months:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		date: func(day int, month string, year int) string {
+			return fmt.Sprintf("%d %s %d", day, month, year)
+		},
+	},
+	LocaleArabic: {
+		labels: Labels{
+			OSS:                     "مفتوح المصدر",
+			OpenSourceContributions: "مساهمات مفتوحة المصدر",
+			Projects:                "المشاريع",
+			PRsMerged:               "طلبات الدمج المقبولة",
+			LinesChanged:            "الأسطر المعدلة",
+			Commits:                 "الإيداعات",
+			Stars:                   "نجوم",
+			StarsReached:            "إجمالي النجوم",
+			TopRepositories:         "أبرز المستودعات",
+			ProjectsUnit:            "مشروع",
+			PRsUnit:                 "طلب دمج",
+			Updated:                 "آخر تحديث",
+			Accepted:                "مقبولة",
+		},
+		decimal:  "٫",
+		percent:  "%s٪",
+		thousand: " ألف",
+		million:  " مليون",
+		digits:   &arabicIndicDigits,
+		months:   [12]string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+		date: func(day int, month string, year int) string {
+			return fmt.Sprintf("%d %s %d", day, month, year)
+		},
+		rtl: true,
+	},
+	LocaleHebrew: {
+		labels: Labels{
+			OSS:                     "קוד פתוח",
+			OpenSourceContributions: "תרומות לקוד פתוח",
+			Projects:                "פרויקטים",
+			PRsMerged:               "PRs שמוזגו",
+			LinesChanged:            "שורות ששונו",
+			Commits:                 "קומיטים",
+			Stars:                   "כוכבים",
+			StarsReached:            "סך הכוכבים",
+			TopRepositories:         "מאגרים מובילים",
+			ProjectsUnit:            "פרויקטים",
+			PRsUnit:                 "PRs",
+			Updated:                 "עודכן",
+			Accepted:                "התקבלו",
+		},
+		decimal:  ".",
+		percent:  "%s%%",
+		thousand: "K",
+		million:  "M",
+		months:   [12]string{"ינו׳", "פבר׳", "מרץ", "אפר׳", "מאי", "יוני", "יולי", "אוג׳", "ספט׳", "אוק׳", "נוב׳", "דצמ׳"},
+		date: func(day int, month string, year int) string {
+			return fmt.Sprintf("%d ב%s %d", day, month, year)
+		},
+		rtl: true,
+	},
+}
+
+// localeFor returns the format for the given locale, falling back to English
+func localeFor(locale BadgeLocale) localeFormat {
+	lf, ok := localeFormats[locale]
+	if !ok {
+		lf = localeFormats[LocaleEnglish]
+	}
+	lf.labels = lf.labels.withDefaults()
+	return lf
+}
+
+// formatNumber formats an integer with the locale's decimal separator and
+// thousand/million suffixes
+func (lf localeFormat) formatNumber(n int) string {
+	var s string
+	switch {
+	case n >= 1_000_000:
+		s = fmt.Sprintf("%.1f", float64(n)/1_000_000)
+		s = strings.Replace(s, ".", lf.decimal, 1) + lf.million
+	case n >= 1_000:
+		s = fmt.Sprintf("%.1f", float64(n)/1_000)
+		s = strings.Replace(s, ".", lf.decimal, 1) + lf.thousand
+	default:
+		s = fmt.Sprintf("%d", n)
+	}
+	return lf.localizeDigits(s)
+}
+
+// formatPercent formats a 0-1 ratio as a whole percentage, e.g. "75%"
+func (lf localeFormat) formatPercent(ratio float64) string {
+	s := fmt.Sprintf(lf.percent, fmt.Sprintf("%.0f", ratio*100))
+	return lf.localizeDigits(s)
+}
+
+// formatDate formats t as a medium-length date, e.g. "Dec 31, 2025"
+func (lf localeFormat) formatDate(t time.Time) string {
+	s := lf.date(t.Day(), lf.months[t.Month()-1], t.Year())
+	return lf.localizeDigits(s)
+}
+
+// direction returns the SVG text direction for the locale
+func (lf localeFormat) direction() string {
+	if lf.rtl {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// localizeDigits replaces ASCII digits with the locale's native digits
+func (lf localeFormat) localizeDigits(s string) string {
+	if lf.digits == nil {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return lf.digits[r-'0']
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,94 @@
+package badge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBadgeLocaleFromName(t *testing.T) {
+	for _, name := range []string{"en", "DE", "fr", "es", "ar", "he"} {
+		if _, err := BadgeLocaleFromName(name); err != nil {
+			t.Errorf("BadgeLocaleFromName(%q) unexpected error: %v", name, err)
+		}
+	}
+
+	got, err := BadgeLocaleFromName("xx")
+	if err == nil {
+		t.Error("BadgeLocaleFromName(\"xx\") expected error, got nil")
+	}
+	if got != DefaultBadgeLocale {
+		t.Errorf("BadgeLocaleFromName(\"xx\") = %s, want %s", got, DefaultBadgeLocale)
+	}
+}
+
+func TestLocaleFormatNumber(t *testing.T) {
+	tests := []struct {
+		locale BadgeLocale
+		input  int
+		want   string
+	}{
+		{LocaleEnglish, 1567, "1.6K"},
+		{LocaleEnglish, 2500000, "2.5M"},
+		{LocaleGerman, 1567, "1,6 Tsd."},
+		{LocaleGerman, 2500000, "2,5 Mio."},
+		{LocaleFrench, 42, "42"},
+		{LocaleSpanish, 125450, "125,5 mil"},
+		{LocaleArabic, 1567, "١٫٦ ألف"},
+		{LocaleHebrew, 999, "999"},
+		{BadgeLocale("unknown"), 1000, "1.0K"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			got := localeFor(tt.locale).formatNumber(tt.input)
+			if got != tt.want {
+				t.Errorf("formatNumber(%d) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFormatDate(t *testing.T) {
+	date := time.Date(2025, 12, 31, 5, 33, 15, 0, time.UTC)
+
+	tests := []struct {
+		locale BadgeLocale
+		want   string
+	}{
+		{LocaleEnglish, "Dec 31, 2025"},
+		{LocaleGerman, "31. Dez. 2025"},
+		{LocaleFrench, "31 déc. 2025"},
+		{LocaleArabic, "٣١ ديسمبر ٢٠٢٥"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			got := localeFor(tt.locale).formatDate(date)
+			if got != tt.want {
+				t.Errorf("formatDate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFormatPercent(t *testing.T) {
+	tests := []struct {
+		locale BadgeLocale
+		ratio  float64
+		want   string
+	}{
+		{LocaleEnglish, 0.75, "75%"},
+		{LocaleEnglish, 1, "100%"},
+		{LocaleGerman, 0.666, "67 %"},
+		{LocaleArabic, 0.5, "٥٠٪"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			got := localeFor(tt.locale).formatPercent(tt.ratio)
+			if got != tt.want {
+				t.Errorf("formatPercent(%v) = %s, want %s", tt.ratio, got, tt.want)
+			}
+		})
+	}
+}
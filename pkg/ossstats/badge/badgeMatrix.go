@@ -0,0 +1,222 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// Matrix selects the badge combinations to render in one run. Empty fields
+// select every value, so the zero Matrix renders all combinations.
+type Matrix struct {
+	Variants []BadgeVariant
+	Styles   []BadgeStyle
+	Themes   []BadgeTheme
+}
+
+// MatrixBadge is one rendered combination of a Matrix
+type MatrixBadge struct {
+	Variant  BadgeVariant
+	Style    BadgeStyle
+	Theme    BadgeTheme
+	FileName string // See MatrixFileName
+	SVG      string
+}
+
+// MatrixFileName returns the file name of a combination:
+// <variant>-<style>-<theme>.svg, e.g. "default-summary-dark.svg"
+func MatrixFileName(variant BadgeVariant, style BadgeStyle, theme BadgeTheme) string {
+	return fmt.Sprintf("%s-%s-%s.svg", variant, style, theme)
+}
+
+// RenderMatrix renders every variant × style × theme combination of m.
+// Everything else (sorting, limit, locale, avatars) is taken from opts.
+// Badges are ordered by variant, then style, then theme.
+func RenderMatrix(stats *ossstats.Stats, m Matrix, opts BadgeOptions) ([]MatrixBadge, error) {
+	variants := m.Variants
+	if len(variants) == 0 {
+		variants = BadgeVariants
+	}
+	styles := m.Styles
+	if len(styles) == 0 {
+		styles = BadgeStyles
+	}
+	themes := m.Themes
+	if len(themes) == 0 {
+		themes = BadgeThemes
+	}
+
+	badges := make([]MatrixBadge, 0, len(variants)*len(styles)*len(themes))
+	for _, variant := range variants {
+		for _, style := range styles {
+			for _, theme := range themes {
+				opts.Variant, opts.Style, opts.Theme = variant, style, theme
+
+				svg, err := RenderSVG(stats, opts)
+				if err != nil {
+					return nil, fmt.Errorf("rendering %s/%s/%s: %w", variant, style, theme, err)
+				}
+
+				badges = append(badges, MatrixBadge{
+					Variant:  variant,
+					Style:    style,
+					Theme:    theme,
+					FileName: MatrixFileName(variant, style, theme),
+					SVG:      svg,
+				})
+			}
+		}
+	}
+
+	return badges, nil
+}
+
+// gallery is the grid shown in a gallery index: one table per variant, a row
+// per theme and a column per style
+type gallery struct {
+	Username string
+	Count    int
+	Styles   []BadgeStyle
+	Tables   []galleryTable
+}
+
+type galleryTable struct {
+	Variant BadgeVariant
+	Rows    []galleryRow
+}
+
+type galleryRow struct {
+	Theme BadgeTheme
+	Cells []galleryCell // One per style; FileName is empty for missing combinations
+}
+
+type galleryCell struct {
+	Style    BadgeStyle
+	FileName string
+}
+
+// newGallery arranges badges into a grid, keeping their order of appearance
+func newGallery(username string, badges []MatrixBadge) gallery {
+	var variants []BadgeVariant
+	var styles []BadgeStyle
+	var themes []BadgeTheme
+	files := make(map[[3]string]string, len(badges))
+
+	for _, b := range badges {
+		if !slices.Contains(variants, b.Variant) {
+			variants = append(variants, b.Variant)
+		}
+		if !slices.Contains(styles, b.Style) {
+			styles = append(styles, b.Style)
+		}
+		if !slices.Contains(themes, b.Theme) {
+			themes = append(themes, b.Theme)
+		}
+		files[[3]string{string(b.Variant), string(b.Style), string(b.Theme)}] = b.FileName
+	}
+
+	g := gallery{Username: username, Count: len(badges), Styles: styles}
+	for _, variant := range variants {
+		table := galleryTable{Variant: variant}
+		for _, theme := range themes {
+			row := galleryRow{Theme: theme}
+			for _, style := range styles {
+				row.Cells = append(row.Cells, galleryCell{
+					Style:    style,
+					FileName: files[[3]string{string(variant), string(style), string(theme)}],
+				})
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		g.Tables = append(g.Tables, table)
+	}
+
+	return g
+}
+
+// RenderGalleryMarkdown returns a Markdown page previewing badges, meant to
+// be saved as README.md next to the SVG files
+func RenderGalleryMarkdown(username string, badges []MatrixBadge) string {
+	g := newGallery(username, badges)
+
+	var sb strings.Builder
+	sb.WriteString("# Badge Gallery\n\n")
+	fmt.Fprintf(&sb, "All %d badge combinations for @%s, generated by gh-oss-stats.\n", g.Count, g.Username)
+
+	for _, table := range g.Tables {
+		fmt.Fprintf(&sb, "\n## %s\n\n", table.Variant)
+
+		sb.WriteString("| Theme |")
+		for _, style := range g.Styles {
+			fmt.Fprintf(&sb, " %s |", style)
+		}
+		sb.WriteString("\n|-------|")
+		for range g.Styles {
+			sb.WriteString("-------|")
+		}
+		sb.WriteString("\n")
+
+		for _, row := range table.Rows {
+			fmt.Fprintf(&sb, "| %s |", row.Theme)
+			for _, cell := range row.Cells {
+				if cell.FileName == "" {
+					sb.WriteString(" — |")
+					continue
+				}
+				fmt.Fprintf(&sb, " ![%s %s %s](%s) |", table.Variant, cell.Style, row.Theme, cell.FileName)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+var galleryHTMLTemplate = template.Must(template.New("gallery").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Badge Gallery – {{.Username}}</title>
+<style>
+body { font-family: system-ui, -apple-system, sans-serif; margin: 2rem; background: #f6f8fa; color: #1f2328; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { padding: 0.75rem; border: 1px solid #d0d7de; vertical-align: top; text-align: left; }
+td img { display: block; max-width: 100%; }
+figcaption { font-size: 0.75rem; color: #59636e; margin-top: 0.25rem; }
+</style>
+</head>
+<body>
+<h1>Badge Gallery</h1>
+<p>All {{.Count}} badge combinations for @{{.Username}}, generated by gh-oss-stats.</p>
+{{- range .Tables}}
+{{- $variant := .Variant}}
+<h2>{{.Variant}}</h2>
+<table>
+<tr><th>Theme</th>{{range $.Styles}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+{{- $theme := .Theme}}
+<tr><th>{{.Theme}}</th>
+{{- range .Cells}}
+{{- if .FileName}}<td><figure><img src="{{.FileName}}" alt="{{$variant}} {{.Style}} {{$theme}}" loading="lazy"><figcaption>{{.FileName}}</figcaption></figure></td>
+{{- else}}<td>—</td>{{end}}
+{{- end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderGalleryHTML returns a standalone HTML page previewing badges, meant
+// to be saved as index.html next to the SVG files
+func RenderGalleryHTML(username string, badges []MatrixBadge) (string, error) {
+	var buf bytes.Buffer
+	if err := galleryHTMLTemplate.Execute(&buf, newGallery(username, badges)); err != nil {
+		return "", fmt.Errorf("rendering gallery: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,112 @@
+package badge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func matrixTestStats() *ossstats.Stats {
+	return &ossstats.Stats{
+		Username: "testuser",
+		Summary: ossstats.Summary{
+			TotalProjects:  3,
+			TotalPRsMerged: 12,
+		},
+		Contributions: []ossstats.Contribution{
+			{Repo: "golang/go", Owner: "golang", RepoName: "go", Stars: 120000, PRsMerged: 5},
+		},
+	}
+}
+
+func TestRenderMatrixAll(t *testing.T) {
+	badges, err := RenderMatrix(matrixTestStats(), Matrix{}, BadgeOptions{})
+	if err != nil {
+		t.Fatalf("RenderMatrix() unexpected error: %v", err)
+	}
+
+	want := len(BadgeVariants) * len(BadgeStyles) * len(BadgeThemes)
+	if len(badges) != want {
+		t.Fatalf("RenderMatrix() rendered %d badges, want %d", len(badges), want)
+	}
+
+	seen := make(map[string]bool)
+	for _, b := range badges {
+		if seen[b.FileName] {
+			t.Errorf("duplicate file name %s", b.FileName)
+		}
+		seen[b.FileName] = true
+
+		if !strings.Contains(b.SVG, "<svg") {
+			t.Errorf("%s: SVG should contain <svg", b.FileName)
+		}
+	}
+
+	if badges[0].FileName != "default-summary-dark.svg" {
+		t.Errorf("first badge = %s, want default-summary-dark.svg", badges[0].FileName)
+	}
+}
+
+func TestRenderMatrixSubset(t *testing.T) {
+	m := Matrix{
+		Variants: []BadgeVariant{VariantTextBased},
+		Styles:   []BadgeStyle{StyleCompact, StyleDetailed},
+		Themes:   []BadgeTheme{ThemeNord},
+	}
+
+	badges, err := RenderMatrix(matrixTestStats(), m, BadgeOptions{Limit: 3})
+	if err != nil {
+		t.Fatalf("RenderMatrix() unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, b := range badges {
+		got = append(got, b.FileName)
+	}
+	want := []string{"text-based-compact-nord.svg", "text-based-detailed-nord.svg"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("file names = %v, want %v", got, want)
+	}
+}
+
+func TestRenderGalleryMarkdown(t *testing.T) {
+	badges := []MatrixBadge{
+		{Variant: VariantDefault, Style: StyleSummary, Theme: ThemeGithubDark, FileName: "default-summary-dark.svg"},
+		{Variant: VariantDefault, Style: StyleCompact, Theme: ThemeGithubDark, FileName: "default-compact-dark.svg"},
+		{Variant: VariantDefault, Style: StyleSummary, Theme: ThemeNord, FileName: "default-summary-nord.svg"},
+	}
+
+	md := RenderGalleryMarkdown("testuser", badges)
+
+	for _, want := range []string{
+		"# Badge Gallery",
+		"All 3 badge combinations for @testuser",
+		"## default",
+		"| Theme | summary | compact |",
+		"| dark | ![default summary dark](default-summary-dark.svg) | ![default compact dark](default-compact-dark.svg) |",
+		"| nord | ![default summary nord](default-summary-nord.svg) | — |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q\n%s", want, md)
+		}
+	}
+}
+
+func TestRenderGalleryHTML(t *testing.T) {
+	badges := []MatrixBadge{
+		{Variant: VariantTextBased, Style: StyleDetailed, Theme: ThemeDracula, FileName: "text-based-detailed-dracula.svg"},
+	}
+
+	html, err := RenderGalleryHTML("<script>", badges)
+	if err != nil {
+		t.Fatalf("RenderGalleryHTML() unexpected error: %v", err)
+	}
+
+	if !strings.Contains(html, `<img src="text-based-detailed-dracula.svg" alt="text-based detailed dracula"`) {
+		t.Errorf("HTML missing badge image\n%s", html)
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("username should be escaped")
+	}
+}
@@ -12,10 +12,17 @@ type BadgeStyle string
 
 const (
 	StyleSummary  BadgeStyle = "summary"  // 400x200 - Key metrics
-	StyleCompact  BadgeStyle = "compact"  // 280x28 - Shields.io style
-	StyleDetailed BadgeStyle = "detailed" // 400x320 - Full stats
+	StyleCompact  BadgeStyle = "compact"  // auto x 32 - Shields.io style, width fitted to text
+	StyleDetailed BadgeStyle = "detailed" // 900 (text-based: 720) x grows with the listed repos - Full stats
 )
 
+// BadgeStyles lists all styles
+var BadgeStyles = []BadgeStyle{
+	StyleSummary,
+	StyleCompact,
+	StyleDetailed,
+}
+
 func BadgeStyleFromName(name string) (BadgeStyle, error) {
 	switch strings.ToLower(name) {
 	case "summary":
@@ -7,7 +7,11 @@ const DefaultSummary = `<svg
   viewBox="0 0 400 200"
   xmlns="http://www.w3.org/2000/svg"
   role="img"
-  aria-label="Open Source Contribution Summary">
+  direction="{{.Direction}}"
+  aria-labelledby="{{.A11yID}}-title"
+  aria-describedby="{{.A11yID}}-desc">
+  <title id="{{.A11yID}}-title">{{.A11yTitle}}</title>
+  <desc id="{{.A11yID}}-desc">{{.A11yDesc}}</desc>
   <defs>
     <style>
       text {
@@ -45,30 +49,34 @@ const DefaultSummary = `<svg
   <!-- Background -->
   <rect class="bg" width="400" height="200" rx="16"/>
   <!-- Header -->
-  <text class="username" x="28" y="42">@{{.Stats.Username}}</text>
-  <text class="subtitle" x="28" y="62">open source contributions</text>
+  <text class="username" x="{{flipX 400 28}}" y="42">{{fitText (printf "@%s" .Stats.Username) 344 18 true}}</text>
+  <text class="subtitle" x="{{flipX 400 28}}" y="62">{{.Labels.OpenSourceLower}}</text>
   <!-- Stat Cards -->
-  <rect class="card" x="22" y="91" width="108" height="70" rx="10"/>
-  <rect class="card" x="146" y="91" width="108" height="70" rx="10"/>
-  <rect class="card" x="270" y="91" width="108" height="70" rx="10"/>
+  <rect class="card" x="{{flipRect 400 22 108}}" y="91" width="108" height="70" rx="10"/>
+  <rect class="card" x="{{flipRect 400 146 108}}" y="91" width="108" height="70" rx="10"/>
+  <rect class="card" x="{{flipRect 400 270 108}}" y="91" width="108" height="70" rx="10"/>
   <!-- Stats -->
-  <text class="stat-value" x="76" y="123" text-anchor="middle">{{.TotalProjects}}</text>
-  <text class="stat-label" x="76" y="144" text-anchor="middle">PROJECTS</text>
-  <text class="stat-value" x="200" y="123" text-anchor="middle">{{.TotalPRs}}</text>
-  <text class="stat-label" x="200" y="144" text-anchor="middle">PRS MERGED</text>
-  <text class="stat-value" x="324" y="123" text-anchor="middle">{{.TotalLines}}</text>
-  <text class="stat-label" x="324" y="144" text-anchor="middle">LINES CHANGED</text>
+  <text class="stat-value" x="{{flipX 400 76}}" y="123" text-anchor="middle">{{.TotalProjects}}</text>
+  <text class="stat-label" x="{{flipX 400 76}}" y="144" text-anchor="middle">{{upper .Labels.Projects}}</text>
+  <text class="stat-value" x="{{flipX 400 200}}" y="123" text-anchor="middle">{{.TotalPRs}}</text>
+  <text class="stat-label" x="{{flipX 400 200}}" y="144" text-anchor="middle">{{upper .Labels.PRsMerged}}</text>
+  <text class="stat-value" x="{{flipX 400 324}}" y="123" text-anchor="middle">{{.TotalLines}}</text>
+  <text class="stat-label" x="{{flipX 400 324}}" y="144" text-anchor="middle">{{upper .Labels.LinesChanged}}</text>
 </svg>
 `
 
-// compactTemplate is the SVG template for the Compact badge style (280x28) - Shields.io style
+// compactTemplate is the SVG template for the Compact badge style (auto width x 32) - Shields.io style
 const DefaultCompact = `<svg
-  width="280"
+  width="{{.CompactWidth}}"
   height="32"
-  viewBox="0 0 280 32"
+  viewBox="0 0 {{.CompactWidth}} 32"
   xmlns="http://www.w3.org/2000/svg"
   role="img"
-  aria-label="OSS Contributions">
+  direction="{{.Direction}}"
+  aria-labelledby="{{.A11yID}}-title"
+  aria-describedby="{{.A11yID}}-desc">
+  <title id="{{.A11yID}}-title">{{.A11yTitle}}</title>
+  <desc id="{{.A11yID}}-desc">{{.A11yDesc}}</desc>
 
   <defs>
     <style>
@@ -95,15 +103,16 @@ const DefaultCompact = `<svg
   </defs>
 
   <!-- Background -->
-  <rect class="card" x="0.5" y="0.5" width="279" height="31"/>
+  <rect class="card" x="0.5" y="0.5" width="{{sub .CompactWidth 1}}" height="31"/>
 
   <!-- Text -->
-  <text x="140" y="21" text-anchor="middle">
-    OSS · {{.CompactText}}
+  <text x="{{div .CompactWidth 2}}" y="21" text-anchor="middle">
+    {{.Labels.OSS}} · {{.CompactText}}
   </text>
 </svg>`
 
-// detailedTemplate is the SVG template for the Detailed badge style (400x320)
+// detailedTemplate is the SVG template for the Detailed badge style (900 wide,
+// one 120px row per 3 listed repos)
 const DefaultDetailed = `
 <svg
   width="900"
@@ -111,7 +120,11 @@ const DefaultDetailed = `
   viewBox="0 0 900 {{add 278 (mul (div (add (len .TopContributions) 2) 3) 120)}}"
   xmlns="http://www.w3.org/2000/svg"
   role="img"
-  aria-label="GitHub Open Source Contribution Stats">
+  direction="{{.Direction}}"
+  aria-labelledby="{{.A11yID}}-title"
+  aria-describedby="{{.A11yID}}-desc">
+  <title id="{{.A11yID}}-title">{{.A11yTitle}}</title>
+  <desc id="{{.A11yID}}-desc">{{.A11yDesc}}</desc>
 
   <!-- ========================= -->
   <!-- Theme + Styling -->
@@ -180,6 +193,12 @@ const DefaultDetailed = `
       fill: var(--text-muted);
     }
 
+    .avatar-initials {
+      font-size: 13px;
+      font-weight: 700;
+      fill: #ffffff;
+    }
+
     .fade-in {
       opacity: 0;
       animation: fadeUp 0.6s ease-out forwards;
@@ -222,8 +241,8 @@ const DefaultDetailed = `
   <!-- Header -->
   <!-- ========================= -->
   <g class="fade-in">
-    <text x="32" y="50" class="title">
-      {{.Stats.Username}} · Open Source
+    <text x="{{flipX 900 32}}" y="50" class="title">
+      {{fitText (printf "%s · %s" .Stats.Username .Labels.OpenSource) 836 26 true}}
     </text>
   </g>
 
@@ -233,33 +252,33 @@ const DefaultDetailed = `
 
   <!-- Projects -->
   <g class="fade-in" style="animation-delay: 100ms">
-    <rect x="32" y="96" width="260" height="96" class="card"/>
-    <rect x="32" y="96" width="260" height="96" class="card-glass"/>
-    <text x="48" y="132" class="metric-label">Projects</text>
-    <text x="48" y="168" class="metric-value">{{.TotalProjects}}</text>
+    <rect x="{{flipRect 900 32 260}}" y="96" width="260" height="96" class="card"/>
+    <rect x="{{flipRect 900 32 260}}" y="96" width="260" height="96" class="card-glass"/>
+    <text x="{{flipX 900 48}}" y="132" class="metric-label">{{.Labels.Projects}}</text>
+    <text x="{{flipX 900 48}}" y="168" class="metric-value">{{.TotalProjects}}</text>
   </g>
 
   <!-- PRs -->
   <g class="fade-in" style="animation-delay: 150ms">
-    <rect x="320" y="96" width="260" height="96" class="card"/>
-    <rect x="320" y="96" width="260" height="96" class="card-glass"/>
-    <text x="336" y="132" class="metric-label">PRs Merged</text>
-    <text x="336" y="168" class="metric-value">{{.TotalPRs}}</text>
+    <rect x="{{flipRect 900 320 260}}" y="96" width="260" height="96" class="card"/>
+    <rect x="{{flipRect 900 320 260}}" y="96" width="260" height="96" class="card-glass"/>
+    <text x="{{flipX 900 336}}" y="132" class="metric-label">{{.Labels.PRsMergedTitle}}{{if .AcceptanceRate}} · {{.AcceptanceRate}} {{.Labels.Accepted}}{{end}}</text>
+    <text x="{{flipX 900 336}}" y="168" class="metric-value">{{.TotalPRs}}</text>
   </g>
 
   <!-- Lines Changed -->
   <g class="fade-in" style="animation-delay: 200ms">
-    <rect x="608" y="96" width="260" height="96" class="card"/>
-    <rect x="608" y="96" width="260" height="96" class="card-glass"/>
-    <text x="624" y="132" class="metric-label">Lines Changed</text>
-    <text x="624" y="168" class="metric-value">{{.TotalLines}}</text>
+    <rect x="{{flipRect 900 608 260}}" y="96" width="260" height="96" class="card"/>
+    <rect x="{{flipRect 900 608 260}}" y="96" width="260" height="96" class="card-glass"/>
+    <text x="{{flipX 900 624}}" y="132" class="metric-label">{{.Labels.LinesChangedTitle}}</text>
+    <text x="{{flipX 900 624}}" y="168" class="metric-value">{{.TotalLines}}</text>
   </g>
 
   <!-- ========================= -->
   <!-- Top Contributions -->
   <!-- ========================= -->
-  <text x="32" y="224" class="subtitle fade-in" style="animation-delay: 260ms">
-    Top Contributions
+  <text x="{{flipX 900 32}}" y="224" class="subtitle fade-in" style="animation-delay: 260ms">
+    {{.Labels.TopContributions}}
   </text>
 
   {{range $i, $r := .TopContributions}}
@@ -267,14 +286,14 @@ const DefaultDetailed = `
   {{ $row := div $i 3 }}
   <g class="fade-in" style="animation-delay: {{add 300 (mul $i 80)}}ms">
     <rect
-      x="{{add 32 (mul $col 288)}}"
+      x="{{flipRect 900 (add 32 (mul $col 288)) 260}}"
       y="{{add 240 (mul $row 120)}}"
       width="260"
       height="108"
       class="card"
     />
     <rect
-      x="{{add 32 (mul $col 288)}}"
+      x="{{flipRect 900 (add 32 (mul $col 288)) 260}}"
       y="{{add 240 (mul $row 120)}}"
       width="260"
       height="108"
@@ -282,17 +301,37 @@ const DefaultDetailed = `
     />
 
     <text
-      x="{{add 48 (mul $col 288)}}"
+      x="{{flipX 900 (add 48 (mul $col 288))}}"
       y="{{add 278 (mul $row 120)}}"
       class="repo-name">
-      {{$r.RepoName}}
+      {{fitText $r.RepoName (sub 228 $.AvatarSpace) 16 true}}
     </text>
     <text
-      x="{{add 48 (mul $col 288)}}"
+      x="{{flipX 900 (add 48 (mul $col 288))}}"
       y="{{add 304 (mul $row 120)}}"
       class="repo-meta">
-      ⭐ {{$r.Stars}} · {{$r.PRs}} PRs Merged
+      ⭐ {{$r.Stars}} · {{$r.PRs}} {{$.Labels.PRsMergedTitle}}
     </text>
+
+    {{if $.ShowAvatars}}
+    {{ $cx := flipX 900 (add 260 (mul $col 288)) }}
+    {{ $cy := add 274 (mul $row 120) }}
+    {{if $r.AvatarURI}}
+    <clipPath id="avatar-clip-{{$i}}">
+      <circle cx="{{$cx}}" cy="{{$cy}}" r="16"/>
+    </clipPath>
+    <image
+      href="{{$r.AvatarURI}}"
+      x="{{sub $cx 16}}"
+      y="{{sub $cy 16}}"
+      width="32"
+      height="32"
+      clip-path="url(#avatar-clip-{{$i}})"/>
+    {{else}}
+    <circle cx="{{$cx}}" cy="{{$cy}}" r="16" fill="{{$r.AvatarColor}}"/>
+    <text x="{{$cx}}" y="{{add $cy 5}}" text-anchor="middle" class="avatar-initials">{{$r.Initials}}</text>
+    {{end}}
+    {{end}}
   </g>
   {{end}}
 
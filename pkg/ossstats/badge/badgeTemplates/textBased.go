@@ -1,12 +1,16 @@
 package badgetemplates
 
 const TextBasedCompact = `<svg
-  width="280"
+  width="{{.CompactWidth}}"
   height="32"
-  viewBox="0 0 280 32"
+  viewBox="0 0 {{.CompactWidth}} 32"
   xmlns="http://www.w3.org/2000/svg"
   role="img"
-  aria-label="OSS Contributions">
+  direction="{{.Direction}}"
+  aria-labelledby="{{.A11yID}}-title"
+  aria-describedby="{{.A11yID}}-desc">
+  <title id="{{.A11yID}}-title">{{.A11yTitle}}</title>
+  <desc id="{{.A11yID}}-desc">{{.A11yDesc}}</desc>
   <defs>
     <style>
       .bg { fill: {{.Colors.Background}}; }
@@ -20,15 +24,15 @@ const TextBasedCompact = `<svg
       .sep { fill: {{.Colors.TextSecondary}}; }
     </style>
   </defs>
-  <rect class="bg" width="280" height="32" rx="16"/>
-  <text class="content" x="140" y="19.5" text-anchor="middle">
-    <tspan class="bold">OSS</tspan>
+  <rect class="bg" width="{{.CompactWidth}}" height="32" rx="16"/>
+  <text class="content" x="{{div .CompactWidth 2}}" y="19.5" text-anchor="middle">
+    <tspan class="bold">{{.Labels.OSS}}</tspan>
     <tspan class="sep"> · </tspan>
     <tspan class="bold">{{.TotalProjects}}</tspan>
-    <tspan> projects</tspan>
+    <tspan> {{.Labels.ProjectsUnit}}</tspan>
     <tspan class="sep"> · </tspan>
     <tspan class="bold">{{.TotalPRs}}</tspan>
-    <tspan> PRs Merged</tspan>
+    <tspan> {{.Labels.PRsMergedTitle}}</tspan>
   </text>
 </svg>`
 
@@ -39,7 +43,11 @@ const TextBasedSummary = `<svg
   viewBox="0 0 400 200"
   xmlns="http://www.w3.org/2000/svg"
   role="img"
-  aria-label="Open Source Contribution Summary">
+  direction="{{.Direction}}"
+  aria-labelledby="{{.A11yID}}-title"
+  aria-describedby="{{.A11yID}}-desc">
+  <title id="{{.A11yID}}-title">{{.A11yTitle}}</title>
+  <desc id="{{.A11yID}}-desc">{{.A11yDesc}}</desc>
   <defs>
     <style>
       text {
@@ -72,18 +80,19 @@ const TextBasedSummary = `<svg
   <!-- Background -->
   <rect class="bg" width="400" height="200" rx="16"/>
   <!-- Header -->
-  <text class="username" x="28" y="45">@{{.Stats.Username}}</text>
-  <text class="subtitle" x="28" y="62">Open Source Contributions</text>
+  <text class="username" x="{{flipX 400 28}}" y="45">{{fitText (printf "@%s" .Stats.Username) 344 18 true}}</text>
+  <text class="subtitle" x="{{flipX 400 28}}" y="62">{{.Labels.OpenSourceTitle}}</text>
   <!-- Stats -->
-  <text class="stat-value" x="60" y="127" text-anchor="middle">{{.TotalProjects}}</text>
-  <text class="stat-label" x="60" y="141" text-anchor="middle">PROJECTS</text>
-  <text class="stat-value" x="180" y="127" text-anchor="middle">{{.TotalPRs}}</text>
-  <text class="stat-label" x="180" y="141" text-anchor="middle">PRs MERGED</text>
-  <text class="stat-value" x="300" y="127" text-anchor="middle">{{.TotalLines}}</text>
-  <text class="stat-label" x="300" y="141" text-anchor="middle">LINES CHANGED</text>
+  <text class="stat-value" x="{{flipX 400 60}}" y="127" text-anchor="middle">{{.TotalProjects}}</text>
+  <text class="stat-label" x="{{flipX 400 60}}" y="141" text-anchor="middle">{{upper .Labels.Projects}}</text>
+  <text class="stat-value" x="{{flipX 400 180}}" y="127" text-anchor="middle">{{.TotalPRs}}</text>
+  <text class="stat-label" x="{{flipX 400 180}}" y="141" text-anchor="middle">{{.Labels.PRsMergedUpper}}</text>
+  <text class="stat-value" x="{{flipX 400 300}}" y="127" text-anchor="middle">{{.TotalLines}}</text>
+  <text class="stat-label" x="{{flipX 400 300}}" y="141" text-anchor="middle">{{upper .Labels.LinesChanged}}</text>
 </svg>`
 
-// textBasedDetailedTemplate is the SVG template for the Detailed badge style (400x320)
+// textBasedDetailedTemplate is the SVG template for the Detailed badge style
+// (720 wide, 56px per listed repo)
 const TextBasedDetailed = `
 	{{ $SVGHeight := add 300 (mul 56 (len .TopContributions)) }}
 	<svg
@@ -92,7 +101,11 @@ const TextBasedDetailed = `
   viewBox="0 0 720 {{$SVGHeight}}"
   xmlns="http://www.w3.org/2000/svg"
   role="img"
-  aria-label="Open Source Contributions">
+  direction="{{.Direction}}"
+  aria-labelledby="{{.A11yID}}-title"
+  aria-describedby="{{.A11yID}}-desc">
+  <title id="{{.A11yID}}-title">{{.A11yTitle}}</title>
+  <desc id="{{.A11yID}}-desc">{{.A11yDesc}}</desc>
 
   <defs>
     <style>
@@ -156,6 +169,12 @@ const TextBasedDetailed = `
         font-size: 12px;
         fill: {{.Colors.TextSecondary}};
       }
+
+      .avatar-initials {
+        font-size: 12px;
+        font-weight: 700;
+        fill: #ffffff;
+      }
     </style>
   </defs>
 
@@ -169,28 +188,28 @@ const TextBasedDetailed = `
     rx="20"/>
 
   <!-- Header -->
-  <text class="username" x="48" y="68">
-    {{.Stats.Username}}
+  <text class="username" x="{{flipX 720 48}}" y="68">
+    {{fitText .Stats.Username 624 30 true}}
   </text>
 
-  <text class="subtitle" x="48" y="92">
-    Open source contributions
+  <text class="subtitle" x="{{flipX 720 48}}" y="92">
+    {{.Labels.OpenSourceContributions}}
   </text>
 
   <!-- Stats -->
-  <g transform="translate(48, 132)">
+  <g transform="translate({{flipX 720 48}}, 132)">
     <text class="stat">{{.TotalProjects}}</text>
-    <text class="stat-label" y="22">Projects</text>
+    <text class="stat-label" y="22">{{.Labels.Projects}}</text>
   </g>
 
-  <g transform="translate(220, 132)">
+  <g transform="translate({{flipX 720 220}}, 132)">
     <text class="stat">{{.TotalPRs}}</text>
-    <text class="stat-label" y="22">PRs merged</text>
+    <text class="stat-label" y="22">{{.Labels.PRsMerged}}{{if .AcceptanceRate}} · {{.AcceptanceRate}} {{.Labels.Accepted}}{{end}}</text>
   </g>
 
-  <g transform="translate(390, 132)">
+  <g transform="translate({{flipX 720 390}}, 132)">
     <text class="stat">{{.TotalLines}}</text>
-    <text class="stat-label" y="22">Lines changed</text>
+    <text class="stat-label" y="22">{{.Labels.LinesChanged}}</text>
   </g>
 
   <!-- Divider -->
@@ -202,19 +221,37 @@ const TextBasedDetailed = `
     y2="196"/>
 
   <!-- Repo Section -->
-  <text class="section-title" x="48" y="228">
-    TOP REPOSITORIES
+  <text class="section-title" x="{{flipX 720 48}}" y="228">
+    {{upper .Labels.TopRepositories}}
   </text>
 
   {{range $i, $r := .TopContributions}}
-  <g transform="translate(48, {{add 260 (mul $i 56)}})">
-    <text class="repo-name">
-      {{$r.RepoName}}
+  <g transform="translate({{flipX 720 48}}, {{add 260 (mul $i 56)}})">
+    {{if $.ShowAvatars}}
+    {{if $r.AvatarURI}}
+    <clipPath id="avatar-clip-{{$i}}">
+      <circle cx="{{dirX 14}}" cy="5" r="14"/>
+    </clipPath>
+    <image
+      href="{{$r.AvatarURI}}"
+      x="{{sub (dirX 14) 14}}"
+      y="-9"
+      width="28"
+      height="28"
+      clip-path="url(#avatar-clip-{{$i}})"/>
+    {{else}}
+    <circle cx="{{dirX 14}}" cy="5" r="14" fill="{{$r.AvatarColor}}"/>
+    <text x="{{dirX 14}}" y="9" text-anchor="middle" class="avatar-initials">{{$r.Initials}}</text>
+    {{end}}
+    {{end}}
+
+    <text class="repo-name" x="{{dirX $.AvatarSpace}}">
+      {{fitText $r.RepoName (sub 480 $.AvatarSpace) 16 true}}
       <tspan class="repo-stars"> ★ {{$r.Stars}}</tspan>
     </text>
 
-    <text class="repo-meta" y="22">
-      {{$r.PRs}} PRs merged
+    <text class="repo-meta" x="{{dirX $.AvatarSpace}}" y="22">
+      {{$r.PRs}} {{$.Labels.PRsMerged}}
     </text>
   </g>
   {{end}}
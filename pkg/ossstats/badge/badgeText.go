@@ -0,0 +1,126 @@
+package badge
+
+import (
+	"math"
+	"unicode"
+)
+
+// Advance widths for printable ASCII (0x20-0x7E) in 1/1000 em, taken from the
+// Adobe Helvetica and Helvetica-Bold AFM files. The badge templates use the
+// system-ui/Helvetica/Arial stack, which all share close enough metrics for
+// layout purposes.
+var (
+	helveticaWidths = [95]uint16{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' - '/'
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // '0' - '9'
+		278, 278, 584, 584, 584, 556, 1015, // ':' - '@'
+		667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // 'A' - 'M'
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // 'N' - 'Z'
+		278, 278, 278, 469, 556, 333, // '[' - '`'
+		556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // 'a' - 'm'
+		556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // 'n' - 'z'
+		334, 260, 334, 584, // '{' - '~'
+	}
+
+	helveticaBoldWidths = [95]uint16{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' - '/'
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // '0' - '9'
+		333, 333, 584, 584, 584, 611, 975, // ':' - '@'
+		722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, // 'A' - 'M'
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // 'N' - 'Z'
+		333, 278, 333, 584, 556, 333, // '[' - '`'
+		556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, // 'a' - 'm'
+		611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, // 'n' - 'z'
+		389, 280, 389, 584, // '{' - '~'
+	}
+)
+
+const (
+	// fallbackWidth is used for non-ASCII letters (accented Latin, Arabic, Hebrew, ...)
+	fallbackWidth = 556
+
+	// wideWidth is used for emoji and East Asian wide characters
+	wideWidth = 1000
+
+	ellipsis = "…"
+)
+
+// textWidth returns the rendered width in pixels of s at the given font size
+func textWidth(s string, fontSize float64, bold bool) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+
+	var units int
+	for _, r := range s {
+		units += runeWidth(r, widths)
+	}
+
+	return float64(units) * fontSize / 1000
+}
+
+// runeWidth returns the advance width of r in 1/1000 em
+func runeWidth(r rune, widths *[95]uint16) int {
+	switch {
+	case r >= 0x20 && r <= 0x7E:
+		return int(widths[r-0x20])
+	case r == '·' || r == '•':
+		return 278
+	case r == '…':
+		return 1000
+	case r == '★':
+		return 800
+	case unicode.Is(unicode.Mn, r), r == 0x200F, r == 0x200E:
+		// Combining marks and direction marks take no space
+		return 0
+	case r >= 0x1100 && isWide(r):
+		return wideWidth
+	default:
+		return fallbackWidth
+	}
+}
+
+// isWide reports whether r is rendered double width (emoji, CJK)
+func isWide(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hangul, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		(r >= 0x1F000 && r <= 0x1FAFF) || // Emoji and pictographs
+		(r >= 0x2600 && r <= 0x27BF) // Misc symbols and dingbats
+}
+
+// fitText returns s unchanged if it fits within maxWidth pixels, otherwise
+// the longest prefix that fits with a trailing ellipsis
+func fitText(s string, maxWidth, fontSize float64, bold bool) string {
+	if textWidth(s, fontSize, bold) <= maxWidth {
+		return s
+	}
+
+	budget := maxWidth - textWidth(ellipsis, fontSize, bold)
+	if budget <= 0 {
+		return ellipsis
+	}
+
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+
+	var used float64
+	for i, r := range s {
+		w := float64(runeWidth(r, widths)) * fontSize / 1000
+		if used+w > budget {
+			return s[:i] + ellipsis
+		}
+		used += w
+	}
+
+	return s
+}
+
+// roundUp rounds a pixel width up to the next whole pixel
+func roundUp(w float64) int {
+	return int(math.Ceil(w))
+}
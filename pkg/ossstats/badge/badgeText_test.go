@@ -0,0 +1,59 @@
+package badge
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		fontSize float64
+		bold     bool
+		want     float64
+	}{
+		{name: "empty", text: "", fontSize: 12, want: 0},
+		{name: "digits", text: "100", fontSize: 10, want: 16.68},
+		{name: "regular_vs_bold", text: "m", fontSize: 10, want: 8.33},
+		{name: "bold_m", text: "m", fontSize: 10, bold: true, want: 8.89},
+		{name: "non_ascii_fallback", text: "é", fontSize: 10, want: 5.56},
+		{name: "wide_cjk", text: "漢", fontSize: 10, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := textWidth(tt.text, tt.fontSize, tt.bold)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("textWidth(%q) = %.2f, want %.2f", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     string
+	}{
+		{name: "fits", text: "react", maxWidth: 100, want: "react"},
+		{name: "exact_fit", text: "iiii", maxWidth: textWidth("iiii", 10, false), want: "iiii"},
+		{name: "truncated", text: "kubernetes", maxWidth: 40, want: "kuber…"},
+		{name: "too_narrow", text: "kubernetes", maxWidth: 5, want: "…"},
+		{name: "multibyte", text: "ééééééé", maxWidth: 30, want: "ééé…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitText(tt.text, tt.maxWidth, 10, false)
+			if got != tt.want {
+				t.Errorf("fitText(%q, %.0f) = %q, want %q", tt.text, tt.maxWidth, got, tt.want)
+			}
+			if w := textWidth(got, 10, false); w > tt.maxWidth && got != "…" {
+				t.Errorf("fitText(%q) width = %.2f, exceeds %.2f", tt.text, w, tt.maxWidth)
+			}
+		})
+	}
+}
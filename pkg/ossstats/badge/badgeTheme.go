@@ -19,6 +19,16 @@ const (
 	ThemeGruvboxLight BadgeTheme = "gruvbox-light"
 )
 
+// BadgeThemes lists all built-in themes
+var BadgeThemes = []BadgeTheme{
+	ThemeGithubDark,
+	ThemeGithubLight,
+	ThemeDracula,
+	ThemeNord,
+	ThemeGruvboxDark,
+	ThemeGruvboxLight,
+}
+
 func BadgeThemeFromName(name string) (BadgeTheme, error) {
 	switch strings.ToLower(name) {
 	case "dark":
@@ -41,21 +51,21 @@ func BadgeThemeFromName(name string) (BadgeTheme, error) {
 // ThemeColors holds the color palette for a theme
 type ThemeColors struct {
 	// Backgrounds
-	Background    string // Main background
-	BackgroundAlt string // Cards, boxes, secondary areas
+	Background    string `json:"background"`    // Main background
+	BackgroundAlt string `json:"backgroundAlt"` // Cards, boxes, secondary areas
 
 	// Text
-	Text          string // Primary text (headings, values)
-	TextSecondary string // Labels, muted text
+	Text          string `json:"text"`          // Primary text (headings, values)
+	TextSecondary string `json:"textSecondary"` // Labels, muted text
 
 	// UI
-	Border string // Borders, dividers
-	Accent string // Primary brand color, highlights
+	Border string `json:"border"` // Borders, dividers
+	Accent string `json:"accent"` // Primary brand color, highlights
 
 	// Semantic (for stats)
-	Positive string // Additions, success, growth
-	Negative string // Deletions, errors
-	Star     string // Star counts (optional, can default to Accent)
+	Positive string `json:"positive"` // Additions, success, growth
+	Negative string `json:"negative"` // Deletions, errors
+	Star     string `json:"star"`     // Star counts (optional, can default to Accent)
 }
 
 // GetThemeColors returns the color palette for a given theme
@@ -78,7 +88,7 @@ func GetThemeColors(theme BadgeTheme) ThemeColors {
 			Background:    "#282a36",
 			BackgroundAlt: "#44475a",
 			Text:          "#f8f8f2",
-			TextSecondary: "#6272a4",
+			TextSecondary: "#b0b8e0",
 			Border:        "#44475a",
 			Accent:        "#bd93f9",
 			Positive:      "#50fa7b",
@@ -90,7 +100,7 @@ func GetThemeColors(theme BadgeTheme) ThemeColors {
 			Background:    "#2e3440",
 			BackgroundAlt: "#3b4252",
 			Text:          "#d8dee9",
-			TextSecondary: "#81a1c1",
+			TextSecondary: "#8fbcbb",
 			Border:        "#4c566a",
 			Accent:        "#88c0d0",
 			Positive:      "#a3be8c",
@@ -102,7 +112,7 @@ func GetThemeColors(theme BadgeTheme) ThemeColors {
 			Background:    "#282828",
 			BackgroundAlt: "#3c3836",
 			Text:          "#ebdbb2",
-			TextSecondary: "#a89984",
+			TextSecondary: "#bdae93",
 			Border:        "#3c3836",
 			Accent:        "#458588",
 			Positive:      "#98971a",
@@ -114,7 +124,7 @@ func GetThemeColors(theme BadgeTheme) ThemeColors {
 			Background:    "#fbf1c7",
 			BackgroundAlt: "#ebdbb2",
 			Text:          "#3c3836",
-			TextSecondary: "#7c6f64",
+			TextSecondary: "#665c54",
 			Border:        "#ebdbb2",
 			Accent:        "#458588",
 			Positive:      "#98971a",
@@ -14,6 +14,12 @@ const (
 	VariantTextBased BadgeVariant = "text-based"
 )
 
+// BadgeVariants lists all variants
+var BadgeVariants = []BadgeVariant{
+	VariantDefault,
+	VariantTextBased,
+}
+
 func BadgeVariantFromName(name string) (BadgeVariant, error) {
 	switch strings.ToLower(name) {
 	case "default":
@@ -1,6 +1,7 @@
 package badge
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -15,14 +16,14 @@ func TestRenderSVG_AllStyles(t *testing.T) {
 		Summary: ossstats.Summary{
 			TotalProjects:  42,
 			TotalPRsMerged: 1567,
-			TotalCommits:   3284,
+			TotalCommits:   intPtr(3284),
 			TotalAdditions: 125450,
 			TotalDeletions: 32000,
 		},
 		Contributions: []ossstats.Contribution{
-			{RepoName: "kubernetes/kubernetes", Stars: 108000, PRsMerged: 45, Commits: 120},
-			{RepoName: "facebook/react", Stars: 220000, PRsMerged: 38, Commits: 95},
-			{RepoName: "microsoft/vscode", Stars: 158000, PRsMerged: 32, Commits: 87},
+			{RepoName: "kubernetes/kubernetes", Stars: 108000, PRsMerged: 45, Commits: intPtr(120)},
+			{RepoName: "facebook/react", Stars: 220000, PRsMerged: 38, Commits: intPtr(95)},
+			{RepoName: "microsoft/vscode", Stars: 158000, PRsMerged: 32, Commits: intPtr(87)},
 		},
 	}
 
@@ -55,7 +56,7 @@ func TestRenderSVG_AllStyles(t *testing.T) {
 			style:     StyleCompact,
 			variant:   VariantDefault,
 			theme:     ThemeGithubDark,
-			wantWidth: `width="280"`,
+			wantWidth: `width="193"`,
 			wantErr:   false,
 		},
 		{
@@ -63,7 +64,7 @@ func TestRenderSVG_AllStyles(t *testing.T) {
 			style:     StyleCompact,
 			variant:   VariantDefault,
 			theme:     ThemeGithubLight,
-			wantWidth: `width="280"`,
+			wantWidth: `width="193"`,
 			wantErr:   false,
 		},
 		{
@@ -95,7 +96,7 @@ func TestRenderSVG_AllStyles(t *testing.T) {
 			style:     StyleCompact,
 			variant:   VariantDefault,
 			theme:     ThemeNord,
-			wantWidth: `width="280"`,
+			wantWidth: `width="193"`,
 			wantErr:   false,
 		},
 		// Text-Based Variants
@@ -112,7 +113,7 @@ func TestRenderSVG_AllStyles(t *testing.T) {
 			style:     StyleCompact,
 			variant:   VariantTextBased,
 			theme:     ThemeNord,
-			wantWidth: `width="280"`,
+			wantWidth: `width="215"`,
 			wantErr:   false,
 		},
 		{
@@ -176,7 +177,7 @@ func TestRenderSVG_EmptyContributions(t *testing.T) {
 		Summary: ossstats.Summary{
 			TotalProjects:  0,
 			TotalPRsMerged: 0,
-			TotalCommits:   0,
+			TotalCommits:   intPtr(0),
 		},
 		Contributions: []ossstats.Contribution{},
 	}
@@ -294,12 +295,12 @@ func TestRenderSVG_DetailedBadgeSorting(t *testing.T) {
 		Summary: ossstats.Summary{
 			TotalProjects:  3,
 			TotalPRsMerged: 100,
-			TotalCommits:   200,
+			TotalCommits:   intPtr(200),
 		},
 		Contributions: []ossstats.Contribution{
-			{RepoName: "repo-a", Stars: 1000, PRsMerged: 5, Commits: 50},
-			{RepoName: "repo-b", Stars: 500, PRsMerged: 10, Commits: 30},
-			{RepoName: "repo-c", Stars: 2000, PRsMerged: 3, Commits: 100},
+			{RepoName: "repo-a", Stars: 1000, PRsMerged: 5, Commits: intPtr(50)},
+			{RepoName: "repo-b", Stars: 500, PRsMerged: 10, Commits: intPtr(30)},
+			{RepoName: "repo-c", Stars: 2000, PRsMerged: 3, Commits: intPtr(100)},
 		},
 	}
 
@@ -360,7 +361,7 @@ func TestRenderSVG_DetailedBadgeLimit(t *testing.T) {
 			RepoName:  string(rune('a' + i)),
 			Stars:     1000 * (10 - i),
 			PRsMerged: 10 - i,
-			Commits:   100,
+			Commits:   intPtr(100),
 		}
 	}
 
@@ -488,9 +489,9 @@ func TestGetTopContributions(t *testing.T) {
 	stats := &ossstats.Stats{
 		Username: "testuser",
 		Contributions: []ossstats.Contribution{
-			{RepoName: "repo-a", Stars: 1000, PRsMerged: 10, Commits: 50},
-			{RepoName: "repo-b", Stars: 2000, PRsMerged: 5, Commits: 100},
-			{RepoName: "repo-c", Stars: 500, PRsMerged: 20, Commits: 30},
+			{RepoName: "repo-a", Stars: 1000, PRsMerged: 10, Commits: intPtr(50)},
+			{RepoName: "repo-b", Stars: 2000, PRsMerged: 5, Commits: intPtr(100)},
+			{RepoName: "repo-c", Stars: 500, PRsMerged: 20, Commits: intPtr(30)},
 		},
 	}
 
@@ -526,7 +527,7 @@ func TestGetTopContributions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getTopContributions(stats, tt.sortBy, tt.limit)
+			got := getTopContributions(stats, tt.sortBy, tt.limit, localeFor(LocaleEnglish))
 
 			if len(got) != tt.wantLen {
 				t.Errorf("getTopContributions() len = %d, want %d", len(got), tt.wantLen)
@@ -620,3 +621,267 @@ func TestRenderSVG_CompactBadgeContent(t *testing.T) {
 		t.Error("Compact badge missing '1.6K PRs'")
 	}
 }
+
+func TestRenderSVG_AccessibleText(t *testing.T) {
+	stats := &ossstats.Stats{
+		Username: "test<user>",
+		Summary: ossstats.Summary{
+			TotalProjects:  3,
+			TotalPRsMerged: 12,
+		},
+		Contributions: []ossstats.Contribution{
+			{RepoName: "tom&jerry", Stars: 1500, PRsMerged: 7},
+		},
+	}
+
+	for _, variant := range []BadgeVariant{VariantDefault, VariantTextBased} {
+		for _, style := range []BadgeStyle{StyleSummary, StyleCompact, StyleDetailed} {
+			t.Run(string(variant)+"_"+string(style), func(t *testing.T) {
+				svg, err := RenderSVG(stats, BadgeOptions{Style: style, Variant: variant})
+				if err != nil {
+					t.Fatalf("RenderSVG() unexpected error: %v", err)
+				}
+
+				id := "badge-" + string(variant) + "-" + string(style) + "-test_user_"
+				wants := []string{
+					`role="img"`,
+					`aria-labelledby="` + id + `-title"`,
+					`aria-describedby="` + id + `-desc"`,
+					`<title id="` + id + `-title">@test&lt;user&gt; · Open source contributions</title>`,
+					`<desc id="` + id + `-desc">`,
+					`Projects: 3, PRs merged: 12`,
+					`tom&amp;jerry (1.5K stars, 7 PRs)`,
+				}
+				for _, want := range wants {
+					if !strings.Contains(svg, want) {
+						t.Errorf("RenderSVG() missing %q", want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRenderSVG_Locale(t *testing.T) {
+	stats := &ossstats.Stats{
+		Username: "testuser",
+		Summary: ossstats.Summary{
+			TotalProjects:  42,
+			TotalPRsMerged: 1567,
+		},
+		Contributions: []ossstats.Contribution{
+			{RepoName: "kubernetes", Stars: 108000, PRsMerged: 45},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		locale    BadgeLocale
+		style     BadgeStyle
+		variant   BadgeVariant
+		wantTexts []string
+	}{
+		// English keeps the wording badges had before they were translated
+		{
+			name:      "english_summary",
+			locale:    LocaleEnglish,
+			style:     StyleSummary,
+			variant:   VariantDefault,
+			wantTexts: []string{">open source contributions<", ">PRS MERGED<"},
+		},
+		{
+			name:      "english_detailed",
+			locale:    LocaleEnglish,
+			style:     StyleDetailed,
+			variant:   VariantDefault,
+			wantTexts: []string{"testuser · Open Source\n", ">PRs Merged<", "Top Contributions", "45 PRs Merged"},
+		},
+		{
+			name:      "english_text_summary",
+			locale:    LocaleEnglish,
+			style:     StyleSummary,
+			variant:   VariantTextBased,
+			wantTexts: []string{">Open Source Contributions<", ">PRs MERGED<"},
+		},
+		{
+			name:      "english_text_compact",
+			locale:    LocaleEnglish,
+			style:     StyleCompact,
+			variant:   VariantTextBased,
+			wantTexts: []string{"<tspan> PRs Merged</tspan>"},
+		},
+		{
+			name:      "german_summary",
+			locale:    LocaleGerman,
+			style:     StyleSummary,
+			variant:   VariantDefault,
+			wantTexts: []string{"PROJEKTE", "1,6 Tsd.", `direction="ltr"`, `x="28"`},
+		},
+		{
+			name:      "french_compact",
+			locale:    LocaleFrench,
+			style:     StyleCompact,
+			variant:   VariantDefault,
+			wantTexts: []string{"42 projets | 1,6 k PR"},
+		},
+		{
+			name:      "arabic_summary_rtl",
+			locale:    LocaleArabic,
+			style:     StyleSummary,
+			variant:   VariantDefault,
+			wantTexts: []string{`direction="rtl"`, `x="372"`, "١٫٦ ألف", "٤٢"},
+		},
+		{
+			name:      "hebrew_detailed_rtl",
+			locale:    LocaleHebrew,
+			style:     StyleDetailed,
+			variant:   VariantTextBased,
+			wantTexts: []string{`direction="rtl"`, "translate(672, 132)", "מאגרים מובילים", "108.0K"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svg, err := RenderSVG(stats, BadgeOptions{
+				Style:   tt.style,
+				Variant: tt.variant,
+				Locale:  tt.locale,
+			})
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+			for _, want := range tt.wantTexts {
+				if !strings.Contains(svg, want) {
+					t.Errorf("RenderSVG() missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSVG_CompactWidthFitsText(t *testing.T) {
+	small := &ossstats.Stats{Summary: ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 1}}
+	large := &ossstats.Stats{Summary: ossstats.Summary{TotalProjects: 420, TotalPRsMerged: 1_500_000}}
+
+	for _, variant := range []BadgeVariant{VariantDefault, VariantTextBased} {
+		t.Run(string(variant), func(t *testing.T) {
+			opts := BadgeOptions{Style: StyleCompact, Variant: variant}
+
+			smallSVG, err := RenderSVG(small, opts)
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+			largeSVG, err := RenderSVG(large, opts)
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+
+			smallWidth := compactWidth(variant, templateData{TotalProjects: "1", TotalPRs: "1", CompactText: "1 projects | 1 PRs", Labels: localeFor(LocaleEnglish).labels})
+			if !strings.Contains(smallSVG, fmt.Sprintf(`width="%d"`, smallWidth)) {
+				t.Errorf("small compact badge missing width=%d", smallWidth)
+			}
+			if strings.Contains(largeSVG, fmt.Sprintf(`width="%d"`, smallWidth)) {
+				t.Error("large compact badge should be wider than small one")
+			}
+		})
+	}
+}
+
+func TestRenderSVG_DetailedTruncatesLongRepoNames(t *testing.T) {
+	longName := strings.Repeat("very-long-repository-name-", 5)
+	stats := &ossstats.Stats{
+		Username:      "testuser",
+		Contributions: []ossstats.Contribution{{RepoName: longName, PRsMerged: 1}},
+	}
+
+	svg, err := RenderSVG(stats, BadgeOptions{Style: StyleDetailed, Variant: VariantDefault})
+	if err != nil {
+		t.Fatalf("RenderSVG() unexpected error: %v", err)
+	}
+
+	if !strings.Contains(svg, "very-long-repository-name-…") {
+		t.Error("detailed badge should truncate long repo name with an ellipsis")
+	}
+	// The accessible description keeps the full name
+	if !strings.Contains(svg, longName+" (") {
+		t.Error("accessible description should contain the full repo name")
+	}
+}
+
+func TestRenderSVG_AcceptanceRate(t *testing.T) {
+	rate := 0.75
+	stats := &ossstats.Stats{
+		Username: "testuser",
+		Summary:  ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 3, AcceptanceRate: &rate},
+	}
+
+	for _, variant := range []BadgeVariant{VariantDefault, VariantTextBased} {
+		t.Run(string(variant), func(t *testing.T) {
+			opts := BadgeOptions{Style: StyleDetailed, Variant: variant, Theme: ThemeGithubDark}
+
+			svg, err := RenderSVG(stats, opts)
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+			if strings.Contains(svg, "accepted") {
+				t.Error("acceptance rate should be hidden unless ShowAcceptanceRate is set")
+			}
+
+			opts.ShowAcceptanceRate = true
+			svg, err = RenderSVG(stats, opts)
+			if err != nil {
+				t.Fatalf("RenderSVG() unexpected error: %v", err)
+			}
+			if !strings.Contains(svg, "75% accepted") {
+				t.Error("detailed badge should show the acceptance rate")
+			}
+		})
+	}
+
+	// Stats fetched without PR states have no rate to show
+	stats.Summary.AcceptanceRate = nil
+	svg, err := RenderSVG(stats, BadgeOptions{Style: StyleDetailed, Variant: VariantDefault, ShowAcceptanceRate: true})
+	if err != nil {
+		t.Fatalf("RenderSVG() unexpected error: %v", err)
+	}
+	if strings.Contains(svg, "accepted") {
+		t.Error("acceptance rate should be hidden when the stats have none")
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestRenderSVG_TotalAccuracy(t *testing.T) {
+	stats := &ossstats.Stats{
+		Username: "testuser",
+		Summary: ossstats.Summary{
+			TotalProjects:  1,
+			TotalPRsMerged: 3,
+			TotalCommits:   intPtr(1500),
+			Accuracy:       ossstats.Accuracy{Commits: ossstats.AccuracyEstimated, Lines: ossstats.AccuracyUnknown},
+		},
+	}
+
+	svg, err := RenderSVG(stats, BadgeOptions{Style: StyleSummary, Variant: VariantDefault})
+	if err != nil {
+		t.Fatalf("RenderSVG() unexpected error: %v", err)
+	}
+	if !strings.Contains(svg, "Commits: ~1.5K") {
+		t.Error("estimated commits should be marked with ~")
+	}
+	if !strings.Contains(svg, "Lines changed: –") || strings.Contains(svg, ">0<") {
+		t.Error("unknown lines changed should be shown as –, not 0")
+	}
+
+	// Stats without accuracy (older versions) are treated as exact
+	stats.Summary.Accuracy = ossstats.Accuracy{}
+	svg, err = RenderSVG(stats, BadgeOptions{Style: StyleSummary, Variant: VariantDefault})
+	if err != nil {
+		t.Fatalf("RenderSVG() unexpected error: %v", err)
+	}
+	if !strings.Contains(svg, "Commits: 1.5K") || !strings.Contains(svg, "Lines changed: 0") {
+		t.Error("stats without accuracy should render exact values")
+	}
+}
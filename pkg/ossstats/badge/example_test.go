@@ -15,7 +15,6 @@ func ExampleRenderSVG_summary() {
 		Summary: ossstats.Summary{
 			TotalProjects:  42,
 			TotalPRsMerged: 156,
-			TotalCommits:   328,
 			TotalAdditions: 12450,
 			TotalDeletions: 3200,
 		},
@@ -34,7 +33,7 @@ func ExampleRenderSVG_summary() {
 	}
 
 	fmt.Printf("Generated summary badge (%d bytes)\n", len(svg))
-	// Output: Generated summary badge (1796 bytes)
+	// Output: Generated summary badge (2124 bytes)
 }
 
 func ExampleRenderSVG_compact() {
@@ -44,7 +43,6 @@ func ExampleRenderSVG_compact() {
 		Summary: ossstats.Summary{
 			TotalProjects:  42,
 			TotalPRsMerged: 156,
-			TotalCommits:   328,
 		},
 	}
 
@@ -61,7 +59,7 @@ func ExampleRenderSVG_compact() {
 	}
 
 	fmt.Printf("Generated compact badge (%d bytes)\n", len(svg))
-	// Output: Generated compact badge (946 bytes)
+	// Output: Generated compact badge (1284 bytes)
 }
 
 func ExampleRenderSVG_detailed() {
@@ -71,7 +69,6 @@ func ExampleRenderSVG_detailed() {
 		Summary: ossstats.Summary{
 			TotalProjects:  42,
 			TotalPRsMerged: 156,
-			TotalCommits:   328,
 		},
 		Contributions: []ossstats.Contribution{
 			{RepoName: "kubernetes/kubernetes", Stars: 108000, PRsMerged: 45},
@@ -95,5 +92,5 @@ func ExampleRenderSVG_detailed() {
 	}
 
 	fmt.Printf("Generated detailed badge (%d bytes)\n", len(svg))
-	// Output: Generated detailed badge (5413 bytes)
+	// Output: Generated detailed badge (5996 bytes)
 }
@@ -0,0 +1,101 @@
+package badge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// ShieldsEndpoint is the JSON schema read by the shields.io endpoint badge
+// (https://shields.io/badges/endpoint-badge). Host the file anywhere and use
+// https://img.shields.io/endpoint?url=<file URL> to render it with shields styling.
+type ShieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color,omitempty"`
+	LabelColor    string `json:"labelColor,omitempty"`
+	NamedLogo     string `json:"namedLogo,omitempty"`
+}
+
+// ShieldsMetric is a single stat that can be exported as a shields endpoint
+type ShieldsMetric string
+
+const (
+	ShieldsProjects ShieldsMetric = "projects" // Number of external projects
+	ShieldsPRs      ShieldsMetric = "prs"      // Merged PRs
+	ShieldsLOC      ShieldsMetric = "loc"      // Lines changed (additions + deletions)
+	ShieldsStars    ShieldsMetric = "stars"    // Combined stars of the contributed repos
+)
+
+// ShieldsMetrics lists all metrics in output order
+var ShieldsMetrics = []ShieldsMetric{
+	ShieldsProjects,
+	ShieldsPRs,
+	ShieldsLOC,
+	ShieldsStars,
+}
+
+func ShieldsMetricFromName(name string) (ShieldsMetric, error) {
+	switch strings.ToLower(name) {
+	case "projects":
+		return ShieldsProjects, nil
+	case "prs":
+		return ShieldsPRs, nil
+	case "loc":
+		return ShieldsLOC, nil
+	case "stars":
+		return ShieldsStars, nil
+	}
+	err := fmt.Errorf("invalid shields metric: %s (must be: projects, prs, loc, stars)", name)
+	return ShieldsProjects, err
+}
+
+// RenderShields builds the shields endpoint JSON for one metric. The message
+// uses the locale's number formatting; the color comes from the theme accent
+// and the label color from the theme alternate background (BackgroundAlt).
+func RenderShields(stats *ossstats.Stats, metric ShieldsMetric, opts BadgeOptions) (ShieldsEndpoint, error) {
+	if stats == nil {
+		return ShieldsEndpoint{}, errors.New("stats cannot be nil")
+	}
+
+	lf := localeFor(opts.Locale)
+	colors := GetThemeColors(opts.Theme)
+
+	var label string
+	var value int
+	accuracy := ossstats.AccuracyExact
+	switch metric {
+	case ShieldsProjects:
+		label, value = lf.labels.Projects, stats.Summary.TotalProjects
+	case ShieldsPRs:
+		label, value = lf.labels.PRsMerged, stats.Summary.TotalPRsMerged
+	case ShieldsLOC:
+		label, value = lf.labels.LinesChanged, stats.Summary.TotalAdditions+stats.Summary.TotalDeletions
+		accuracy = stats.Summary.Accuracy.Lines
+	case ShieldsStars:
+		label, value = lf.labels.StarsReached, totalStars(stats)
+	default:
+		return ShieldsEndpoint{}, fmt.Errorf("unsupported shields metric: %s", metric)
+	}
+
+	return ShieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         label,
+		Message:       lf.formatTotal(&value, accuracy),
+		Color:         strings.TrimPrefix(colors.Accent, "#"),
+		LabelColor:    strings.TrimPrefix(colors.BackgroundAlt, "#"),
+		NamedLogo:     "github",
+	}, nil
+}
+
+// totalStars sums the stars of every repository contributed to
+func totalStars(stats *ossstats.Stats) int {
+	total := 0
+	for _, c := range stats.Contributions {
+		total += c.Stars
+	}
+	return total
+}
@@ -0,0 +1,110 @@
+package badge
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestRenderShields(t *testing.T) {
+	stats := &ossstats.Stats{
+		Username: "testuser",
+		Summary: ossstats.Summary{
+			TotalProjects:  42,
+			TotalPRsMerged: 1567,
+			TotalAdditions: 12000,
+			TotalDeletions: 3450,
+		},
+		Contributions: []ossstats.Contribution{
+			{Stars: 108000},
+			{Stars: 220000},
+		},
+	}
+
+	tests := []struct {
+		metric      ShieldsMetric
+		wantLabel   string
+		wantMessage string
+	}{
+		{ShieldsProjects, "Projects", "42"},
+		{ShieldsPRs, "PRs merged", "1.6K"},
+		{ShieldsLOC, "Lines changed", "15.4K"},
+		{ShieldsStars, "Stars reached", "328.0K"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			got, err := RenderShields(stats, tt.metric, BadgeOptions{Theme: ThemeGithubDark})
+			if err != nil {
+				t.Fatalf("RenderShields() unexpected error: %v", err)
+			}
+
+			if got.SchemaVersion != 1 {
+				t.Errorf("SchemaVersion = %d, want 1", got.SchemaVersion)
+			}
+			if got.Label != tt.wantLabel {
+				t.Errorf("Label = %q, want %q", got.Label, tt.wantLabel)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if got.Color != "58a6ff" {
+				t.Errorf("Color = %q, want theme accent 58a6ff", got.Color)
+			}
+		})
+	}
+}
+
+func TestRenderShieldsUnknownLines(t *testing.T) {
+	stats := &ossstats.Stats{
+		Summary: ossstats.Summary{Accuracy: ossstats.Accuracy{Lines: ossstats.AccuracyUnknown}},
+	}
+
+	got, err := RenderShields(stats, ShieldsLOC, BadgeOptions{Theme: ThemeGithubDark})
+	if err != nil {
+		t.Fatalf("RenderShields() unexpected error: %v", err)
+	}
+	if got.Message != "–" {
+		t.Errorf("Message = %q, want – when lines weren't fetched", got.Message)
+	}
+}
+
+func TestRenderShieldsJSON(t *testing.T) {
+	stats := &ossstats.Stats{Summary: ossstats.Summary{TotalProjects: 3}}
+
+	endpoint, err := RenderShields(stats, ShieldsProjects, BadgeOptions{Locale: LocaleGerman})
+	if err != nil {
+		t.Fatalf("RenderShields() unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(endpoint)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+	for _, key := range []string{"schemaVersion", "label", "message", "color"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("shields JSON missing %q: %s", key, data)
+		}
+	}
+	if decoded["label"] != "Projekte" {
+		t.Errorf("label = %v, want Projekte", decoded["label"])
+	}
+}
+
+func TestRenderShieldsErrors(t *testing.T) {
+	if _, err := RenderShields(nil, ShieldsPRs, BadgeOptions{}); err == nil {
+		t.Error("RenderShields(nil) expected error, got nil")
+	}
+	if _, err := RenderShields(&ossstats.Stats{}, ShieldsMetric("bogus"), BadgeOptions{}); err == nil {
+		t.Error("RenderShields() expected error for unknown metric, got nil")
+	}
+	if _, err := ShieldsMetricFromName("bogus"); err == nil {
+		t.Error("ShieldsMetricFromName() expected error, got nil")
+	}
+}
@@ -5,6 +5,17 @@ type BadgeOptions struct {
 	Style   BadgeStyle
 	Variant BadgeVariant
 	Theme   BadgeTheme
-	SortBy  SortBy // For detailed badge - how to sort contributions (default: prs)
-	Limit   int    // For detailed badge - max contributions to show (default: 5)
+	SortBy  SortBy      // For detailed badge - how to sort contributions (default: prs)
+	Limit   int         // For detailed badge - max contributions to show (default: 5)
+	Locale  BadgeLocale // Language for labels and number formatting (default: en)
+
+	// For detailed badge - show each repo owner's avatar
+	ShowAvatars bool
+	// Owner login -> image data URI (see AvatarFetcher). Owners without an
+	// entry are shown as colored initials.
+	Avatars map[string]string
+
+	// For detailed badge - show the PR acceptance rate next to merged PRs.
+	// Needs stats fetched with ossstats.WithPRStates.
+	ShowAcceptanceRate bool
 }
@@ -0,0 +1,229 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// checkpointVersion is bumped when the checkpoint file format changes.
+// Files of another version are ignored.
+const checkpointVersion = 1
+
+// checkpointInterval is the minimum time between checkpoint writes while
+// results are recorded
+const checkpointInterval = 5 * time.Second
+
+// checkpoint records the API results of a run so a later run can resume
+// without fetching them again. It is safe for concurrent use.
+type checkpoint struct {
+	path string
+
+	mu      sync.Mutex
+	state   checkpointState
+	dirty   bool
+	savedAt time.Time
+	// failed is set when a call made through the checkpoint fails, so an
+	// incomplete run keeps its checkpoint even if no error is returned
+	failed bool
+}
+
+// checkpointState is the checkpoint file content
+type checkpointState struct {
+	Version      int                                     `json:"version"`
+	Username     string                                  `json:"username"`
+	UpdatedAt    time.Time                               `json:"updatedAt"`
+	SearchPages  map[string]*github.SearchIssuesResponse `json:"searchPages"`  // By query and page
+	PullRequests map[string]*github.PullRequest          `json:"pullRequests"` // By owner/repo#number
+	Repositories map[string]*github.Repository           `json:"repositories"` // By owner/repo
+}
+
+// loadCheckpoint reads the checkpoint at path. A missing file, or one left
+// by a run for another user or version, starts an empty checkpoint.
+func loadCheckpoint(path, username string) (*checkpoint, error) {
+	cp := &checkpoint{
+		path: path,
+		state: checkpointState{
+			Version:      checkpointVersion,
+			Username:     username,
+			SearchPages:  make(map[string]*github.SearchIssuesResponse),
+			PullRequests: make(map[string]*github.PullRequest),
+			Repositories: make(map[string]*github.Repository),
+		},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+
+	var saved checkpointState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("parsing checkpoint %s: %w", path, err)
+	}
+	if saved.Version != checkpointVersion || saved.Username != username {
+		return cp, nil
+	}
+
+	maps.Copy(cp.state.SearchPages, saved.SearchPages)
+	maps.Copy(cp.state.PullRequests, saved.PullRequests)
+	maps.Copy(cp.state.Repositories, saved.Repositories)
+	cp.savedAt = time.Now()
+
+	return cp, nil
+}
+
+// empty reports whether nothing was recorded yet
+func (cp *checkpoint) empty() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.state.SearchPages)+len(cp.state.PullRequests)+len(cp.state.Repositories) == 0
+}
+
+// summary describes what the checkpoint holds, for logging
+func (cp *checkpoint) summary() string {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return fmt.Sprintf("%d search pages, %d PRs, %d repos",
+		len(cp.state.SearchPages), len(cp.state.PullRequests), len(cp.state.Repositories))
+}
+
+// save writes the checkpoint if anything was recorded since the last save.
+// The file is replaced atomically so an interrupted save can't corrupt it.
+func (cp *checkpoint) save() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.saveLocked()
+}
+
+// saveLocked is save for callers holding cp.mu
+func (cp *checkpoint) saveLocked() error {
+	if !cp.dirty {
+		return nil
+	}
+	cp.state.UpdatedAt = time.Now().UTC()
+
+	data, err := json.Marshal(cp.state)
+	if err != nil {
+		return fmt.Errorf("encoding checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cp.path), filepath.Base(cp.path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), cp.path); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+
+	cp.dirty = false
+	cp.savedAt = time.Now()
+	return nil
+}
+
+// remove deletes the checkpoint file once a run completed
+func (cp *checkpoint) remove() error {
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing checkpoint: %w", err)
+	}
+	return nil
+}
+
+// complete reports whether every call made through the checkpoint succeeded
+func (cp *checkpoint) complete() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return !cp.failed
+}
+
+// checkpointAPI serves search pages, PR details and repository metadata
+// from a checkpoint, and records the ones it fetches. Other calls go
+// straight to the wrapped API.
+type checkpointAPI struct {
+	github.GithubAPI
+	cp *checkpoint
+}
+
+// cachedResponse stands in for the HTTP response of a result read from the checkpoint
+func cachedResponse() *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+}
+
+func (a checkpointAPI) SearchIssues(ctx context.Context, query string, page, perPage int) (*github.SearchIssuesResponse, *http.Response, error) {
+	key := query + "|" + strconv.Itoa(page) + "|" + strconv.Itoa(perPage)
+	if result, ok := lookup(a.cp, a.cp.state.SearchPages, key); ok {
+		return result, cachedResponse(), nil
+	}
+
+	result, resp, err := a.GithubAPI.SearchIssues(ctx, query, page, perPage)
+	record(a.cp, a.cp.state.SearchPages, key, result, err)
+	return result, resp, err
+}
+
+func (a checkpointAPI) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *http.Response, error) {
+	key := prKey(owner+"/"+repo, number)
+	if pr, ok := lookup(a.cp, a.cp.state.PullRequests, key); ok {
+		return pr, cachedResponse(), nil
+	}
+
+	pr, resp, err := a.GithubAPI.GetPullRequest(ctx, owner, repo, number)
+	record(a.cp, a.cp.state.PullRequests, key, pr, err)
+	return pr, resp, err
+}
+
+func (a checkpointAPI) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *http.Response, error) {
+	key := owner + "/" + repo
+	if result, ok := lookup(a.cp, a.cp.state.Repositories, key); ok {
+		return result, cachedResponse(), nil
+	}
+
+	result, resp, err := a.GithubAPI.GetRepository(ctx, owner, repo)
+	record(a.cp, a.cp.state.Repositories, key, result, err)
+	return result, resp, err
+}
+
+func lookup[T any](cp *checkpoint, results map[string]*T, key string) (*T, bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	result, ok := results[key]
+	return result, ok
+}
+
+func record[T any](cp *checkpoint, results map[string]*T, key string, result *T, err error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if err != nil {
+		cp.failed = true
+		return
+	}
+	results[key] = result
+	cp.dirty = true
+
+	// Save as the run goes, so a killed process loses little. Errors are
+	// reported by the final save.
+	if time.Since(cp.savedAt) >= checkpointInterval {
+		cp.saveLocked()
+	}
+}
@@ -0,0 +1,187 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestGetContributionsResumesFromCheckpoint(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := []github.Issue{
+		{Number: 1, RepositoryURL: "https://api.github.com/repos/owner/repo", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+		{Number: 2, RepositoryURL: "https://api.github.com/repos/owner/repo", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+	}
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	failPR2 := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		fail := failPR2 && r.URL.Path == "/repos/owner/repo/pulls/2"
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case fail:
+			w.WriteHeader(http.StatusInternalServerError)
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: 2, Items: issues})
+		case strings.Contains(r.URL.Path, "/pulls/"):
+			json.NewEncoder(w).Encode(github.PullRequest{Additions: 10, Deletions: 2, Commits: 1})
+		default:
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "run.state")
+	newClient := func() *Client {
+		client := New(WithToken("test-token"), WithLOC(true), WithCheckpoint(path))
+		client.httpClient.Transport = &mockTransport{server: server}
+		return client
+	}
+
+	// First run: PR #2 fails, so the checkpoint is kept
+	_, err := newClient().GetContributions(context.Background(), "testuser")
+	var partialErr *ErrPartialResults
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("first run error = %v, want partial results", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("checkpoint should be saved after a failed run: %v", err)
+	}
+
+	// Second run: only PR #2 is fetched again
+	mu.Lock()
+	failPR2 = false
+	clear(requests)
+	mu.Unlock()
+
+	stats, err := newClient().GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("second run error = %v", err)
+	}
+	if stats.Summary.TotalPRsMerged != 2 || stats.Summary.TotalAdditions != 20 {
+		t.Errorf("Summary = %+v, want 2 PRs and 20 additions", stats.Summary)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, want := range map[string]int{
+		"/search/issues":            0,
+		"/repos/owner/repo/pulls/1": 0,
+		"/repos/owner/repo/pulls/2": 1,
+		"/repos/owner/repo":         0,
+	} {
+		if got := requests[path]; got != want {
+			t.Errorf("requests to %s = %d, want %d", path, got, want)
+		}
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint should be removed after a complete run, stat error = %v", err)
+	}
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name string, state checkpointState) string {
+		path := filepath.Join(dir, name)
+		data, _ := json.Marshal(state)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	saved := checkpointState{
+		Version:      checkpointVersion,
+		Username:     "testuser",
+		Repositories: map[string]*github.Repository{"owner/repo": {StargazersCount: 10}},
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		cp, err := loadCheckpoint(filepath.Join(dir, "missing"), "testuser")
+		if err != nil || !cp.empty() {
+			t.Errorf("loadCheckpoint() = %v, %v, want an empty checkpoint", cp, err)
+		}
+	})
+
+	t.Run("same user", func(t *testing.T) {
+		cp, err := loadCheckpoint(write("same", saved), "testuser")
+		if err != nil {
+			t.Fatalf("loadCheckpoint() error = %v", err)
+		}
+		if repo := cp.state.Repositories["owner/repo"]; repo == nil || repo.StargazersCount != 10 {
+			t.Errorf("repository not restored: %+v", cp.state.Repositories)
+		}
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		cp, err := loadCheckpoint(write("other", saved), "someone-else")
+		if err != nil || !cp.empty() {
+			t.Errorf("loadCheckpoint() = %v, %v, want an empty checkpoint", cp, err)
+		}
+	})
+
+	t.Run("other version", func(t *testing.T) {
+		old := saved
+		old.Version = checkpointVersion + 1
+		cp, err := loadCheckpoint(write("version", old), "testuser")
+		if err != nil || !cp.empty() {
+			t.Errorf("loadCheckpoint() = %v, %v, want an empty checkpoint", cp, err)
+		}
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		path := filepath.Join(dir, "corrupt")
+		os.WriteFile(path, []byte("{not json"), 0644)
+		if _, err := loadCheckpoint(path, "testuser"); err == nil {
+			t.Error("loadCheckpoint() should fail on a corrupt file")
+		}
+	})
+}
+
+func TestCheckpointSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.state")
+	cp, err := loadCheckpoint(path, "testuser")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record(cp, cp.state.PullRequests, prKey("owner/repo", 1), &github.PullRequest{Additions: 5}, nil)
+	record(cp, cp.state.PullRequests, prKey("owner/repo", 2), nil, errors.New("boom"))
+	if err := cp.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	if cp.complete() {
+		t.Error("complete() should be false after a failed call")
+	}
+
+	loaded, err := loadCheckpoint(path, "testuser")
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if len(loaded.state.PullRequests) != 1 || loaded.state.PullRequests["owner/repo#1"].Additions != 5 {
+		t.Errorf("PullRequests = %+v, want only owner/repo#1", loaded.state.PullRequests)
+	}
+
+	// No temp files are left next to the checkpoint
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the checkpoint", len(entries))
+	}
+}
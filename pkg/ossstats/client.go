@@ -1,8 +1,13 @@
 package ossstats
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 var (
@@ -11,6 +16,7 @@ var (
 	DefaultMinStars         int           = 0
 	DefaultMaxPRS           int           = 500
 	DefaultTimeout          time.Duration = 5 * time.Minute
+	DefaultConcurrency      int           = github.DefaultConcurrency
 )
 
 // Client represents a GitHub OSS stats client.
@@ -20,20 +26,40 @@ type Client struct {
 	token string
 
 	// Configuration options
-	includeLOC       bool
-	includePRDetails bool
-	minStars         int
-	maxPRs           int
-	timeout          time.Duration
-	excludeOrgs      []string
+	includeLOC          bool
+	includePRDetails    bool
+	includeCommitCounts bool
+	minStars            int
+	maxPRs              int
+	timeout             time.Duration
+	excludeOrgs         []string
+	filters             []Filter
+	quality             *QualityFilter
+
+	// Optional contribution kinds
+	includePRStates     bool
+	includeReviews      bool
+	includeIssues       bool
+	includeClosedIssues bool
+	includeDiscussions  bool
+	includeCoAuthored   bool
+
+	// HTTP client, and the scheduler pacing its API requests
+	httpClient  *http.Client
+	baseURL     string
+	concurrency int
+	scheduler   *github.Scheduler
 
-	// HTTP client
-	httpClient *http.Client
+	// Checkpoint file for resumable runs, empty when disabled
+	checkpointPath string
 
-	// Logger
-	logger Logger
+	// Structured logger, progress events and tracing
+	logger         *slog.Logger
+	progress       *reporter
+	tracerProvider trace.TracerProvider
 
-	debug bool
+	debug         bool
+	debugScenario string
 }
 
 // New creates a new Client with the provided options.
@@ -55,8 +81,10 @@ func New(opts ...Option) *Client {
 		minStars:         DefaultMinStars,
 		maxPRs:           DefaultMaxPRS,
 		timeout:          DefaultTimeout,
+		concurrency:      DefaultConcurrency,
 		httpClient:       &http.Client{},
-		logger:           defaultLogger{},
+		logger:           discardLogger(),
+		tracerProvider:   noop.NewTracerProvider(),
 	}
 
 	// Apply all provided options
@@ -69,5 +97,15 @@ func New(opts ...Option) *Client {
 		client.httpClient.Timeout = client.timeout
 	}
 
+	// Shared by every call so concurrent fetches draw from one budget
+	onWait := client.logRateLimitWait
+	if client.progress != nil {
+		onWait = func(resource github.Resource, reset time.Time, waiting bool) {
+			client.logRateLimitWait(resource, reset, waiting)
+			client.progress.rateLimitWait(resource, reset, waiting)
+		}
+	}
+	client.scheduler = github.NewScheduler(client.concurrency, onWait)
+
 	return client
 }
@@ -1,6 +1,8 @@
 package ossstats
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
 	"testing"
 	"time"
@@ -91,7 +93,8 @@ func TestNewWithOptions(t *testing.T) {
 		t.Errorf("timeout = %v, want %v", client.timeout, timeout)
 	}
 
-	if client.logger != logger {
+	client.logger.Info("test message")
+	if len(logger.messages) != 1 {
 		t.Error("logger not set correctly")
 	}
 
@@ -178,13 +181,13 @@ func TestDefaultConstants(t *testing.T) {
 func TestNewDefaultLogger(t *testing.T) {
 	client := New()
 
-	// Should have defaultLogger
-	if _, ok := client.logger.(defaultLogger); !ok {
-		t.Error("default client should have defaultLogger")
+	// Should discard all logs
+	if client.logger.Enabled(context.Background(), slog.LevelError) {
+		t.Error("default client should discard logs")
 	}
 
 	// Should not panic when logging
-	client.logger.Printf("test message")
+	client.logger.Info("test message")
 }
 
 func TestNewMultipleOptionsSameType(t *testing.T) {
@@ -0,0 +1,212 @@
+package ossstats
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// prReference matches the PR number GitHub appends to squash commits,
+// "Fix parser (#123)", and the title of merge commits, "Merge pull request #123"
+var prReference = regexp.MustCompile(`\(#(\d+)\)|^Merge pull request #(\d+)`)
+
+// fetchCommitActivity finds commits in external repos that the user authored
+// or is named as co-author of, and returns the ones not already covered by
+// the given merged PRs. A commit is covered when it references one of the
+// PRs in its message or is one of the PRs' own commits. Rebase merges give
+// the PRs' commits new SHAs, so those are also matched by rebaseKey.
+func (c *Client) fetchCommitActivity(ctx context.Context, api github.GithubAPI, username string, prs []github.Issue) ([]activity, []error) {
+	var errors []error
+
+	c.logger.Info("Searching for authored and co-authored commits")
+	query := fmt.Sprintf("author:%s", username) + c.searchExclusions(username)
+	authored, err := c.searchAllCommits(ctx, api, query, username)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("searching authored commits: %w", err))
+	}
+
+	// The search matches the trailer text; isCoAuthor checks it names the user
+	query = fmt.Sprintf(`"co-authored-by" %s`, username) + c.searchExclusions(username)
+	coAuthored, err := c.searchAllCommits(ctx, api, query, username)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("searching co-authored commits: %w", err))
+	}
+
+	prNumbers := make(map[string]map[int]bool)
+	for _, iss := range prs {
+		owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
+		if err != nil {
+			continue
+		}
+		repoKey := owner + "/" + repo
+		if prNumbers[repoKey] == nil {
+			prNumbers[repoKey] = make(map[int]bool)
+		}
+		prNumbers[repoKey][iss.Number] = true
+	}
+
+	// Unique commits that don't reference a counted PR, grouped by repository
+	seen := make(map[string]bool)
+	candidates := make(map[string][]github.CommitSearchItem)
+	for _, item := range append(authored, coAuthored...) {
+		repoKey := item.Repository.FullName
+		if seen[item.SHA] || repoKey == "" || c.isExcludedOwner(item.Repository.Owner.Login, username) {
+			continue
+		}
+		seen[item.SHA] = true
+
+		if !isCommitAuthor(item, username) && !isCoAuthor(item.Commit.Message, username) {
+			continue
+		}
+		if number, ok := referencedPR(item.Commit.Message); ok && prNumbers[repoKey][number] {
+			continue
+		}
+		candidates[repoKey] = append(candidates[repoKey], item)
+	}
+
+	// Drop the PRs' own commits in repos that have both
+	prCommits, prErrors := c.listPRCommits(ctx, api, candidates, prNumbers)
+	errors = append(errors, prErrors...)
+
+	var activities []activity
+	for repoKey, items := range candidates {
+		owner, repo, _ := strings.Cut(repoKey, "/")
+		for _, item := range items {
+			if prCommits[item.SHA] || prCommits[rebaseKey(item.Commit)] {
+				continue
+			}
+			activities = append(activities, activity{owner: owner, repo: repo, kind: activityCommit, at: item.Commit.Author.Date})
+		}
+	}
+
+	c.logger.Info("Found commits outside merged PRs", "count", len(activities))
+	return activities, errors
+}
+
+// listPRCommits returns the SHAs and rebase keys of the merged PRs' commits
+// in the repositories that have candidate commits. When a PR's commits can't
+// be listed, every candidate in its repo is treated as covered so nothing is
+// counted twice.
+func (c *Client) listPRCommits(
+	ctx context.Context,
+	api github.GithubAPI,
+	candidates map[string][]github.CommitSearchItem,
+	prNumbers map[string]map[int]bool,
+) (map[string]bool, []error) {
+	shas := make(map[string]bool)
+	var errors []error
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+
+	for repoKey := range candidates {
+		owner, repo, _ := strings.Cut(repoKey, "/")
+		for number := range prNumbers[repoKey] {
+			wg.Add(1)
+			go func(repoKey string, number int) {
+				defer wg.Done()
+
+				commits, _, err := api.ListPullRequestCommits(ctx, owner, repo, number)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errors = append(errors, fmt.Errorf("fetching commits of PR %s#%d: %w", repoKey, number, err))
+					for _, item := range candidates[repoKey] {
+						shas[item.SHA] = true
+					}
+					return
+				}
+				for _, commit := range commits {
+					shas[commit.SHA] = true
+					shas[rebaseKey(commit.Commit)] = true
+				}
+			}(repoKey, number)
+		}
+	}
+
+	wg.Wait()
+	return shas, errors
+}
+
+// rebaseKey identifies a commit by its message and author date, which a
+// rebase merge keeps while giving the commit a new SHA
+func rebaseKey(details github.CommitDetails) string {
+	return details.Author.Date.UTC().Format(time.RFC3339) + " " + strings.TrimSpace(details.Message)
+}
+
+// searchAllCommits fetches every page of a commit search, up to the max PRs limit.
+func (c *Client) searchAllCommits(ctx context.Context, api github.GithubAPI, query, username string) ([]github.CommitSearchItem, error) {
+	var allCommits []github.CommitSearchItem
+	page := 1
+	perPage := 100
+
+	for {
+		result, resp, err := api.SearchCommits(ctx, query, page, perPage)
+		if err != nil {
+			return nil, searchError(resp, err, "searching commits")
+		}
+
+		allCommits = append(allCommits, result.Items...)
+
+		if c.maxPRs > 0 && len(allCommits) >= c.maxPRs {
+			allCommits = allCommits[:c.maxPRs]
+			c.logger.Info("Reached max PRs limit for commits", "max_prs", c.maxPRs)
+			break
+		}
+
+		if len(result.Items) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allCommits, nil
+}
+
+// isCommitAuthor reports whether the commit's linked author is the user
+func isCommitAuthor(item github.CommitSearchItem, username string) bool {
+	return item.Author != nil && strings.EqualFold(item.Author.Login, username)
+}
+
+// isCoAuthor reports whether a Co-authored-by trailer in the message names
+// the user, by login or by their GitHub noreply email
+func isCoAuthor(message, username string) bool {
+	login := strings.ToLower(username)
+	for _, line := range strings.Split(message, "\n") {
+		coAuthor, ok := cutPrefixFold(strings.TrimSpace(line), "co-authored-by:")
+		if !ok {
+			continue
+		}
+		name, email, _ := strings.Cut(strings.ToLower(coAuthor), "<")
+		email = strings.TrimSuffix(strings.TrimSpace(email), ">")
+		if strings.TrimSpace(name) == login ||
+			email == login+"@users.noreply.github.com" ||
+			strings.HasSuffix(email, "+"+login+"@users.noreply.github.com") {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedPR returns the PR number a squash or merge commit message refers to
+func referencedPR(message string) (int, bool) {
+	subject, _, _ := strings.Cut(message, "\n")
+	match := prReference.FindStringSubmatch(subject)
+	if match == nil {
+		return 0, false
+	}
+	digits := match[1]
+	if digits == "" {
+		digits = match[2]
+	}
+	number, err := strconv.Atoi(digits)
+	return number, err == nil
+}
@@ -0,0 +1,287 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestIsCoAuthor(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"Fix parser\n\nCo-authored-by: testuser <testuser@example.com>", true},
+		{"Fix parser\n\nco-authored-by: Test User <12345+TestUser@users.noreply.github.com>", true},
+		{"Fix parser\n\nCo-authored-by: Test User <testuser@users.noreply.github.com>", true},
+		{"Fix parser\n\nCo-authored-by: testuser2 <1+testuser2@users.noreply.github.com>", false},
+		{"Thanks testuser for the co-authored-by idea", false},
+	}
+
+	for _, tt := range tests {
+		if got := isCoAuthor(tt.message, "testuser"); got != tt.want {
+			t.Errorf("isCoAuthor(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestReferencedPR(t *testing.T) {
+	tests := []struct {
+		message string
+		want    int
+		ok      bool
+	}{
+		{"Fix parser (#123)", 123, true},
+		{"Merge pull request #45 from testuser/fix", 45, true},
+		{"Fix parser\n\nSee (#9)", 0, false},
+		{"Fix parser", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := referencedPR(tt.message)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("referencedPR(%q) = %d, %v, want %d, %v", tt.message, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGetContributionsWithCoAuthoredCommits(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(sha, repo, message, login string) github.CommitSearchItem {
+		owner, name, _ := strings.Cut(repo, "/")
+		item := github.CommitSearchItem{
+			SHA:        sha,
+			Commit:     github.CommitDetails{Message: message, Author: github.CommitAuthor{Date: at}},
+			Repository: github.Repository{Name: name, FullName: repo, Owner: github.User{Login: owner}},
+		}
+		if login != "" {
+			item.Author = &github.User{Login: login}
+		}
+		return item
+	}
+
+	var commitQueries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        7,
+					RepositoryURL: "https://api.github.com/repos/owner/repo",
+					PullRequest:   &github.PullRequestRef{MergedAt: &at},
+				}},
+			})
+
+		case strings.HasPrefix(r.URL.Path, "/search/commits"):
+			q := r.URL.Query().Get("q")
+			commitQueries = append(commitQueries, q)
+
+			var items []github.CommitSearchItem
+			if strings.HasPrefix(q, "author:") {
+				items = []github.CommitSearchItem{
+					commit("pr-commit", "owner/repo", "Add retries", "testuser"),        // Part of PR #7
+					commit("squash", "owner/repo", "Add retries (#7)", "testuser"),      // Squash of PR #7
+					commit("cherry-pick", "owner/repo", "Backport retries", "testuser"), // Landed outside the PR
+					commit("shared", "other/tool", "Fix build\n\nCo-authored-by: testuser <1+testuser@users.noreply.github.com>", "testuser"),
+				}
+			} else {
+				items = []github.CommitSearchItem{
+					commit("shared", "other/tool", "Fix build\n\nCo-authored-by: testuser <1+testuser@users.noreply.github.com>", "testuser"),
+					commit("co-authored", "other/tool", "Add docs\n\nCo-authored-by: Test User <1+testuser@users.noreply.github.com>", "maintainer"),
+					commit("mention", "other/tool", "Add docs\n\nCo-authored-by: testuser2 <testuser2@example.com>", "maintainer"),
+				}
+			}
+			json.NewEncoder(w).Encode(github.SearchCommitsResponse{TotalCount: len(items), Items: items})
+
+		case r.URL.Path == "/graphql":
+			w.Write([]byte(`{"data":{"search":{"pageInfo":{"hasNextPage":false},"nodes":[
+				{"number":7,"repository":{"nameWithOwner":"owner/repo"},"commits":{"totalCount":1}}
+			]}}}`))
+
+		case strings.HasSuffix(r.URL.Path, "/pulls/7/commits"):
+			json.NewEncoder(w).Encode([]github.Commit{{SHA: "pr-commit"}})
+
+		case strings.HasPrefix(r.URL.Path, "/repos/"):
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithCommitCounts(true), WithCoAuthoredCommits(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(commitQueries) != 2 {
+		t.Errorf("commit searches = %d, want 2 (authored, co-authored)", len(commitQueries))
+	}
+	for _, q := range commitQueries {
+		if !strings.Contains(q, "-user:testuser") {
+			t.Errorf("query %q should exclude the user's own repos", q)
+		}
+	}
+
+	commits := make(map[string]*int)
+	for _, contrib := range stats.Contributions {
+		commits[contrib.Repo] = contrib.Commits
+	}
+	// 1 commit in PR #7, plus the cherry-pick
+	if n := commits["owner/repo"]; n == nil || *n != 2 {
+		t.Errorf("owner/repo commits = %s, want 2", fmtInt(n))
+	}
+	// The shared commit is found by both searches but counted once
+	if n := commits["other/tool"]; n == nil || *n != 2 {
+		t.Errorf("other/tool commits = %s, want 2", fmtInt(n))
+	}
+	if n := stats.Summary.TotalCommits; n == nil || *n != 4 || stats.Summary.TotalPRsMerged != 1 {
+		t.Errorf("Summary = %+v", stats.Summary)
+	}
+}
+
+func TestGetContributionsWithRebaseMergedCommits(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	details := func(message string, date time.Time) github.CommitDetails {
+		return github.CommitDetails{Message: message, Author: github.CommitAuthor{Date: date}}
+	}
+	commit := func(sha string, details github.CommitDetails) github.CommitSearchItem {
+		return github.CommitSearchItem{
+			SHA:        sha,
+			Commit:     details,
+			Author:     &github.User{Login: "testuser"},
+			Repository: github.Repository{Name: "repo", FullName: "owner/repo", Owner: github.User{Login: "owner"}},
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        7,
+					RepositoryURL: "https://api.github.com/repos/owner/repo",
+					PullRequest:   &github.PullRequestRef{MergedAt: &at},
+				}},
+			})
+
+		case strings.HasPrefix(r.URL.Path, "/search/commits"):
+			// PR #7 was rebase-merged: its commits landed with new SHAs and
+			// no PR reference, but kept their messages and author dates
+			items := []github.CommitSearchItem{
+				commit("rebased-1", details("Add retries", at)),
+				commit("rebased-2", details("Test retries\n", at.Add(time.Hour))),
+				commit("unrelated", details("Add retries", at.Add(48*time.Hour))), // Same message, other commit
+			}
+			json.NewEncoder(w).Encode(github.SearchCommitsResponse{TotalCount: len(items), Items: items})
+
+		case r.URL.Path == "/graphql":
+			w.Write([]byte(`{"data":{"search":{"pageInfo":{"hasNextPage":false},"nodes":[
+				{"number":7,"repository":{"nameWithOwner":"owner/repo"},"commits":{"totalCount":2}}
+			]}}}`))
+
+		case strings.HasSuffix(r.URL.Path, "/pulls/7/commits"):
+			json.NewEncoder(w).Encode([]github.Commit{
+				{SHA: "head-1", Commit: details("Add retries", at)},
+				{SHA: "head-2", Commit: details("Test retries", at.Add(time.Hour).In(time.FixedZone("CET", 3600)))},
+			})
+
+		case strings.HasPrefix(r.URL.Path, "/repos/"):
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithCommitCounts(true), WithCoAuthoredCommits(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 2 commits in PR #7, plus the unrelated commit
+	if n := stats.Summary.TotalCommits; n == nil || *n != 3 {
+		t.Errorf("TotalCommits = %s, want 3", fmtInt(n))
+	}
+}
+
+func TestGetContributionsWithCommitCounts(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	pr := func(number int) github.Issue {
+		return github.Issue{
+			Number:        number,
+			RepositoryURL: "https://api.github.com/repos/owner/repo",
+			PullRequest:   &github.PullRequestRef{MergedAt: &at},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		nodes        string
+		wantCommits  int
+		wantAccuracy string
+	}{
+		{"all counted", `{"number":1,"repository":{"nameWithOwner":"owner/repo"},"commits":{"totalCount":3}},
+			{"number":2,"repository":{"nameWithOwner":"owner/repo"},"commits":{"totalCount":4}}`, 7, AccuracyExact},
+		// PR #2 is missing from the GraphQL search, so it is assumed to have 1 commit
+		{"partly counted", `{"number":1,"repository":{"nameWithOwner":"owner/repo"},"commits":{"totalCount":3}}`, 4, AccuracyEstimated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/search/issues"):
+					json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: 2, Items: []github.Issue{pr(1), pr(2)}})
+				case r.URL.Path == "/graphql":
+					w.Write([]byte(`{"data":{"search":{"pageInfo":{"hasNextPage":false},"nodes":[` + tt.nodes + `]}}}`))
+				case strings.Contains(r.URL.Path, "/pulls/"):
+					t.Errorf("PR details should not be fetched for commit counts: %s", r.URL.Path)
+				default:
+					json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+				}
+			}))
+			defer server.Close()
+
+			client := New(WithToken("test-token"), WithCommitCounts(true))
+			client.httpClient.Transport = &mockTransport{server: server}
+
+			stats, err := client.GetContributions(context.Background(), "testuser")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if n := stats.Summary.TotalCommits; n == nil || *n != tt.wantCommits {
+				t.Errorf("TotalCommits = %s, want %d", fmtInt(n), tt.wantCommits)
+			}
+			if stats.Summary.Accuracy.Commits != tt.wantAccuracy {
+				t.Errorf("Accuracy.Commits = %s, want %s", stats.Summary.Accuracy.Commits, tt.wantAccuracy)
+			}
+			if stats.Summary.Accuracy.Lines != AccuracyUnknown {
+				t.Errorf("Accuracy.Lines = %s, want %s", stats.Summary.Accuracy.Lines, AccuracyUnknown)
+			}
+		})
+	}
+}
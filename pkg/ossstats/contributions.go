@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mabd-dev/gh-oss-stats/internal/github"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // GetContributions fetches and aggregates a user's open source contributions
@@ -16,52 +19,159 @@ import (
 //
 // Returns Stats containing the aggregated contribution data, or an error.
 // If rate limiting occurs mid-fetch, returns ErrPartialResults with whatever
-// data was collected before the rate limit.
-func (c *Client) GetContributions(ctx context.Context, username string) (*Stats, error) {
+// data was collected before the rate limit. The user is looked up first, so
+// an unknown user fails with ErrUserNotFound before any search. Other
+// failures are returned as ErrAuthentication, ErrForbidden, ErrRateLimited
+// or ErrSecondaryRateLimit when they have one of these causes.
+func (c *Client) GetContributions(ctx context.Context, username string) (stats *Stats, err error) {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	c.logger.Printf("Fetching contributions for user: %s", username)
+	ctx, span := c.startSpan(ctx, "ossstats.GetContributions", attribute.String("github.user", username))
+	defer func() { endSpan(span, err) }()
 
-	// Initialize GitHub API client
-	var apiClient github.GithubAPI
-	if c.debug {
-		c.logger.Printf("DEBUG MODE: Using mock API client")
-		apiClient = github.NewMockAPIClient()
-	} else {
-		apiClient = github.NewAPIClient(c.httpClient, c.token)
+	ctx = c.withRunProgress(ctx, username)
+	defer startPhase(ctx, PhaseDone, 0)
+
+	c.logger.Info("Fetching contributions", "user", username)
+
+	for _, filter := range c.filters {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var quality *qualityChecker
+	if c.quality != nil {
+		if quality, err = newQualityChecker(*c.quality); err != nil {
+			return nil, err
+		}
+	}
+
+	apiClient, err := c.apiClient(username)
+	if err != nil {
+		return nil, err
+	}
+	if c.checkpointPath == "" {
+		return c.fetchContributions(ctx, apiClient, username, quality)
+	}
+
+	cp, err := loadCheckpoint(c.checkpointPath, username)
+	if err != nil {
+		return nil, err
+	}
+	if !cp.empty() {
+		c.logger.Info("Resuming from checkpoint", "path", c.checkpointPath, "state", cp.summary())
+	}
+
+	stats, err = c.fetchContributions(ctx, checkpointAPI{GithubAPI: apiClient, cp: cp}, username, quality)
+	c.closeCheckpoint(cp, err)
+	return stats, err
+}
+
+// closeCheckpoint removes the checkpoint after a complete run, and saves it
+// for the next run to resume from otherwise.
+func (c *Client) closeCheckpoint(cp *checkpoint, runErr error) {
+	if runErr == nil && cp.complete() {
+		if err := cp.remove(); err != nil {
+			c.logger.Error("Failed to remove checkpoint", "path", cp.path, "error", err)
+		}
+		return
+	}
+
+	if err := cp.save(); err != nil {
+		c.logger.Error("Failed to save checkpoint", "path", cp.path, "error", err)
+		return
+	}
+	c.logger.Info("Saved checkpoint", "path", cp.path, "state", cp.summary())
+}
+
+// fetchContributions runs the fetch steps of GetContributions.
+func (c *Client) fetchContributions(ctx context.Context, apiClient github.GithubAPI, username string, quality *qualityChecker) (*Stats, error) {
+	startPhase(ctx, PhaseSearch, 0)
+	if err := checkUser(ctx, apiClient, username); err != nil {
+		return nil, err
 	}
 
 	// Step 1: Search for merged PRs to external repos
-	c.logger.Printf("Searching for merged PRs...")
+	c.logger.Info("Searching for merged PRs")
 	issues, err := c.searchMergedPRs(ctx, apiClient, username)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(issues) == 0 {
-		c.logger.Printf("No contributions found")
+	// Commit counts come with the PR details when LOC is enabled, or from
+	// one GraphQL search per 100 PRs otherwise
+	var commitCounts map[string]int
+	var commitsEstimated bool
+	var countErrors []error
+	if !c.includeLOC && c.includeCommitCounts && len(issues) > 0 {
+		c.logger.Info("Fetching commit counts")
+		startPhase(ctx, PhaseCommitCounts, len(issues))
+		commitCounts, commitsEstimated, err = c.fetchCommitCounts(ctx, apiClient, username, issues)
+		if err != nil {
+			countErrors = append(countErrors, err)
+		}
+	}
+
+	// Optional contribution kinds
+	if c.includePRStates || c.includeReviews || c.includeIssues || c.includeDiscussions || c.includeCoAuthored {
+		startPhase(ctx, PhaseActivity, 0)
+	}
+	activities, activityErrors := c.fetchActivity(ctx, apiClient, username)
+
+	if c.includeCoAuthored {
+		commits, commitErrors := c.fetchCommitActivity(ctx, apiClient, username, issues)
+		activities = append(activities, commits...)
+		activityErrors = append(activityErrors, commitErrors...)
+	}
+
+	if len(issues) == 0 && len(activities) == 0 && len(activityErrors) == 0 {
+		c.logger.Info("No contributions found", "user", username)
 		return &Stats{
 			Username:      username,
 			GeneratedAt:   time.Now().UTC(),
-			Summary:       Summary{},
+			Summary:       c.calculateSummary(nil),
 			Contributions: []Contribution{},
 		}, nil
 	}
 
-	c.logger.Printf("Found %d merged PRs", len(issues))
+	c.logger.Info("Found merged PRs", "count", len(issues))
 
 	// Step 2: Fetch PR details and aggregate by repository
-	c.logger.Printf("Fetching PR details...")
-	contributions, errors := c.fetchPRDetails(ctx, apiClient, issues)
+	c.logger.Info("Fetching PR details")
+	startPhase(ctx, PhasePRDetails, len(issues))
+	contributions, excluded, errors := c.fetchPRDetails(ctx, apiClient, issues, quality, commitCounts)
+	if len(excluded) > 0 {
+		c.logger.Info("Excluded PRs by quality filter", "count", len(excluded))
+	}
+
+	contributions = mergeActivity(contributions, activities)
+	errors = append(errors, countErrors...)
+	errors = append(errors, activityErrors...)
+
+	if c.includePRStates {
+		for i := range contributions {
+			contributions[i].AcceptanceRate = acceptanceRate(contributions[i].PRsMerged, contributions[i].PRsClosedUnmerged)
+		}
+	}
+
+	// Drop repos excluded by name, owner or PR count before fetching their metadata
+	contributions = c.applyRepoFilters(contributions)
 
 	// Step 3: Fetch repository metadata
-	c.logger.Printf("Fetching repository metadata...")
-	contributions = c.enrichWithRepoData(ctx, apiClient, contributions)
+	c.logger.Info("Fetching repository metadata")
+	startPhase(ctx, PhaseRepoMetadata, len(contributions))
+	contributions, noMetadata := c.enrichWithRepoData(ctx, apiClient, contributions)
+	for _, contrib := range contributions {
+		if err, ok := noMetadata[contrib.Repo]; ok {
+			errors = append(errors, err)
+		}
+	}
 
 	// Step 4: Apply filters
-	contributions = c.applyFilters(contributions)
+	contributions = c.applyFilters(contributions, noMetadata)
 
 	slices.SortFunc(contributions, func(a, b Contribution) int {
 		if a.FirstContribution.Before(b.FirstContribution) {
@@ -75,17 +185,22 @@ func (c *Client) GetContributions(ctx context.Context, username string) (*Stats,
 
 	// Step 5: Calculate summary
 	summary := c.calculateSummary(contributions)
+	summary.TotalExcluded = len(excluded)
+	if commitsEstimated && summary.TotalCommits != nil {
+		summary.Accuracy.Commits = AccuracyEstimated
+	}
 
 	stats := &Stats{
 		Username:      username,
 		GeneratedAt:   time.Now().UTC(),
 		Summary:       summary,
 		Contributions: contributions,
+		ExcludedPRs:   excluded,
 	}
 
 	// If there were errors during fetching, return partial results
 	if len(errors) > 0 {
-		c.logger.Printf("Completed with %d errors", len(errors))
+		c.logger.Warn("Completed with errors", "errors", len(errors))
 		return stats, &ErrPartialResults{
 			Stats:   stats,
 			Errors:  errors,
@@ -93,14 +208,86 @@ func (c *Client) GetContributions(ctx context.Context, username string) (*Stats,
 		}
 	}
 
-	c.logger.Printf("Successfully fetched %d contributions", len(contributions))
+	c.logger.Info("Successfully fetched contributions", "count", len(contributions))
 	return stats, nil
 }
 
+// apiClient returns the GitHub API client for fetching username's
+// contributions. In debug mode it serves the debug scenario instead.
+func (c *Client) apiClient(username string) (github.GithubAPI, error) {
+	httpClient := c.httpClient
+	if c.debug {
+		transport, err := c.debugTransport(username)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+	api := github.NewAPIClient(loggingHTTPClient(httpClient, c.logger), c.token, c.scheduler)
+	api.SetTracerProvider(c.tracerProvider)
+	switch {
+	case c.baseURL != "":
+		api.SetBaseURL(c.baseURL)
+	case c.debug:
+		api.SetBaseURL(debugBaseURL)
+	}
+	return api, nil
+}
+
 // searchMergedPRs searches for all merged PRs authored by the user to external repos.
-func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, username string) ([]github.Issue, error) {
-	// Build search query: merged PRs by user, excluding their own repos
-	query := fmt.Sprintf("author:%s type:pr is:merged -user:%s", username, username)
+func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, username string) (issues []github.Issue, err error) {
+	ctx, span := c.startSpan(ctx, "ossstats.searchMergedPRs")
+	defer func() {
+		span.SetAttributes(attribute.Int("ossstats.prs", len(issues)))
+		endSpan(span, err)
+	}()
+
+	return c.searchAll(ctx, api, c.mergedPRsQuery(username), username)
+}
+
+// mergedPRsQuery returns the search query for merged PRs by the user,
+// excluding their own repos
+func (c *Client) mergedPRsQuery(username string) string {
+	return fmt.Sprintf("author:%s type:pr is:merged", username) + c.searchExclusions(username)
+}
+
+// fetchCommitCounts returns the commit count of each merged PR, keyed by
+// prKey, and whether any of the issues is missing from the result. The
+// GraphQL search can lag behind the REST one.
+func (c *Client) fetchCommitCounts(ctx context.Context, api github.GithubAPI, username string, issues []github.Issue) (map[string]int, bool, error) {
+	counts, _, err := api.SearchPullRequestCommitCounts(ctx, c.mergedPRsQuery(username), c.maxPRs)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetching commit counts: %w", err)
+	}
+
+	byPR := make(map[string]int, len(counts))
+	for _, count := range counts {
+		byPR[prKey(count.Repository.NameWithOwner, count.Number)] = count.Commits.TotalCount
+	}
+
+	missing := false
+	for _, iss := range issues {
+		owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
+		if err != nil {
+			continue
+		}
+		if _, ok := byPR[prKey(owner+"/"+repo, iss.Number)]; !ok {
+			missing = true
+		}
+	}
+
+	return byPR, missing, nil
+}
+
+// prKey identifies a PR across repositories, e.g. "owner/repo#123"
+func prKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+// searchExclusions returns the search qualifiers that leave out the user's
+// own repos, excluded organizations and excluded owners.
+func (c *Client) searchExclusions(username string) string {
+	query := fmt.Sprintf(" -user:%s", username)
 
 	// Exclude specified organizations
 	for _, org := range c.excludeOrgs {
@@ -109,45 +296,42 @@ func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, user
 		}
 	}
 
+	// Excluded owners can be dropped by the search itself
+	for _, filter := range c.filters {
+		for _, owner := range filter.ExcludeOwners {
+			if owner != "" {
+				query += fmt.Sprintf(" -user:%s", owner)
+			}
+		}
+	}
+
+	return query
+}
+
+// searchAll fetches every page of an issue search, up to the max PRs limit.
+func (c *Client) searchAll(ctx context.Context, api github.GithubAPI, query, username string) ([]github.Issue, error) {
 	var allIssues []github.Issue
 	page := 1
 	perPage := 100
 
 	for {
-		// Respect search API rate limits
-		if page > 1 {
-			if err := github.WaitForSearchAPI(ctx); err != nil {
-				return nil, fmt.Errorf("waiting for search API: %w", err)
-			}
-		}
-
 		result, resp, err := api.SearchIssues(ctx, query, page, perPage)
 		if err != nil {
-			if github.IsRateLimited(resp) {
-				resetTime := time.Now().Add(time.Minute)
-				if info, err := github.ParseRateLimitHeaders(resp.Header); err == nil {
-					resetTime = info.Reset
-				}
-				return nil, &ErrRateLimited{
-					ResetAt: resetTime,
-					Message: "search API rate limit exceeded",
-				}
-			}
-			if resp != nil && resp.StatusCode == http.StatusUnauthorized {
-				return nil, &ErrAuthentication{Message: "invalid or missing token"}
-			}
-			if resp != nil && resp.StatusCode == http.StatusNotFound {
-				return nil, &ErrNotFound{Username: username}
-			}
-			return nil, fmt.Errorf("searching issues: %w", err)
+			return nil, searchError(resp, err, "searching issues")
 		}
 
 		allIssues = append(allIssues, result.Items...)
 
+		total := result.TotalCount
+		if c.maxPRs > 0 {
+			total = min(total, c.maxPRs)
+		}
+		report(ctx, Event{Kind: EventSearchPage, Page: page, Done: min(len(allIssues), total), Total: total})
+
 		// Check if we've hit the max PRs limit
 		if c.maxPRs > 0 && len(allIssues) >= c.maxPRs {
 			allIssues = allIssues[:c.maxPRs]
-			c.logger.Printf("Reached max PRs limit (%d)", c.maxPRs)
+			c.logger.Info("Reached max PRs limit", "max_prs", c.maxPRs)
 			break
 		}
 
@@ -162,79 +346,201 @@ func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, user
 	return allIssues, nil
 }
 
+// checkUser looks the user up with /users/{username}. GitHub rejects
+// searches for unknown authors like malformed queries (422, or 404), so the
+// lookup is what tells a missing user apart, as ErrUserNotFound.
+func checkUser(ctx context.Context, api github.GithubAPI, username string) error {
+	_, resp, err := api.GetUser(ctx, username)
+	if err == nil {
+		return nil
+	}
+	if typed, ok := classifyError(resp, err); ok {
+		return typed
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return &ErrUserNotFound{Username: username}
+	}
+	return fmt.Errorf("looking up user: %w", err)
+}
+
+// searchError turns a failed search into the error returned to the caller
+func searchError(resp *http.Response, err error, action string) error {
+	if typed, ok := classifyError(resp, err); ok {
+		return typed
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// classifyError returns the typed error for a request that failed with resp
+// and err, wrapping err: ErrRateLimited, ErrSecondaryRateLimit,
+// ErrAuthentication or ErrForbidden. It returns false for other failures.
+func classifyError(resp *http.Response, err error) (error, bool) {
+	switch {
+	case resp == nil:
+		return nil, false
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &ErrAuthentication{Message: "invalid or missing token", Err: err}, true
+	case github.IsPrimaryRateLimited(resp):
+		info, _ := github.ParseRateLimitHeaders(resp.Header)
+		message := "API rate limit exceeded"
+		if resource := resp.Header.Get(github.RateLimitResourceHeader); resource != "" {
+			message = resource + " " + message
+		}
+		return &ErrRateLimited{ResetAt: info.Reset, Message: message, Err: err}, true
+	case github.IsSecondaryRateLimited(resp, err):
+		retryAfter := github.RetryAfter(resp)
+		if retryAfter == 0 {
+			retryAfter = time.Minute
+		}
+		return &ErrSecondaryRateLimit{RetryAfter: retryAfter, Err: err}, true
+	case resp.StatusCode == http.StatusForbidden:
+		return &ErrForbidden{Err: err}, true
+	}
+	return nil, false
+}
+
 // fetchPRDetails fetches detailed information for each PR and aggregates by repository.
-func (c *Client) fetchPRDetails(ctx context.Context, api github.GithubAPI, issues []github.Issue) ([]Contribution, []error) {
+// PRs rejected by the quality checker (if any) are returned separately.
+// Commit counts are taken from the PR details with LOC enabled, otherwise
+// from commitCounts; without either they are left unknown.
+func (c *Client) fetchPRDetails(
+	ctx context.Context,
+	api github.GithubAPI,
+	issues []github.Issue,
+	quality *qualityChecker,
+	commitCounts map[string]int,
+) ([]Contribution, []ExcludedPR, []error) {
+	ctx, span := c.startSpan(ctx, "ossstats.fetchPRDetails", attribute.Int("ossstats.prs", len(issues)))
+	defer span.End()
+
 	// Map to aggregate PRs by repository
 	repoMap := make(map[string]*Contribution)
+	var excluded []ExcludedPR
 	var mu sync.Mutex
 	var errors []error
 
-	// Process PRs with limited concurrency
-	semaphore := make(chan struct{}, 5) // Limit to 5 concurrent requests
-	var wg sync.WaitGroup
+	fetchDetails := c.includeLOC || (quality != nil && quality.filter.needsPRDetails())
 
-	for _, issue := range issues {
-		// Skip if not a PR
-		if issue.PullRequest == nil {
-			continue
-		}
+	// Only merged PRs are processed
+	merged := slices.DeleteFunc(slices.Clone(issues), func(iss github.Issue) bool {
+		return iss.PullRequest == nil || iss.PullRequest.MergedAt == nil
+	})
+	var processed atomic.Int64
 
-		// Skip if not merged
-		if issue.PullRequest.MergedAt == nil {
-			continue
-		}
+	// Process PRs concurrently, the API client's scheduler limits the requests in flight
+	var wg sync.WaitGroup
 
+	for _, issue := range merged {
 		wg.Add(1)
 		go func(iss github.Issue) {
 			defer wg.Done()
 
-			// Acquire semaphore
-			select {
-			case semaphore <- struct{}{}:
-				defer func() { <-semaphore }()
-			case <-ctx.Done():
-				return
-			}
+			var repoKey string
+			var failure error
+			start := time.Now()
+			defer func() {
+				kind := EventPRFetched
+				if failure != nil {
+					kind = EventPRFailed
+					c.logger.Warn("Failed to process PR", "repo", repoKey, "pr", iss.Number, "error", failure)
+				} else {
+					c.logger.Debug("Processed PR", "repo", repoKey, "pr", iss.Number, "duration", time.Since(start))
+				}
+				done := int(processed.Add(1))
+				report(ctx, Event{Kind: kind, Repo: repoKey, PR: iss.Number, Done: done, Total: len(merged), Err: failure})
+			}()
 
 			// Parse repository URL
 			owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
 			if err != nil {
+				failure = fmt.Errorf("parsing repo URL: %w", err)
 				mu.Lock()
-				errors = append(errors, fmt.Errorf("parsing repo URL: %w", err))
+				errors = append(errors, failure)
 				mu.Unlock()
 				return
 			}
+			repoKey = owner + "/" + repo
 
-			// Fetch PR details if LOC is enabled
-			var additions, deletions, commits int
-			if c.includeLOC {
-				pr, resp, err := api.GetPullRequest(ctx, owner, repo, iss.Number)
+			// Fetch PR details if LOC is enabled or the quality filter needs them
+			var pr *github.PullRequest
+			if fetchDetails {
+				var resp *http.Response
+				pr, resp, err = api.GetPullRequest(ctx, owner, repo, iss.Number)
 				if err != nil {
+					failure = err
 					if !github.IsRateLimited(resp) {
 						mu.Lock()
+						if typed, ok := classifyError(resp, err); ok {
+							err = typed
+						}
 						errors = append(errors, fmt.Errorf("fetching PR %s/%s#%d: %w", owner, repo, iss.Number, err))
 						mu.Unlock()
 					}
 					return
 				}
+			}
+
+			var additions, deletions, commits int
+			knownCommits := true
+			switch {
+			case c.includeLOC:
 				additions = pr.Additions
 				deletions = pr.Deletions
 				commits = pr.Commits
-			} else {
-				commits = 1 // Default to 1 commit per PR if not fetching details
+			case commitCounts != nil:
+				// PRs missing from the count search are assumed to have 1 commit
+				if commits = commitCounts[prKey(repoKey, iss.Number)]; commits == 0 {
+					commits = 1
+				}
+			default:
+				knownCommits = false
+			}
+
+			// Drop trivial and bot-assisted PRs, keeping them when the check fails
+			if quality != nil {
+				reason, err := quality.check(ctx, api, owner, repo, iss, pr)
+				if err != nil {
+					mu.Lock()
+					errors = append(errors, err)
+					mu.Unlock()
+				}
+				if reason != "" {
+					mu.Lock()
+					excluded = append(excluded, ExcludedPR{
+						Repo:   repoKey,
+						Number: iss.Number,
+						Title:  iss.Title,
+						URL:    iss.HTMLURL,
+						Reason: reason,
+					})
+					mu.Unlock()
+					return
+				}
+			}
+
+			var issuesClosed int
+			if c.includeClosedIssues {
+				issuesClosed, _, err = api.CountClosingIssues(ctx, owner, repo, iss.Number)
+				if err != nil {
+					mu.Lock()
+					errors = append(errors, fmt.Errorf("counting issues closed by PR %s/%s#%d: %w", owner, repo, iss.Number, err))
+					mu.Unlock()
+				}
 			}
 
 			// Aggregate by repository
-			repoKey := owner + "/" + repo
 			mu.Lock()
 			defer mu.Unlock()
 
 			if contrib, exists := repoMap[repoKey]; exists {
 				// Update existing contribution
 				contrib.PRsMerged++
-				contrib.Commits += commits
+				if knownCommits {
+					contrib.addCommits(commits)
+				}
 				contrib.Additions += additions
 				contrib.Deletions += deletions
+				contrib.IssuesClosed += issuesClosed
 
 				// Update first/last contribution times
 				mergedAt := *iss.PullRequest.MergedAt
@@ -246,17 +552,21 @@ func (c *Client) fetchPRDetails(ctx context.Context, api github.GithubAPI, issue
 				}
 			} else {
 				// Create new contribution entry
-				repoMap[repoKey] = &Contribution{
+				contrib := &Contribution{
 					Repo:              repoKey,
 					Owner:             owner,
 					RepoName:          repo,
 					PRsMerged:         1,
-					Commits:           commits,
 					Additions:         additions,
 					Deletions:         deletions,
+					IssuesClosed:      issuesClosed,
 					FirstContribution: *iss.PullRequest.MergedAt,
 					LastContribution:  *iss.PullRequest.MergedAt,
 				}
+				if knownCommits {
+					contrib.addCommits(commits)
+				}
+				repoMap[repoKey] = contrib
 			}
 		}(issue)
 	}
@@ -269,53 +579,86 @@ func (c *Client) fetchPRDetails(ctx context.Context, api github.GithubAPI, issue
 		contributions = append(contributions, *contrib)
 	}
 
-	return contributions, errors
+	// Goroutines finish in any order
+	slices.SortFunc(excluded, func(a, b ExcludedPR) int {
+		if a.Repo != b.Repo {
+			return strings.Compare(a.Repo, b.Repo)
+		}
+		return a.Number - b.Number
+	})
+
+	span.SetAttributes(
+		attribute.Int("ossstats.repos", len(contributions)),
+		attribute.Int("ossstats.excluded", len(excluded)),
+		attribute.Int("ossstats.errors", len(errors)),
+	)
+	return contributions, excluded, errors
 }
 
 // enrichWithRepoData fetches repository metadata and enriches contributions.
-func (c *Client) enrichWithRepoData(ctx context.Context, api github.GithubAPI, contributions []Contribution) []Contribution {
+// It returns the errors of the repos whose metadata couldn't be fetched, by
+// repo name.
+func (c *Client) enrichWithRepoData(ctx context.Context, api github.GithubAPI, contributions []Contribution) ([]Contribution, map[string]error) {
+	ctx, span := c.startSpan(ctx, "ossstats.enrichWithRepoData", attribute.Int("ossstats.repos", len(contributions)))
+	defer span.End()
+
 	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 5) // Limit concurrent requests
+	var enriched atomic.Int64
+	var mu sync.Mutex
+	failed := make(map[string]error)
 
 	for i := range contributions {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
 
-			// Acquire semaphore
-			select {
-			case semaphore <- struct{}{}:
-				defer func() { <-semaphore }()
-			case <-ctx.Done():
-				return
-			}
-
 			contrib := &contributions[idx]
 			repo, _, err := api.GetRepository(ctx, contrib.Owner, contrib.RepoName)
+			done := int(enriched.Add(1))
+			report(ctx, Event{Kind: EventRepoEnriched, Repo: contrib.Repo, Done: done, Total: len(contributions), Err: err})
 			if err != nil {
-				c.logger.Printf("Failed to fetch repo %s: %v", contrib.Repo, err)
+				c.logger.Warn("Failed to fetch repo", "repo", contrib.Repo, "error", err)
+				mu.Lock()
+				failed[contrib.Repo] = fmt.Errorf("fetching repo %s: %w", contrib.Repo, err)
+				mu.Unlock()
 				return
 			}
 
 			contrib.Description = repo.Description
 			contrib.RepoURL = repo.HTMLURL
 			contrib.Stars = repo.StargazersCount
+			contrib.Language = repo.Language
+			contrib.Topics = repo.Topics
+			contrib.Archived = repo.Archived
+			contrib.Fork = repo.Fork
 		}(i)
 	}
 
 	wg.Wait()
-	return contributions
+	span.SetAttributes(attribute.Int("ossstats.errors", len(failed)))
+	return contributions, failed
 }
 
-// applyFilters applies client filters to contributions.
-func (c *Client) applyFilters(contributions []Contribution) []Contribution {
-	if c.minStars == 0 {
+// applyFilters applies client filters to contributions. Repos in
+// noMetadata, whose metadata couldn't be fetched, are kept rather than
+// dropped by rules they can't be checked against (stars, language, topics,
+// archived, fork).
+func (c *Client) applyFilters(contributions []Contribution, noMetadata map[string]error) []Contribution {
+	if c.minStars == 0 && len(c.filters) == 0 {
 		return contributions
 	}
 
 	filtered := make([]Contribution, 0, len(contributions))
 	for _, contrib := range contributions {
-		if contrib.Stars >= c.minStars {
+		_, unknown := noMetadata[contrib.Repo]
+		if !unknown && contrib.Stars < c.minStars {
+			continue
+		}
+		match := Filter.Match
+		if unknown {
+			match = Filter.matchRepo
+		}
+		if !slices.ContainsFunc(c.filters, func(f Filter) bool { return !match(f, contrib) }) {
 			filtered = append(filtered, contrib)
 		}
 	}
@@ -323,17 +666,66 @@ func (c *Client) applyFilters(contributions []Contribution) []Contribution {
 	return filtered
 }
 
+// applyRepoFilters applies the filter rules that don't need repository metadata.
+func (c *Client) applyRepoFilters(contributions []Contribution) []Contribution {
+	if len(c.filters) == 0 {
+		return contributions
+	}
+
+	filtered := make([]Contribution, 0, len(contributions))
+	for _, contrib := range contributions {
+		if !slices.ContainsFunc(c.filters, func(f Filter) bool { return !f.matchRepo(contrib) }) {
+			filtered = append(filtered, contrib)
+		}
+	}
+
+	if dropped := len(contributions) - len(filtered); dropped > 0 {
+		c.logger.Info("Filtered out repositories", "count", dropped)
+	}
+
+	return filtered
+}
+
 // calculateSummary calculates aggregate statistics.
 func (c *Client) calculateSummary(contributions []Contribution) Summary {
 	summary := Summary{
 		TotalProjects: len(contributions),
 	}
 
+	// The commit total is only known if every repository's count is.
+	// Repositories without merged PRs (only reviews, issues, ...) have no
+	// PR commits to count.
+	totalCommits, commitsKnown := 0, true
+
 	for _, contrib := range contributions {
 		summary.TotalPRsMerged += contrib.PRsMerged
-		summary.TotalCommits += contrib.Commits
+		if contrib.Commits != nil {
+			totalCommits += *contrib.Commits
+		} else if contrib.PRsMerged > 0 {
+			commitsKnown = false
+		}
 		summary.TotalAdditions += contrib.Additions
 		summary.TotalDeletions += contrib.Deletions
+		summary.TotalReviews += contrib.Reviews
+		summary.TotalIssuesOpened += contrib.IssuesOpened
+		summary.TotalIssuesClosed += contrib.IssuesClosed
+		summary.TotalDiscussionAnswers += contrib.DiscussionAnswers
+		summary.TotalPRsOpen += contrib.PRsOpen
+		summary.TotalPRsClosedUnmerged += contrib.PRsClosedUnmerged
+	}
+
+	if c.includePRStates {
+		summary.AcceptanceRate = acceptanceRate(summary.TotalPRsMerged, summary.TotalPRsClosedUnmerged)
+	}
+
+	summary.Accuracy = Accuracy{Commits: AccuracyExact, Lines: AccuracyExact}
+	if commitsKnown {
+		summary.TotalCommits = &totalCommits
+	} else {
+		summary.Accuracy.Commits = AccuracyUnknown
+	}
+	if !c.includeLOC {
+		summary.Accuracy.Lines = AccuracyUnknown
 	}
 
 	return summary
@@ -3,8 +3,10 @@ package ossstats
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -148,8 +150,8 @@ func TestGetContributionsWithContributions(t *testing.T) {
 		t.Errorf("Stars = %d, want 100", contrib.Stars)
 	}
 
-	if contrib.Commits != 5 {
-		t.Errorf("Commits = %d, want 5", contrib.Commits)
+	if contrib.Commits == nil || *contrib.Commits != 5 {
+		t.Errorf("Commits = %s, want 5", fmtInt(contrib.Commits))
 	}
 
 	if contrib.Additions != 100 {
@@ -169,8 +171,12 @@ func TestGetContributionsWithContributions(t *testing.T) {
 		t.Errorf("TotalPRsMerged = %d, want 1", stats.Summary.TotalPRsMerged)
 	}
 
-	if stats.Summary.TotalCommits != 5 {
-		t.Errorf("TotalCommits = %d, want 5", stats.Summary.TotalCommits)
+	if stats.Summary.TotalCommits == nil || *stats.Summary.TotalCommits != 5 {
+		t.Errorf("TotalCommits = %s, want 5", fmtInt(stats.Summary.TotalCommits))
+	}
+
+	if want := (Accuracy{Commits: AccuracyExact, Lines: AccuracyExact}); stats.Summary.Accuracy != want {
+		t.Errorf("Accuracy = %+v, want %+v", stats.Summary.Accuracy, want)
 	}
 
 	if stats.Summary.TotalAdditions != 100 {
@@ -247,9 +253,15 @@ func TestGetContributionsWithoutLOC(t *testing.T) {
 
 	contrib := stats.Contributions[0]
 
-	// Should default to 1 commit when not fetching details
-	if contrib.Commits != 1 {
-		t.Errorf("Commits = %d, want 1 (default)", contrib.Commits)
+	// Commits are unknown when not fetching details, not made up
+	if contrib.Commits != nil {
+		t.Errorf("Commits = %s, want nil (unknown)", fmtInt(contrib.Commits))
+	}
+	if stats.Summary.TotalCommits != nil {
+		t.Errorf("TotalCommits = %s, want nil (unknown)", fmtInt(stats.Summary.TotalCommits))
+	}
+	if want := (Accuracy{Commits: AccuracyUnknown, Lines: AccuracyUnknown}); stats.Summary.Accuracy != want {
+		t.Errorf("Accuracy = %+v, want %+v", stats.Summary.Accuracy, want)
 	}
 
 	// LOC should be 0 when not fetched
@@ -409,11 +421,7 @@ func TestGetContributionsUnauthorized(t *testing.T) {
 
 func TestGetContributionsUserNotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasPrefix(r.URL.Path, "/search/issues") {
-			w.WriteHeader(http.StatusNotFound)
-			w.Write([]byte(`{"message":"Not Found"}`))
-			return
-		}
+		t.Errorf("unexpected request %s after the user lookup failed", r.URL.Path)
 		http.NotFound(w, r)
 	}))
 	defer server.Close()
@@ -421,7 +429,7 @@ func TestGetContributionsUserNotFound(t *testing.T) {
 	client := New(
 		WithToken("test-token"),
 	)
-	client.httpClient.Transport = &mockTransport{server: server}
+	client.httpClient.Transport = &mockTransport{server: server, missingUsers: []string{"nonexistent"}}
 
 	_, err := client.GetContributions(context.Background(), "nonexistent")
 
@@ -439,19 +447,47 @@ func TestGetContributionsUserNotFound(t *testing.T) {
 	}
 }
 
+func TestGetContributionsSearchRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/search/issues") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message":"Validation Failed"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := New(
+		WithToken("test-token"),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	_, err := client.GetContributions(context.Background(), "testuser")
+
+	var notFoundErr *ErrUserNotFound
+	if errors.As(err, &notFoundErr) {
+		t.Fatalf("error = %v, an existing user should not be reported as not found", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("error = %v, want the search's APIError", err)
+	}
+}
+
 func TestCalculateSummary(t *testing.T) {
 	client := New()
 
 	contributions := []Contribution{
 		{
 			PRsMerged: 5,
-			Commits:   15,
+			Commits:   intPtr(15),
 			Additions: 100,
 			Deletions: 20,
 		},
 		{
 			PRsMerged: 3,
-			Commits:   10,
+			Commits:   intPtr(10),
 			Additions: 50,
 			Deletions: 10,
 		},
@@ -467,8 +503,8 @@ func TestCalculateSummary(t *testing.T) {
 		t.Errorf("TotalPRsMerged = %d, want 8", summary.TotalPRsMerged)
 	}
 
-	if summary.TotalCommits != 25 {
-		t.Errorf("TotalCommits = %d, want 25", summary.TotalCommits)
+	if summary.TotalCommits == nil || *summary.TotalCommits != 25 {
+		t.Errorf("TotalCommits = %s, want 25", fmtInt(summary.TotalCommits))
 	}
 
 	if summary.TotalAdditions != 150 {
@@ -521,7 +557,7 @@ func TestApplyFilters(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			client := New(WithMinStars(tt.minStars))
 
-			filtered := client.applyFilters(tt.contributions)
+			filtered := client.applyFilters(tt.contributions, nil)
 
 			if len(filtered) != tt.wantCount {
 				t.Errorf("filtered count = %d, want %d", len(filtered), tt.wantCount)
@@ -602,8 +638,8 @@ func TestGetContributionsMultiplePRsSameRepo(t *testing.T) {
 		t.Errorf("PRsMerged = %d, want 2", contrib.PRsMerged)
 	}
 
-	if contrib.Commits != 2 {
-		t.Errorf("Commits = %d, want 2 (1 per PR)", contrib.Commits)
+	if contrib.Commits != nil {
+		t.Errorf("Commits = %s, want nil (unknown without LOC)", fmtInt(contrib.Commits))
 	}
 
 	// Should have first and last contribution times
@@ -617,11 +653,27 @@ func TestGetContributionsMultiplePRsSameRepo(t *testing.T) {
 }
 
 // mockTransport redirects requests to test server
+// mockTransport sends requests to the test server. User lookups are
+// answered without it: every user exists but missingUsers.
 type mockTransport struct {
-	server *httptest.Server
+	server       *httptest.Server
+	missingUsers []string
 }
 
 func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if login, ok := strings.CutPrefix(req.URL.Path, "/users/"); ok {
+		rec := httptest.NewRecorder()
+		if slices.Contains(t.missingUsers, login) {
+			rec.WriteHeader(http.StatusNotFound)
+			rec.WriteString(`{"message":"Not Found"}`)
+		} else {
+			json.NewEncoder(rec).Encode(github.User{Login: login})
+		}
+		resp := rec.Result()
+		resp.Request = req
+		return resp, nil
+	}
+
 	// Rewrite the URL to point to our test server
 	req.URL.Scheme = "http"
 	req.URL.Host = strings.TrimPrefix(t.server.URL, "http://")
@@ -0,0 +1,57 @@
+package ossstats
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/ghfake"
+	"github.com/mabd-dev/gh-oss-stats/internal/replay"
+)
+
+// DefaultDebugScenario replays recorded responses of a user with 17 merged
+// PRs across 7 repositories
+const DefaultDebugScenario = "default"
+
+// debugBaseURL is where debug mode sends requests unless WithBaseURL is
+// set. Its .invalid domain can't resolve, and errors show it instead of
+// GitHub's API.
+const debugBaseURL = "https://debug-scenario.invalid"
+
+// DebugScenarios returns the names of the built-in debug scenarios:
+//
+//   - default: recorded responses covering every fetch option. Searches
+//     are answered whatever organizations or owners they exclude.
+//   - auth-failure: every request fails with 401 Bad credentials
+//   - empty-user: no contributions
+//   - forbidden: the search fails with 403 (ErrForbidden)
+//   - huge-contributor: 600 merged PRs across 80 repositories
+//   - partial-results: some PR detail requests fail, so runs fetching them
+//     (e.g. WithLOC) return ErrPartialResults
+//   - rate-limit-exceeded: the search rate limit resets in an hour
+//     (ErrRateLimited)
+//   - rate-limited: the core rate limit runs out mid-run and resets a few
+//     seconds later
+//   - secondary-rate-limit: the search hits a secondary rate limit
+//     (ErrSecondaryRateLimit)
+//   - user-not-found: only octocat exists (ErrUserNotFound)
+//
+// Every scenario but default is a fake GitHub API answering for any user,
+// so the options change the requests made like they would against GitHub.
+func DebugScenarios() []string {
+	return append([]string{DefaultDebugScenario}, ghfake.EmbeddedNames()...)
+}
+
+// debugTransport returns the transport serving the debug scenario
+func (c *Client) debugTransport(username string) (http.RoundTripper, error) {
+	if c.debugScenario == "" || c.debugScenario == DefaultDebugScenario {
+		c.logger.Info("DEBUG MODE: Replaying recorded API responses")
+		return replay.NewReplayer(replay.DebugFixture(), username).IgnoreExclusions(), nil
+	}
+
+	scenario, err := ghfake.Embedded(c.debugScenario)
+	if err != nil {
+		return nil, fmt.Errorf("debug scenario: %w", err)
+	}
+	c.logger.Info("DEBUG MODE: Serving a built-in scenario", "scenario", c.debugScenario)
+	return ghfake.New(scenario).Transport(), nil
+}
@@ -0,0 +1,131 @@
+package ossstats
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebugScenarios(t *testing.T) {
+	want := []string{
+		"default", "auth-failure", "empty-user", "forbidden", "huge-contributor", "partial-results",
+		"rate-limit-exceeded", "rate-limited", "secondary-rate-limit", "user-not-found",
+	}
+	if got := DebugScenarios(); !slices.Equal(got, want) {
+		t.Errorf("DebugScenarios() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		scenario string
+		opts     []Option
+		check    func(t *testing.T, stats *Stats, err error)
+	}{
+		{"empty-user", nil, func(t *testing.T, stats *Stats, err error) {
+			if err != nil || stats.Summary.TotalProjects != 0 {
+				t.Errorf("got %+v, %v, want no contributions", stats, err)
+			}
+		}},
+		{"huge-contributor", nil, func(t *testing.T, stats *Stats, err error) {
+			if err != nil || stats.Summary.TotalPRsMerged != DefaultMaxPRS || stats.Summary.TotalProjects != 80 {
+				t.Errorf("got %+v, %v, want %d PRs across 80 projects", stats.Summary, err, DefaultMaxPRS)
+			}
+		}},
+		{"auth-failure", nil, func(t *testing.T, stats *Stats, err error) {
+			var auth *ErrAuthentication
+			if !errors.As(err, &auth) {
+				t.Errorf("error = %v, want ErrAuthentication", err)
+			}
+		}},
+		{"partial-results", []Option{WithLOC(true)}, func(t *testing.T, stats *Stats, err error) {
+			var partial *ErrPartialResults
+			if !errors.As(err, &partial) || len(partial.Errors) != 2 || partial.Stats.Summary.TotalPRsMerged != 7 {
+				t.Errorf("error = %v, want ErrPartialResults with 2 errors and 7 PRs", err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != 502 || apiErr.RequestID == "" {
+				t.Errorf("partial results should wrap the failed requests' APIErrors, got %v", apiErr)
+			}
+		}},
+		{"user-not-found", nil, func(t *testing.T, stats *Stats, err error) {
+			var notFound *ErrUserNotFound
+			if !errors.As(err, &notFound) || notFound.Username != "anyone" {
+				t.Errorf("error = %v, want ErrUserNotFound for anyone", err)
+			}
+		}},
+		{"secondary-rate-limit", nil, func(t *testing.T, stats *Stats, err error) {
+			var secondary *ErrSecondaryRateLimit
+			if !errors.As(err, &secondary) || secondary.RetryAfter != time.Minute {
+				t.Errorf("error = %v, want ErrSecondaryRateLimit retrying after a minute", err)
+			}
+		}},
+		{"forbidden", nil, func(t *testing.T, stats *Stats, err error) {
+			var forbidden *ErrForbidden
+			var apiErr *APIError
+			if !errors.As(err, &forbidden) || !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, "SAML") {
+				t.Errorf("error = %v, want ErrForbidden wrapping GitHub's message", err)
+			}
+		}},
+		{"rate-limit-exceeded", nil, func(t *testing.T, stats *Stats, err error) {
+			var rateLimited *ErrRateLimited
+			if !errors.As(err, &rateLimited) || time.Until(rateLimited.ResetAt) < 50*time.Minute {
+				t.Errorf("error = %v, want ErrRateLimited resetting in about an hour", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+			client := New(append(tt.opts, WithDebugScenario(tt.scenario), WithConcurrency(1))...)
+			stats, err := client.GetContributions(context.Background(), "anyone")
+			tt.check(t, stats, err)
+		})
+	}
+}
+
+func TestDebugScenarioRateLimited(t *testing.T) {
+	var waits atomic.Int32
+	client := New(WithDebugScenario("rate-limited"), WithProgress(func(e Event) {
+		if e.Kind == EventRateLimitWait {
+			waits.Add(1)
+		}
+	}))
+
+	stats, err := client.GetContributions(context.Background(), "anyone")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+	if waits.Load() == 0 || stats.Summary.TotalProjects != 6 {
+		t.Errorf("got %d waits and %d projects, want the run to pause and fetch all 6 projects",
+			waits.Load(), stats.Summary.TotalProjects)
+	}
+}
+
+func TestDebugModeWithExclusions(t *testing.T) {
+	client := New(
+		WithDebug(true),
+		WithExcludeOrgs([]string{"foo"}),
+		WithFilter(Filter{ExcludeOwners: []string{"x"}}),
+		WithCoAuthoredCommits(true),
+	)
+
+	stats, err := client.GetContributions(context.Background(), "anyone")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+	if stats.Summary.TotalPRsMerged == 0 {
+		t.Errorf("debug mode should replay recorded contributions, got %+v", stats.Summary)
+	}
+}
+
+func TestUnknownDebugScenario(t *testing.T) {
+	client := New(WithDebugScenario("missing"))
+
+	_, err := client.GetContributions(context.Background(), "anyone")
+	if err == nil {
+		t.Fatal("GetContributions() should fail for an unknown scenario")
+	}
+}
@@ -0,0 +1,127 @@
+package ossstats
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Filter selects which repositories count as contributions.
+//
+// A repository must match every non-empty Include list (any entry of a list
+// is enough) and no entry of any Exclude list. All comparisons are
+// case-insensitive. GetContributions keeps repositories whose metadata
+// couldn't be fetched, checking only the rules that don't need it, and
+// reports the failure in ErrPartialResults.
+//
+// Example:
+//
+//	ossstats.WithFilter(ossstats.Filter{
+//	    ExcludeRepos:  []string{"*/awesome-*", "*/docs"},
+//	    IncludeOwners: []string{"kubernetes", "golang"},
+//	    ExcludeForks:  true,
+//	    MinPRs:        2,
+//	})
+type Filter struct {
+	IncludeRepos []string // Glob patterns on "owner/repo", e.g. "kubernetes/*", "*/docs"
+	ExcludeRepos []string
+
+	IncludeOwners []string // Exact owner logins
+	ExcludeOwners []string
+
+	IncludeLanguages []string // Primary repository language, e.g. "Go"
+	ExcludeLanguages []string
+
+	IncludeTopics []string // Repository topics, e.g. "kubernetes"
+	ExcludeTopics []string
+
+	ExcludeArchived bool // Drop archived repositories
+	ExcludeForks    bool // Drop repositories that are forks
+
+	MinPRs int // Minimum merged PRs per repository
+}
+
+// Validate reports malformed glob patterns
+func (f Filter) Validate() error {
+	for _, pattern := range slices.Concat(f.IncludeRepos, f.ExcludeRepos) {
+		if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+			return fmt.Errorf("invalid repo pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the contribution passes the filter
+func (f Filter) Match(c Contribution) bool {
+	return f.matchRepo(c) && f.matchMetadata(c)
+}
+
+// matchRepo applies the rules that don't need repository metadata, so they
+// can run before fetching it
+func (f Filter) matchRepo(c Contribution) bool {
+	repo := strings.ToLower(c.Repo)
+
+	if len(f.IncludeRepos) > 0 && !matchAnyGlob(f.IncludeRepos, repo) {
+		return false
+	}
+	if matchAnyGlob(f.ExcludeRepos, repo) {
+		return false
+	}
+
+	if len(f.IncludeOwners) > 0 && !containsFold(f.IncludeOwners, c.Owner) {
+		return false
+	}
+	if containsFold(f.ExcludeOwners, c.Owner) {
+		return false
+	}
+
+	return c.PRsMerged >= f.MinPRs
+}
+
+// matchMetadata applies the rules based on repository metadata
+func (f Filter) matchMetadata(c Contribution) bool {
+	if len(f.IncludeLanguages) > 0 && !containsFold(f.IncludeLanguages, c.Language) {
+		return false
+	}
+	if c.Language != "" && containsFold(f.ExcludeLanguages, c.Language) {
+		return false
+	}
+
+	if len(f.IncludeTopics) > 0 && !slices.ContainsFunc(c.Topics, func(topic string) bool {
+		return containsFold(f.IncludeTopics, topic)
+	}) {
+		return false
+	}
+	if slices.ContainsFunc(c.Topics, func(topic string) bool {
+		return containsFold(f.ExcludeTopics, topic)
+	}) {
+		return false
+	}
+
+	if f.ExcludeArchived && c.Archived {
+		return false
+	}
+	if f.ExcludeForks && c.Fork {
+		return false
+	}
+
+	return true
+}
+
+// matchAnyGlob reports whether the lowercase repo matches any pattern
+func matchAnyGlob(patterns []string, repo string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), repo); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	return slices.ContainsFunc(list, func(item string) bool {
+		return strings.EqualFold(item, s)
+	})
+}
@@ -0,0 +1,219 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestFilterMatch(t *testing.T) {
+	kube := Contribution{
+		Repo: "kubernetes/kubectl", Owner: "kubernetes", PRsMerged: 3,
+		Language: "Go", Topics: []string{"cli", "kubernetes"},
+	}
+	docs := Contribution{Repo: "golang/docs", Owner: "golang", PRsMerged: 1, Language: "Markdown"}
+	awesome := Contribution{Repo: "sindresorhus/awesome-go", Owner: "sindresorhus", PRsMerged: 1, Topics: []string{"awesome-list"}}
+	archived := Contribution{Repo: "old/tool", Owner: "old", PRsMerged: 5, Archived: true, Fork: true}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		c      Contribution
+		want   bool
+	}{
+		{"empty filter", Filter{}, kube, true},
+		{"include repo glob", Filter{IncludeRepos: []string{"kubernetes/*"}}, kube, true},
+		{"include repo glob miss", Filter{IncludeRepos: []string{"kubernetes/*"}}, docs, false},
+		{"exclude repo glob", Filter{ExcludeRepos: []string{"*/docs"}}, docs, false},
+		{"exclude repo prefix glob", Filter{ExcludeRepos: []string{"*/awesome-*"}}, awesome, false},
+		{"glob is case-insensitive", Filter{IncludeRepos: []string{"Kubernetes/*"}}, kube, true},
+		{"include owner", Filter{IncludeOwners: []string{"golang", "kubernetes"}}, kube, true},
+		{"include owner miss", Filter{IncludeOwners: []string{"golang"}}, kube, false},
+		{"exclude owner", Filter{ExcludeOwners: []string{"KUBERNETES"}}, kube, false},
+		{"include language", Filter{IncludeLanguages: []string{"go"}}, kube, true},
+		{"include language miss", Filter{IncludeLanguages: []string{"Rust"}}, kube, false},
+		{"include language unknown", Filter{IncludeLanguages: []string{"Go"}}, awesome, false},
+		{"exclude language", Filter{ExcludeLanguages: []string{"Markdown"}}, docs, false},
+		{"include topic", Filter{IncludeTopics: []string{"kubernetes"}}, kube, true},
+		{"include topic miss", Filter{IncludeTopics: []string{"kubernetes"}}, docs, false},
+		{"exclude topic", Filter{ExcludeTopics: []string{"awesome-list"}}, awesome, false},
+		{"exclude archived", Filter{ExcludeArchived: true}, archived, false},
+		{"exclude forks", Filter{ExcludeForks: true}, archived, false},
+		{"archived allowed by default", Filter{}, archived, true},
+		{"min PRs met", Filter{MinPRs: 3}, kube, true},
+		{"min PRs not met", Filter{MinPRs: 2}, docs, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.c); got != tt.want {
+				t.Errorf("Match(%s) = %v, want %v", tt.c.Repo, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	if err := (Filter{IncludeRepos: []string{"kubernetes/*", "*/docs"}}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := (Filter{ExcludeRepos: []string{"[invalid"}}).Validate(); err == nil {
+		t.Error("Validate() should reject a malformed pattern")
+	}
+}
+
+func TestWithFilter(t *testing.T) {
+	client := New(
+		WithFilter(Filter{MinPRs: 2}),
+		WithFilter(Filter{ExcludeForks: true}),
+	)
+
+	if len(client.filters) != 2 {
+		t.Fatalf("filters = %d, want 2", len(client.filters))
+	}
+
+	contributions := []Contribution{
+		{Repo: "a/one", PRsMerged: 1},
+		{Repo: "b/two", PRsMerged: 2, Fork: true},
+		{Repo: "c/three", PRsMerged: 2},
+	}
+
+	filtered := client.applyFilters(contributions, nil)
+	if len(filtered) != 1 || filtered[0].Repo != "c/three" {
+		t.Errorf("applyFilters() = %v, want only c/three", filtered)
+	}
+}
+
+func TestGetContributionsWithFilter(t *testing.T) {
+	mergedAt := time.Now().UTC()
+	var searchQuery string
+	repoRequests := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/search/issues") {
+			searchQuery = r.URL.Query().Get("q")
+			resp := github.SearchIssuesResponse{
+				TotalCount: 3,
+				Items: []github.Issue{
+					{Number: 1, RepositoryURL: "https://api.github.com/repos/kubernetes/kubectl", PullRequest: &github.PullRequestRef{MergedAt: &mergedAt}},
+					{Number: 2, RepositoryURL: "https://api.github.com/repos/someone/awesome-go", PullRequest: &github.PullRequestRef{MergedAt: &mergedAt}},
+					{Number: 3, RepositoryURL: "https://api.github.com/repos/legacy/tool", PullRequest: &github.PullRequestRef{MergedAt: &mergedAt}},
+				},
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			repoRequests[r.URL.Path]++
+			resp := github.Repository{Language: "Go", Archived: strings.Contains(r.URL.Path, "legacy")}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := New(
+		WithToken("test-token"),
+		WithFilter(Filter{
+			ExcludeRepos:    []string{"*/awesome-*"},
+			ExcludeOwners:   []string{"spam-org"},
+			ExcludeArchived: true,
+		}),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(stats.Contributions) != 1 || stats.Contributions[0].Repo != "kubernetes/kubectl" {
+		t.Fatalf("Contributions = %+v, want only kubernetes/kubectl", stats.Contributions)
+	}
+	if stats.Contributions[0].Language != "Go" {
+		t.Errorf("Language = %q, want Go", stats.Contributions[0].Language)
+	}
+
+	if !strings.Contains(searchQuery, "-user:spam-org") {
+		t.Errorf("search query %q should exclude owner spam-org", searchQuery)
+	}
+
+	// Excluded by name, so its metadata is never fetched
+	if repoRequests["/repos/someone/awesome-go"] != 0 {
+		t.Error("metadata of a repo excluded by glob should not be fetched")
+	}
+}
+
+func TestGetContributionsFilterMissingMetadata(t *testing.T) {
+	mergedAt := time.Now().UTC()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search/issues":
+			resp := github.SearchIssuesResponse{
+				TotalCount: 3,
+				Items: []github.Issue{
+					{Number: 1, RepositoryURL: "https://api.github.com/repos/golang/go", PullRequest: &github.PullRequestRef{MergedAt: &mergedAt}},
+					{Number: 2, RepositoryURL: "https://api.github.com/repos/python/cpython", PullRequest: &github.PullRequestRef{MergedAt: &mergedAt}},
+					{Number: 3, RepositoryURL: "https://api.github.com/repos/gone/repo", PullRequest: &github.PullRequestRef{MergedAt: &mergedAt}},
+				},
+			}
+			json.NewEncoder(w).Encode(resp)
+		case "/repos/golang/go":
+			json.NewEncoder(w).Encode(github.Repository{Language: "Go", StargazersCount: 100})
+		case "/repos/python/cpython":
+			json.NewEncoder(w).Encode(github.Repository{Language: "Python", StargazersCount: 100})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(
+		WithToken("test-token"),
+		WithMinStars(10),
+		WithFilter(Filter{IncludeLanguages: []string{"Go"}}),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	_, err := client.GetContributions(context.Background(), "testuser")
+
+	// A repo whose metadata failed can't be checked, so it is kept and reported
+	var partial *ErrPartialResults
+	if !errors.As(err, &partial) {
+		t.Fatalf("error = %v, want ErrPartialResults", err)
+	}
+	if len(partial.Errors) != 1 || !strings.Contains(partial.Errors[0].Error(), "gone/repo") {
+		t.Errorf("Errors = %v, want the metadata error of gone/repo", partial.Errors)
+	}
+
+	var repos []string
+	for _, contrib := range partial.Stats.Contributions {
+		repos = append(repos, contrib.Repo)
+	}
+	slices.Sort(repos)
+	if want := []string{"golang/go", "gone/repo"}; !slices.Equal(repos, want) {
+		t.Errorf("Contributions = %v, want %v", repos, want)
+	}
+}
+
+func TestGetContributionsInvalidFilter(t *testing.T) {
+	client := New(WithFilter(Filter{IncludeRepos: []string{"[bad"}}))
+
+	if _, err := client.GetContributions(context.Background(), "testuser"); err == nil {
+		t.Error("GetContributions() should fail for an invalid filter")
+	}
+}
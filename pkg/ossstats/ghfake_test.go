@@ -0,0 +1,94 @@
+package ossstats
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/ghfake"
+)
+
+const fakeScenario = `
+repositories:
+  - {name: acme/widgets, stars: 1200, language: Go}
+  - {name: acme/gadgets, stars: 80, language: Rust}
+pullRequests:
+  - {repo: acme/widgets, number: 12, author: octocat, createdAt: 2024-03-01T10:00:00Z, additions: 40, deletions: 10, commits: 2}
+  - {repo: acme/widgets, number: 15, author: octocat, createdAt: 2024-04-01T10:00:00Z, additions: 120, deletions: 5, commits: 4}
+  - {repo: acme/gadgets, number: 3, author: octocat, createdAt: 2024-02-01T10:00:00Z, additions: 15, commits: 1}
+  - {repo: acme/gadgets, number: 4, author: octocat, state: open, createdAt: 2024-05-01T10:00:00Z}
+  - {repo: octocat/dotfiles, number: 1, author: octocat, createdAt: 2024-01-01T10:00:00Z}
+`
+
+// newFakeServerClient returns a client sending its requests to a fake
+// GitHub API serving scenario
+func newFakeServerClient(t *testing.T, scenario string, opts ...Option) *Client {
+	t.Helper()
+
+	s, err := ghfake.ParseScenario([]byte(scenario))
+	if err != nil {
+		t.Fatalf("ParseScenario() error = %v", err)
+	}
+	server := httptest.NewServer(ghfake.New(s))
+	t.Cleanup(server.Close)
+
+	return New(append(opts, WithToken("test-token"), WithBaseURL(server.URL+"/"))...)
+}
+
+func TestFakeServerContributions(t *testing.T) {
+	client := newFakeServerClient(t, fakeScenario, WithLOC(true), WithPRStates(true))
+
+	stats, err := client.GetContributions(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+
+	summary := stats.Summary
+	if summary.TotalProjects != 2 || summary.TotalPRsMerged != 3 || summary.TotalPRsOpen != 1 {
+		t.Errorf("summary = %+v, want 2 projects, 3 merged and 1 open PR", summary)
+	}
+	if summary.TotalAdditions != 175 || summary.TotalDeletions != 15 || summary.TotalCommits == nil || *summary.TotalCommits != 7 {
+		t.Errorf("summary = %+v, want 175 additions, 15 deletions and 7 commits", summary)
+	}
+	if stats.Contributions[0].Repo != "acme/widgets" || stats.Contributions[0].Stars != 1200 {
+		t.Errorf("first contribution = %+v", stats.Contributions[0])
+	}
+}
+
+func TestFakeServerFaults(t *testing.T) {
+	scenario := fakeScenario + `
+faults:
+  - {endpoint: "/repos/{owner}/{repo}/pulls/{number}", status: 502, times: 1}
+`
+	client := newFakeServerClient(t, scenario, WithLOC(true), WithConcurrency(1))
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+
+	var partial *ErrPartialResults
+	if !errors.As(err, &partial) {
+		t.Fatalf("GetContributions() error = %v, want ErrPartialResults", err)
+	}
+	if len(partial.Errors) != 1 || partial.Stats.Summary.TotalPRsMerged != 2 {
+		t.Errorf("got %d errors and %d PRs, want 1 error and the 2 other PRs", len(partial.Errors), partial.Stats.Summary.TotalPRsMerged)
+	}
+}
+
+func TestFakeServerRateLimited(t *testing.T) {
+	scenario := fakeScenario + `
+rateLimits:
+  search: {limit: 30, remaining: 0, resetIn: 1h}
+`
+	client := newFakeServerClient(t, scenario, WithTimeout(5*time.Second))
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("GetContributions() error = %v, want ErrRateLimited", err)
+	}
+	if until := time.Until(rateLimited.ResetAt); until < 50*time.Minute {
+		t.Errorf("ResetAt is %s away, want about an hour", until)
+	}
+}
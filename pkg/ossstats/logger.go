@@ -1,12 +1,169 @@
 package ossstats
 
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
 // Logger is the interface for logging within the library.
 // Implementations can provide custom logging behavior.
+//
+// The library logs through log/slog (see WithLogHandler). A Logger set with
+// WithLogger receives the Info and higher records as single text lines.
 type Logger interface {
 	Printf(format string, v ...any)
 }
 
-// defaultLogger is a no-op logger that discards all log messages.
-type defaultLogger struct{}
+// discardLogger returns the slog logger used when logging is disabled
+func discardLogger() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// printfHandler is a slog.Handler writing each record to a Logger as one
+// line: the level (unless Info), the message and key=value attributes.
+type printfHandler struct {
+	logger Logger
+	level  slog.Leveler
+	attrs  string // Preformatted attributes of WithAttrs
+	group  string // Key prefix of WithGroup, ending with "."
+}
+
+func newPrintfHandler(logger Logger, level slog.Leveler) *printfHandler {
+	return &printfHandler{logger: logger, level: level}
+}
+
+func (h *printfHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *printfHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	if r.Level != slog.LevelInfo {
+		b.WriteString(r.Level.String())
+		b.WriteByte(' ')
+	}
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&b, h.group, a)
+		return true
+	})
+
+	h.logger.Printf("%s", b.String())
+	return nil
+}
 
-func (defaultLogger) Printf(format string, v ...any) {}
+func (h *printfHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		appendAttr(&b, h.group, a)
+	}
+
+	clone := *h
+	clone.attrs = b.String()
+	return &clone
+}
+
+func (h *printfHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.group += name + "."
+	return &clone
+}
+
+// appendAttr writes a as " key=value", prefixing keys with group and
+// flattening nested groups
+func appendAttr(b *strings.Builder, group string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendAttr(b, group, ga)
+		}
+		return
+	}
+
+	value := a.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	b.WriteByte(' ')
+	b.WriteString(group + a.Key)
+	b.WriteByte('=')
+	b.WriteString(value)
+}
+
+// loggingTransport logs every GitHub API request at debug level, with its
+// status, remaining rate limit and duration
+type loggingTransport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+		t.logger.LogAttrs(req.Context(), slog.LevelDebug, "GitHub API request failed", attrs...)
+		return resp, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if remaining := resp.Header.Get(github.RateLimitRemainingHeader); remaining != "" {
+		if n, err := strconv.Atoi(remaining); err == nil {
+			attrs = append(attrs, slog.Int("rate_limit_remaining", n))
+		}
+	}
+	t.logger.LogAttrs(req.Context(), slog.LevelDebug, "GitHub API request", attrs...)
+	return resp, err
+}
+
+// loggingHTTPClient returns httpClient with its requests logged, or
+// httpClient itself when debug logs are disabled
+func loggingHTTPClient(httpClient *http.Client, logger *slog.Logger) *http.Client {
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return httpClient
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	logged := *httpClient
+	logged.Transport = loggingTransport{base: base, logger: logger}
+	return &logged
+}
+
+// logRateLimitWait logs the scheduler's rate limit pauses
+func (c *Client) logRateLimitWait(resource github.Resource, reset time.Time, waiting bool) {
+	if waiting {
+		c.logger.Warn("Waiting for rate limit reset",
+			"resource", string(resource),
+			"reset", reset.Format(time.RFC3339),
+			"duration", time.Until(reset).Round(time.Second))
+		return
+	}
+	c.logger.Info("Rate limit reset, resuming", "resource", string(resource))
+}
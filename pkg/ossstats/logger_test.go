@@ -1,7 +1,18 @@
 package ossstats
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
 )
 
 // mockLogger is a test logger that captures log messages
@@ -19,19 +30,6 @@ func TestLoggerInterface(t *testing.T) {
 	var _ Logger = &mockLogger{}
 }
 
-func TestDefaultLogger(t *testing.T) {
-	logger := defaultLogger{}
-
-	// Should not panic when called
-	logger.Printf("test message")
-	logger.Printf("test with args: %s %d", "hello", 42)
-}
-
-func TestDefaultLoggerImplementsInterface(t *testing.T) {
-	// Verify defaultLogger implements Logger
-	var _ Logger = defaultLogger{}
-}
-
 func TestMockLogger(t *testing.T) {
 	mock := &mockLogger{}
 
@@ -50,3 +48,106 @@ func TestMockLogger(t *testing.T) {
 		t.Errorf("Second message = %q, want %q", mock.messages[1], "second message with %s")
 	}
 }
+
+// lineLogger is a Logger keeping formatted lines
+type lineLogger struct {
+	lines []string
+}
+
+func (l *lineLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestPrintfHandler(t *testing.T) {
+	out := &lineLogger{}
+	logger := slog.New(newPrintfHandler(out, slog.LevelInfo))
+
+	logger.Debug("hidden")
+	logger.Info("Found merged PRs", "count", 3)
+	logger.Warn("Failed to fetch repo", "repo", "owner/repo", "error", "not found")
+	logger.With("user", "octocat").WithGroup("req").Info("Done", "status", 200, "path", "")
+
+	want := []string{
+		"Found merged PRs count=3",
+		`WARN Failed to fetch repo repo=owner/repo error="not found"`,
+		`Done user=octocat req.status=200 req.path=""`,
+	}
+	if len(out.lines) != len(want) {
+		t.Fatalf("lines = %q, want %q", out.lines, want)
+	}
+	for i := range want {
+		if out.lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, out.lines[i], want[i])
+		}
+	}
+}
+
+func TestWithLogHandler(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(github.RateLimitRemainingHeader, "29")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: 1, Items: []github.Issue{
+				{Number: 7, RepositoryURL: "https://api.github.com/repos/owner/repo", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+			}})
+		case strings.Contains(r.URL.Path, "/pulls/"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := New(
+		WithToken("test-token"),
+		WithLOC(true),
+		WithLogHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	client.GetContributions(context.Background(), "testuser")
+
+	var request, failed map[string]any
+	for line := range strings.Lines(buf.String()) {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+		switch {
+		case record["msg"] == "GitHub API request" && record["path"] == "/search/issues" && request == nil:
+			request = record
+		case record["msg"] == "Failed to process PR":
+			failed = record
+		}
+	}
+
+	if request == nil {
+		t.Fatal("no debug log of the API requests")
+	}
+	if request["level"] != "DEBUG" || request["status"] != float64(200) || request["rate_limit_remaining"] != float64(29) {
+		t.Errorf("request log = %v", request)
+	}
+	if _, ok := request["duration"]; !ok {
+		t.Errorf("request log has no duration: %v", request)
+	}
+
+	if failed == nil {
+		t.Fatal("no log of the failed PR")
+	}
+	if failed["level"] != "WARN" || failed["repo"] != "owner/repo" || failed["pr"] != float64(7) {
+		t.Errorf("failed PR log = %v", failed)
+	}
+}
+
+func TestWithLogHandlerNil(t *testing.T) {
+	client := New(WithLogHandler(nil))
+	if client.logger.Enabled(context.Background(), slog.LevelError) {
+		t.Error("WithLogHandler(nil) should discard logs")
+	}
+	if got := loggingHTTPClient(client.httpClient, client.logger); got != client.httpClient {
+		t.Error("requests should not be wrapped when debug logs are disabled")
+	}
+}
@@ -2,8 +2,13 @@ package ossstats
 
 import (
 	"log"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 // Option is a functional option for configuring the Client.
@@ -34,6 +39,76 @@ func WithPRDetails(enabled bool) Option {
 	}
 }
 
+// WithCommitCounts enables or disables fetching the commit count of each
+// merged PR when LOC is disabled (WithLOC fetches them with the PR details).
+// Costs one GraphQL call per 100 PRs and requires a token. Without either
+// option, commit counts are reported as unknown (nil).
+// Default: false
+func WithCommitCounts(enabled bool) Option {
+	return func(c *Client) {
+		c.includeCommitCounts = enabled
+	}
+}
+
+// WithPRStates enables or disables counting open and closed-unmerged PRs
+// next to merged ones, and computing acceptance rates. Costs two more
+// searches per 100 PRs.
+// Default: false
+func WithPRStates(enabled bool) Option {
+	return func(c *Client) {
+		c.includePRStates = enabled
+	}
+}
+
+// WithReviews enables or disables counting PRs the user reviewed on external
+// repositories (excluding their own PRs). Costs one search per 100 reviews.
+// Default: false
+func WithReviews(enabled bool) Option {
+	return func(c *Client) {
+		c.includeReviews = enabled
+	}
+}
+
+// WithIssues enables or disables counting issues the user opened on external
+// repositories. Costs one search per 100 issues.
+// Default: false
+func WithIssues(enabled bool) Option {
+	return func(c *Client) {
+		c.includeIssues = enabled
+	}
+}
+
+// WithClosedIssues enables or disables counting the issues closed by the
+// user's merged PRs. Costs one GraphQL call per PR and requires a token.
+// Default: false
+func WithClosedIssues(enabled bool) Option {
+	return func(c *Client) {
+		c.includeClosedIssues = enabled
+	}
+}
+
+// WithDiscussionAnswers enables or disables counting the user's discussion
+// comments marked as the answer. Costs one GraphQL call per 100 answers and
+// requires a token.
+// Default: false
+func WithDiscussionAnswers(enabled bool) Option {
+	return func(c *Client) {
+		c.includeDiscussions = enabled
+	}
+}
+
+// WithCoAuthoredCommits enables or disables counting commits the user
+// authored or co-authored (via a Co-authored-by trailer) that landed outside
+// their merged PRs, e.g. cherry-picked or squashed under a maintainer's name.
+// Commits already covered by a PR are not counted twice. Costs two commit
+// searches per 100 commits, plus one call per PR in repos with such commits.
+// Default: false
+func WithCoAuthoredCommits(enabled bool) Option {
+	return func(c *Client) {
+		c.includeCoAuthored = enabled
+	}
+}
+
 // WithMinStars filters repositories by minimum star count.
 // Only contributions to repositories with at least this many stars will be included.
 // Default: 0 (no filtering)
@@ -60,6 +135,16 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithConcurrency sets the maximum number of API requests in flight at once.
+// The client lowers it as the rate limit budget runs low, and pauses when it
+// runs out instead of failing.
+// Default: 5
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		c.concurrency = n
+	}
+}
+
 // WithExcludeOrgs excludes contributions to repositories owned by the specified organizations.
 // This is useful for excluding your own organizations from the report.
 func WithExcludeOrgs(orgs []string) Option {
@@ -68,12 +153,77 @@ func WithExcludeOrgs(orgs []string) Option {
 	}
 }
 
+// WithFilter adds include/exclude rules for repositories (see Filter).
+// When called multiple times, a repository must pass every filter.
+// Owner exclusions are also added to the search query to save API calls.
+func WithFilter(filter Filter) Option {
+	return func(c *Client) {
+		c.filters = append(c.filters, filter)
+	}
+}
+
+// WithQualityFilter drops trivial and bot-assisted PRs (see QualityFilter).
+// Dropped PRs are listed in Stats.ExcludedPRs and not counted in the totals.
+// Some rules cost extra API calls per PR: docs-only detection lists the PR
+// files and bot detection lists the PR commits.
+func WithQualityFilter(filter QualityFilter) Option {
+	return func(c *Client) {
+		c.quality = &filter
+	}
+}
+
+// WithCheckpoint records search pages, PR details and repository metadata
+// in the file at path as GetContributions runs. When a run fails or is cut
+// short (rate limited, timed out), the next run with the same checkpoint
+// resumes from it instead of fetching those again. The file is removed once
+// a run completes. Default: "" (disabled)
+func WithCheckpoint(path string) Option {
+	return func(c *Client) {
+		c.checkpointPath = path
+	}
+}
+
 // WithLogger sets a custom logger for the client.
-// The logger will receive informational messages about the operation progress.
+// The logger will receive informational messages about the operation progress,
+// and warnings and errors, formatted as "message key=value ...".
+// Use WithLogHandler for structured or debug logs.
 // Default: no-op logger that discards all messages
 func WithLogger(logger Logger) Option {
 	return func(c *Client) {
-		c.logger = logger
+		if logger == nil {
+			c.logger = discardLogger()
+			return
+		}
+		c.logger = slog.New(newPrintfHandler(logger, slog.LevelInfo))
+	}
+}
+
+// WithLogHandler sends the client's logs to a log/slog handler, which
+// decides the format and the minimum level. Records carry attributes such as
+// "repo", "pr", "status", "rate_limit_remaining" and "duration"; every
+// GitHub API request is logged at debug level. Replaces WithLogger.
+// Default: nil (logs are discarded)
+func WithLogHandler(h slog.Handler) Option {
+	return func(c *Client) {
+		if h == nil {
+			c.logger = discardLogger()
+			return
+		}
+		c.logger = slog.New(h)
+	}
+}
+
+// WithProgress sets a callback receiving structured progress events while
+// GetContributions runs: phase changes, search pages, each processed PR and
+// repository, and rate limit pauses (see Event). Calls are made one at a
+// time, from the goroutines doing the work, so the callback should return
+// quickly. Default: nil (no events)
+func WithProgress(fn func(Event)) Option {
+	return func(c *Client) {
+		c.progress = nil
+		if fn != nil {
+			c.progress = &reporter{fn: fn}
+		}
 	}
 }
 
@@ -86,18 +236,52 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithBaseURL sends API requests to another GitHub API server, such as
+// GitHub Enterprise Server (https://HOST/api/v3) or a local fake.
+// Default: https://api.github.com
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithTracerProvider records OpenTelemetry spans for GetContributions, its
+// phases (searchMergedPRs, fetchPRDetails, enrichWithRepoData) and every
+// GitHub API request, with attributes for the endpoint, status and retries.
+// Default: no tracing
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		if tp == nil {
+			tp = noop.NewTracerProvider()
+		}
+		c.tracerProvider = tp
+	}
+}
+
 // WithVerbose enables verbose logging to the default logger.
 // This is a convenience option that sets up a standard logger.
 func WithVerbose() Option {
 	return func(c *Client) {
-		c.logger = log.Default()
+		c.logger = slog.New(newPrintfHandler(log.Default(), slog.LevelInfo))
 	}
 }
 
-// WithDebug enable/disable debug mode. When enabled, fake api client is used
-// and mock api data is returned
+// WithDebug enable/disable debug mode. When enabled, the responses of the
+// debug scenario (see WithDebugScenario) are served instead of calling
+// GitHub, and the token is not needed.
 func WithDebug(debug bool) Option {
 	return func(c *Client) {
 		c.debug = debug
 	}
 }
+
+// WithDebugScenario enables debug mode with one of the built-in scenarios
+// listed by DebugScenarios, e.g. "auth-failure" to reproduce a rejected
+// token. Unknown names fail GetContributions.
+// Default: DefaultDebugScenario
+func WithDebugScenario(name string) Option {
+	return func(c *Client) {
+		c.debug = true
+		c.debugScenario = name
+	}
+}
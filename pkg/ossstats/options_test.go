@@ -1,7 +1,9 @@
 package ossstats
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net/http"
 	"testing"
 	"time"
@@ -137,6 +139,17 @@ func TestWithTimeout(t *testing.T) {
 	}
 }
 
+func TestWithConcurrency(t *testing.T) {
+	client := New(WithConcurrency(10))
+
+	if client.concurrency != 10 {
+		t.Errorf("concurrency = %d, want 10", client.concurrency)
+	}
+	if client.scheduler == nil {
+		t.Error("scheduler should be created by New")
+	}
+}
+
 func TestWithLogger(t *testing.T) {
 	client := &Client{}
 	logger := &mockLogger{}
@@ -144,12 +157,8 @@ func TestWithLogger(t *testing.T) {
 	opt := WithLogger(logger)
 	opt(client)
 
-	if client.logger != logger {
-		t.Error("logger not set correctly")
-	}
-
 	// Test that logger works
-	client.logger.Printf("test message")
+	client.logger.Info("test message")
 	if len(logger.messages) != 1 {
 		t.Errorf("logger messages count = %d, want 1", len(logger.messages))
 	}
@@ -173,9 +182,18 @@ func TestWithHTTPClient(t *testing.T) {
 	}
 }
 
+func TestWithBaseURL(t *testing.T) {
+	client := &Client{}
+	WithBaseURL("https://ghe.example.com/api/v3/")(client)
+
+	if client.baseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("baseURL = %q, want it without the trailing slash", client.baseURL)
+	}
+}
+
 func TestWithVerbose(t *testing.T) {
 	client := &Client{
-		logger: defaultLogger{}, // Start with default
+		logger: discardLogger(), // Start with default
 	}
 
 	opt := WithVerbose()
@@ -183,17 +201,20 @@ func TestWithVerbose(t *testing.T) {
 
 	// Should replace with log.Default()
 	if client.logger == nil {
-		t.Error("logger should not be nil")
+		t.Fatal("logger should not be nil")
 	}
 
-	// Verify it's not the default logger anymore
-	_, isDefault := client.logger.(defaultLogger)
-	if isDefault {
-		t.Error("logger should not be defaultLogger after WithVerbose")
+	// Verify it's not discarding logs anymore
+	if !client.logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("logger should log info messages after WithVerbose")
 	}
 
-	// Should be *log.Logger
-	if _, ok := client.logger.(*log.Logger); !ok {
+	// Should write to *log.Logger
+	h, ok := client.logger.Handler().(*printfHandler)
+	if !ok {
+		t.Fatal("logger should use a printfHandler")
+	}
+	if _, ok := h.logger.(*log.Logger); !ok {
 		t.Error("logger should be *log.Logger")
 	}
 }
@@ -240,7 +261,8 @@ func TestMultipleOptions(t *testing.T) {
 		t.Errorf("timeout = %v, want %v", client.timeout, timeout)
 	}
 
-	if client.logger != logger {
+	client.logger.Info("test message")
+	if len(logger.messages) != 1 {
 		t.Error("logger not set correctly")
 	}
 }
@@ -0,0 +1,188 @@
+package ossstats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// FetchPlan describes what GetContributions would fetch with the client's
+// current options and how many API calls it would cost. Only the user
+// lookup and the merged PR search are run to build it.
+//
+// Counts are lower bounds where they depend on results that aren't searched
+// yet: each optional search (reviews, issues, PR states, commits) counts as
+// one page, and per-result calls of optional kinds aren't included.
+type FetchPlan struct {
+	Username string `json:"username"`
+	PRs      int    `json:"prs"`   // Merged PRs found by the search
+	Repos    int    `json:"repos"` // Repositories the PRs were merged into, after repo filters
+
+	Search  PlannedCalls `json:"search"`
+	Core    PlannedCalls `json:"core"`
+	GraphQL PlannedCalls `json:"graphql"`
+
+	FitsBudget        bool          `json:"fitsBudget"`        // The remaining rate limits cover the run
+	EstimatedDuration time.Duration `json:"estimatedDuration"` // Time spent waiting for rate limit resets, 0 when it fits
+}
+
+// PlannedCalls is the number of calls a run would make to one API resource,
+// next to the resource's current rate limit
+type PlannedCalls struct {
+	Calls     int       `json:"calls"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Rate limit windows of the GitHub API resources
+const (
+	searchWindow = time.Minute
+	coreWindow   = time.Hour
+)
+
+// PlanContributions runs only the merged PR search for the user and returns
+// the API cost of a full GetContributions run with the same options,
+// checked against the current rate limits.
+func (c *Client) PlanContributions(ctx context.Context, username string) (*FetchPlan, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	for _, filter := range c.filters {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if c.quality != nil {
+		if _, err := newQualityChecker(*c.quality); err != nil {
+			return nil, err
+		}
+	}
+
+	apiClient, err := c.apiClient(username)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("Planning fetch", "user", username)
+	if err := checkUser(ctx, apiClient, username); err != nil {
+		return nil, err
+	}
+	issues, err := c.searchMergedPRs(ctx, apiClient, username)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := apiClient.GetRateLimit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching rate limit: %w", err)
+	}
+
+	plan := c.planCalls(username, issues)
+	plan.Search = withLimit(plan.Search, limits.Resources.Search)
+	plan.Core = withLimit(plan.Core, limits.Resources.Core)
+	plan.GraphQL = withLimit(plan.GraphQL, limits.Resources.GraphQL)
+
+	now := time.Now()
+	plan.EstimatedDuration = max(
+		plan.Search.wait(now, searchWindow),
+		plan.Core.wait(now, coreWindow),
+		plan.GraphQL.wait(now, coreWindow),
+	)
+	plan.FitsBudget = plan.EstimatedDuration == 0
+
+	return plan, nil
+}
+
+// planCalls counts the calls a run would make for the found merged PRs
+func (c *Client) planCalls(username string, issues []github.Issue) *FetchPlan {
+	repoPRs := make(map[string]*Contribution)
+	for _, iss := range issues {
+		owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
+		if err != nil {
+			continue
+		}
+		repoKey := owner + "/" + repo
+		if repoPRs[repoKey] == nil {
+			repoPRs[repoKey] = &Contribution{Repo: repoKey, Owner: owner, RepoName: repo}
+		}
+		repoPRs[repoKey].PRsMerged++
+	}
+
+	repos := make([]Contribution, 0, len(repoPRs))
+	for _, contrib := range repoPRs {
+		repos = append(repos, *contrib)
+	}
+	repos = c.applyRepoFilters(repos)
+
+	prs := len(issues)
+	plan := &FetchPlan{Username: username, PRs: prs, Repos: len(repos)}
+
+	// Search: the merged PR pages, then one page per optional search
+	plan.Search.Calls = pages(prs)
+	if c.includePRStates {
+		plan.Search.Calls += 2
+	}
+	if c.includeReviews {
+		plan.Search.Calls++
+	}
+	if c.includeIssues {
+		plan.Search.Calls++
+	}
+	if c.includeCoAuthored {
+		plan.Search.Calls += 2
+	}
+
+	// Core: the user lookup, per-PR details and quality checks, then
+	// repository metadata
+	plan.Core.Calls = 1
+	if c.includeLOC || (c.quality != nil && c.quality.needsPRDetails()) {
+		plan.Core.Calls += prs
+	}
+	if c.quality != nil && c.quality.ExcludeDocsOnly {
+		plan.Core.Calls += prs
+	}
+	if c.quality != nil && c.quality.ExcludeBotCoAuthored {
+		plan.Core.Calls += prs
+	}
+	plan.Core.Calls += len(repos)
+
+	// GraphQL
+	if !c.includeLOC && c.includeCommitCounts && prs > 0 {
+		plan.GraphQL.Calls += pages(prs)
+	}
+	if c.includeClosedIssues {
+		plan.GraphQL.Calls += prs
+	}
+	if c.includeDiscussions {
+		plan.GraphQL.Calls++
+	}
+
+	return plan
+}
+
+// pages returns the number of 100-item pages needed for n results, at least one
+func pages(n int) int {
+	return max(1, (n+99)/100)
+}
+
+func withLimit(p PlannedCalls, limit github.RateLimit) PlannedCalls {
+	p.Limit = limit.Limit
+	p.Remaining = limit.Remaining
+	p.Reset = time.Unix(limit.Reset, 0)
+	return p
+}
+
+// wait returns how long the calls would wait for rate limit resets: until
+// the next reset, plus a full window for every further limit's worth of calls
+func (p PlannedCalls) wait(now time.Time, window time.Duration) time.Duration {
+	if p.Calls <= p.Remaining || p.Limit <= 0 {
+		return 0
+	}
+
+	windows := (p.Calls - p.Remaining + p.Limit - 1) / p.Limit
+	untilReset := max(0, p.Reset.Sub(now))
+	return untilReset + time.Duration(windows-1)*window
+}
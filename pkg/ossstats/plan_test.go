@@ -0,0 +1,177 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestPlanContributions(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+
+	// 50 PRs: 30 in owner/a, 20 in owner/b
+	var issues []github.Issue
+	for i := range 50 {
+		repo := "a"
+		if i >= 30 {
+			repo = "b"
+		}
+		issues = append(issues, github.Issue{
+			Number:        i + 1,
+			RepositoryURL: "https://api.github.com/repos/owner/" + repo,
+			PullRequest:   &github.PullRequestRef{MergedAt: &at},
+		})
+	}
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		coreRemaining int
+		wantSearch    int
+		wantCore      int
+		wantGraphQL   int
+		wantFits      bool
+	}{
+		{
+			name:          "defaults",
+			coreRemaining: 5000,
+			wantSearch:    1,
+			wantCore:      3,
+			wantFits:      true,
+		},
+		{
+			name:          "LOC and PR states",
+			opts:          []Option{WithLOC(true), WithPRStates(true)},
+			coreRemaining: 5000,
+			wantSearch:    3,
+			wantCore:      53,
+			wantFits:      true,
+		},
+		{
+			name:          "commit counts and closed issues",
+			opts:          []Option{WithCommitCounts(true), WithClosedIssues(true)},
+			coreRemaining: 5000,
+			wantSearch:    1,
+			wantCore:      3,
+			wantGraphQL:   51,
+			wantFits:      true,
+		},
+		{
+			name:          "repo filter",
+			opts:          []Option{WithFilter(Filter{ExcludeRepos: []string{"owner/b"}})},
+			coreRemaining: 5000,
+			wantSearch:    1,
+			wantCore:      2,
+			wantFits:      true,
+		},
+		{
+			// 53 calls with 10 left: waits for the reset, then fits in the next window
+			name:          "over budget",
+			opts:          []Option{WithLOC(true)},
+			coreRemaining: 10,
+			wantSearch:    1,
+			wantCore:      53,
+			wantFits:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/search/issues"):
+					json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: len(issues), Items: issues})
+				case r.URL.Path == "/rate_limit":
+					limit := github.RateLimit{Limit: 5000, Remaining: 5000, Reset: reset.Unix()}
+					core := limit
+					core.Remaining = tt.coreRemaining
+					json.NewEncoder(w).Encode(github.RateLimitResponse{Resources: github.RateLimitResources{
+						Core:    core,
+						Search:  github.RateLimit{Limit: 30, Remaining: 30, Reset: reset.Unix()},
+						GraphQL: limit,
+					}})
+				default:
+					t.Errorf("Unexpected request during planning: %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client := New(append([]Option{WithToken("test-token")}, tt.opts...)...)
+			client.httpClient.Transport = &mockTransport{server: server}
+
+			plan, err := client.PlanContributions(context.Background(), "testuser")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if plan.PRs != 50 {
+				t.Errorf("PRs = %d, want 50", plan.PRs)
+			}
+			if plan.Search.Calls != tt.wantSearch {
+				t.Errorf("Search.Calls = %d, want %d", plan.Search.Calls, tt.wantSearch)
+			}
+			if plan.Core.Calls != tt.wantCore {
+				t.Errorf("Core.Calls = %d, want %d", plan.Core.Calls, tt.wantCore)
+			}
+			if plan.GraphQL.Calls != tt.wantGraphQL {
+				t.Errorf("GraphQL.Calls = %d, want %d", plan.GraphQL.Calls, tt.wantGraphQL)
+			}
+			if plan.Core.Remaining != tt.coreRemaining || !plan.Core.Reset.Equal(reset) {
+				t.Errorf("Core = %+v, want remaining %d, reset %v", plan.Core, tt.coreRemaining, reset)
+			}
+			if plan.FitsBudget != tt.wantFits {
+				t.Errorf("FitsBudget = %v, want %v", plan.FitsBudget, tt.wantFits)
+			}
+
+			var wantDuration time.Duration
+			if !tt.wantFits {
+				wantDuration = time.Until(reset)
+			}
+			if diff := plan.EstimatedDuration - wantDuration; diff < -time.Second || diff > time.Second {
+				t.Errorf("EstimatedDuration = %v, want about %v", plan.EstimatedDuration, wantDuration)
+			}
+		})
+	}
+}
+
+func TestPlannedCallsWait(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name  string
+		calls PlannedCalls
+		want  time.Duration
+	}{
+		{"fits", PlannedCalls{Calls: 100, Limit: 5000, Remaining: 100, Reset: reset}, 0},
+		{"next window", PlannedCalls{Calls: 200, Limit: 5000, Remaining: 100, Reset: reset}, 10 * time.Minute},
+		{"two more windows", PlannedCalls{Calls: 10100, Limit: 5000, Remaining: 0, Reset: reset}, 10*time.Minute + 2*time.Hour},
+		{"reset passed", PlannedCalls{Calls: 200, Limit: 5000, Remaining: 100, Reset: now.Add(-time.Minute)}, 0},
+		{"no limit reported", PlannedCalls{Calls: 200}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calls.wait(now, coreWindow); got != tt.want {
+				t.Errorf("wait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPages(t *testing.T) {
+	for n, want := range map[int]int{0: 1, 1: 1, 100: 1, 101: 2, 250: 3} {
+		if got := pages(n); got != want {
+			t.Errorf("pages(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ossstats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// EventKind identifies what a progress Event reports
+type EventKind string
+
+const (
+	// EventPhase: a run entered Event.Phase. Total is the number of items
+	// the phase works through, when known.
+	EventPhase EventKind = "phase"
+
+	// EventSearchPage: a search results page was fetched. Done is the number
+	// of results so far, Total the number the search will return.
+	EventSearchPage EventKind = "search_page"
+
+	// EventPRFetched and EventPRFailed: a merged PR was processed, or its
+	// details could not be fetched (Err). Done counts both, out of Total.
+	EventPRFetched EventKind = "pr_fetched"
+	EventPRFailed  EventKind = "pr_failed"
+
+	// EventRepoEnriched: repository metadata was fetched, or failed (Err).
+	// Done counts both, out of Total.
+	EventRepoEnriched EventKind = "repo_enriched"
+
+	// EventRateLimitWait and EventRateLimitResume: requests to Resource
+	// paused until its rate limit resets at ResetAt, and resumed. Rate
+	// limits are shared by every run of a client, so Username is empty.
+	EventRateLimitWait   EventKind = "rate_limit_wait"
+	EventRateLimitResume EventKind = "rate_limit_resume"
+)
+
+// Phase is a step of GetContributions, in the order they run. Optional
+// phases only run when their options are enabled.
+type Phase string
+
+const (
+	PhaseSearch       Phase = "search"        // Searching merged PRs
+	PhaseCommitCounts Phase = "commit_counts" // Optional, WithCommitCounts
+	PhaseActivity     Phase = "activity"      // Optional contribution kinds
+	PhasePRDetails    Phase = "pr_details"    // Processing each merged PR
+	PhaseRepoMetadata Phase = "repo_metadata" // Fetching repository metadata
+	PhaseDone         Phase = "done"          // The run finished, successfully or not
+)
+
+// Event is a progress update of a running GetContributions call (see WithProgress).
+// Fields that don't apply to the event kind are left empty.
+type Event struct {
+	Kind     EventKind
+	Time     time.Time
+	Username string
+	Phase    Phase // Phase of the run the event happened in
+
+	Done  int
+	Total int // 0 when unknown
+
+	Page     int       // EventSearchPage
+	Repo     string    // owner/repo
+	PR       int       // PR number
+	Resource string    // Rate limit resource: core, search or graphql
+	ResetAt  time.Time // Rate limit reset
+	Err      error
+}
+
+// reporter delivers progress events to the WithProgress callback, one at a
+// time, in the order they happen.
+type reporter struct {
+	mu sync.Mutex
+	fn func(Event)
+}
+
+func (r *reporter) emit(e Event) {
+	if r == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fn(e)
+}
+
+// rateLimitWait reports the scheduler's rate limit pauses
+func (r *reporter) rateLimitWait(resource github.Resource, reset time.Time, waiting bool) {
+	kind := EventRateLimitResume
+	if waiting {
+		kind = EventRateLimitWait
+	}
+	r.emit(Event{Kind: kind, Resource: string(resource), ResetAt: reset})
+}
+
+// runProgress tracks the progress of one GetContributions call
+type runProgress struct {
+	reporter *reporter
+	username string
+
+	mu    sync.Mutex
+	phase Phase
+}
+
+type runProgressKey struct{}
+
+// withRunProgress returns a context carrying the progress of a run for
+// username, when the client reports progress
+func (c *Client) withRunProgress(ctx context.Context, username string) context.Context {
+	if c.progress == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, runProgressKey{}, &runProgress{reporter: c.progress, username: username})
+}
+
+// report sends e as an event of the run in ctx, if any
+func report(ctx context.Context, e Event) {
+	run, ok := ctx.Value(runProgressKey{}).(*runProgress)
+	if !ok {
+		return
+	}
+
+	run.mu.Lock()
+	if e.Kind == EventPhase {
+		run.phase = e.Phase
+	}
+	e.Phase = run.phase
+	run.mu.Unlock()
+
+	e.Username = run.username
+	run.reporter.emit(e)
+}
+
+// startPhase reports that the run in ctx entered phase
+func startPhase(ctx context.Context, phase Phase, total int) {
+	report(ctx, Event{Kind: EventPhase, Phase: phase, Total: total})
+}
@@ -0,0 +1,122 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestGetContributionsReportsProgress(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := []github.Issue{
+		{Number: 1, RepositoryURL: "https://api.github.com/repos/owner/a", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+		{Number: 2, RepositoryURL: "https://api.github.com/repos/owner/a", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+		{Number: 3, RepositoryURL: "https://api.github.com/repos/owner/b", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: 3, Items: issues})
+		case r.URL.Path == "/repos/owner/a/pulls/2":
+			w.WriteHeader(http.StatusInternalServerError)
+		case strings.Contains(r.URL.Path, "/pulls/"):
+			json.NewEncoder(w).Encode(github.PullRequest{Additions: 1, Commits: 1})
+		default:
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+		}
+	}))
+	defer server.Close()
+
+	var events []Event
+	client := New(WithToken("test-token"), WithLOC(true), WithProgress(func(e Event) {
+		events = append(events, e)
+	}))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	client.GetContributions(context.Background(), "testuser")
+
+	var phases []Phase
+	var prs, failed, repos int
+	for _, e := range events {
+		if e.Username != "testuser" {
+			t.Errorf("event %s has username %q", e.Kind, e.Username)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("event %s has no time", e.Kind)
+		}
+
+		switch e.Kind {
+		case EventPhase:
+			phases = append(phases, e.Phase)
+		case EventSearchPage:
+			if e.Phase != PhaseSearch || e.Page != 1 || e.Done != 3 || e.Total != 3 {
+				t.Errorf("search page event = %+v", e)
+			}
+		case EventPRFetched, EventPRFailed:
+			prs++
+			if e.Phase != PhasePRDetails || e.Total != 3 || e.Done < 1 || e.Done > 3 {
+				t.Errorf("PR event = %+v", e)
+			}
+			if e.Kind == EventPRFailed {
+				failed++
+				if e.Repo != "owner/a" || e.PR != 2 || e.Err == nil {
+					t.Errorf("failed PR event = %+v", e)
+				}
+			}
+		case EventRepoEnriched:
+			repos++
+			if e.Phase != PhaseRepoMetadata || e.Total != 2 || e.Err != nil {
+				t.Errorf("repo event = %+v", e)
+			}
+		}
+	}
+
+	wantPhases := []Phase{PhaseSearch, PhasePRDetails, PhaseRepoMetadata, PhaseDone}
+	if !slices.Equal(phases, wantPhases) {
+		t.Errorf("phases = %v, want %v", phases, wantPhases)
+	}
+	if prs != 3 || failed != 1 {
+		t.Errorf("PR events = %d (%d failed), want 3 (1 failed)", prs, failed)
+	}
+	if repos != 2 {
+		t.Errorf("repo events = %d, want 2", repos)
+	}
+}
+
+func TestReporterRateLimitWait(t *testing.T) {
+	var events []Event
+	r := &reporter{fn: func(e Event) { events = append(events, e) }}
+	reset := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	r.rateLimitWait(github.ResourceSearch, reset, true)
+	r.rateLimitWait(github.ResourceSearch, reset, false)
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if e := events[0]; e.Kind != EventRateLimitWait || e.Resource != "search" || !e.ResetAt.Equal(reset) {
+		t.Errorf("wait event = %+v", e)
+	}
+	if e := events[1]; e.Kind != EventRateLimitResume {
+		t.Errorf("resume event = %+v", e)
+	}
+}
+
+func TestWithProgressNil(t *testing.T) {
+	client := New(WithProgress(nil))
+	if client.progress != nil {
+		t.Error("WithProgress(nil) should disable progress events")
+	}
+
+	// Reporting without a run in the context is a no-op
+	report(context.Background(), Event{Kind: EventPhase})
+}
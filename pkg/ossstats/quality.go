@@ -0,0 +1,206 @@
+package ossstats
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// Reasons a PR is dropped by the quality filter
+const (
+	ExcludeReasonTooSmall      = "too-small"
+	ExcludeReasonDocsOnly      = "docs-only"
+	ExcludeReasonTitlePattern  = "title-pattern"
+	ExcludeReasonBotCoAuthored = "bot-co-authored"
+)
+
+// DependencyBumpPatterns match the titles of common automated dependency
+// updates (Dependabot, Renovate, manual "bump x from a to b")
+var DependencyBumpPatterns = []string{
+	`(?i)^bump \S+ from \S+ to \S+`,
+	`(?i)^(chore|build|fix)\(deps(-dev)?\)`,
+	`(?i)^update dependency `,
+	`(?i)^(chore|build): (bump|update) (dependency|dependencies|deps)\b`,
+}
+
+// QualityFilter drops "noise" PRs before they are counted. Dropped PRs are
+// reported in Stats.ExcludedPRs instead of disappearing silently.
+//
+// Example:
+//
+//	ossstats.WithQualityFilter(ossstats.QualityFilter{
+//	    MinChangedLines:      5,
+//	    ExcludeDocsOnly:      true,
+//	    ExcludeTitlePatterns: ossstats.DependencyBumpPatterns,
+//	    ExcludeBotCoAuthored: true,
+//	})
+type QualityFilter struct {
+	MinChangedLines      int      // Drop PRs with fewer added + deleted lines
+	ExcludeDocsOnly      bool     // Drop PRs that only touch docs/README files
+	ExcludeTitlePatterns []string // Drop PRs whose title matches any regular expression
+	ExcludeBotCoAuthored bool     // Drop PRs with commits authored or co-authored by bots
+}
+
+// ExcludedPR is a merged PR left out of the totals by the quality filter
+type ExcludedPR struct {
+	Repo   string `json:"repo"`   // Full repo name (owner/repo)
+	Number int    `json:"number"` // PR number
+	Title  string `json:"title"`  // PR title
+	URL    string `json:"url"`    // PR URL
+	Reason string `json:"reason"` // One of the ExcludeReason* values
+}
+
+// Validate reports malformed title patterns
+func (q QualityFilter) Validate() error {
+	_, err := q.compileTitlePatterns()
+	return err
+}
+
+func (q QualityFilter) compileTitlePatterns() ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(q.ExcludeTitlePatterns))
+	for _, pattern := range q.ExcludeTitlePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// needsPRDetails reports whether the filter uses additions/deletions
+func (q QualityFilter) needsPRDetails() bool {
+	return q.MinChangedLines > 0
+}
+
+// qualityChecker applies a QualityFilter, fetching only the PR data its
+// rules need. Checks are ordered from cheapest to most expensive.
+type qualityChecker struct {
+	filter        QualityFilter
+	titlePatterns []*regexp.Regexp
+}
+
+func newQualityChecker(q QualityFilter) (*qualityChecker, error) {
+	patterns, err := q.compileTitlePatterns()
+	if err != nil {
+		return nil, err
+	}
+	return &qualityChecker{filter: q, titlePatterns: patterns}, nil
+}
+
+// check returns the reason the PR should be excluded, or "" to keep it.
+// pr may be nil when PR details weren't fetched.
+func (qc *qualityChecker) check(
+	ctx context.Context,
+	api github.GithubAPI,
+	owner, repo string,
+	iss github.Issue,
+	pr *github.PullRequest,
+) (string, error) {
+	for _, re := range qc.titlePatterns {
+		if re.MatchString(iss.Title) {
+			return ExcludeReasonTitlePattern, nil
+		}
+	}
+
+	if pr != nil && qc.filter.MinChangedLines > 0 && pr.Additions+pr.Deletions < qc.filter.MinChangedLines {
+		return ExcludeReasonTooSmall, nil
+	}
+
+	if qc.filter.ExcludeDocsOnly {
+		files, _, err := api.ListPullRequestFiles(ctx, owner, repo, iss.Number)
+		if err != nil {
+			return "", fmt.Errorf("fetching files of PR %s/%s#%d: %w", owner, repo, iss.Number, err)
+		}
+		// Only the first page is listed, so a full page is never treated as docs-only
+		if len(files) > 0 && len(files) < 100 && allDocFiles(files) {
+			return ExcludeReasonDocsOnly, nil
+		}
+	}
+
+	if qc.filter.ExcludeBotCoAuthored {
+		commits, _, err := api.ListPullRequestCommits(ctx, owner, repo, iss.Number)
+		if err != nil {
+			return "", fmt.Errorf("fetching commits of PR %s/%s#%d: %w", owner, repo, iss.Number, err)
+		}
+		if hasBotCommit(commits) {
+			return ExcludeReasonBotCoAuthored, nil
+		}
+	}
+
+	return "", nil
+}
+
+// docExtensions are the file extensions treated as documentation. Plain .txt
+// is left out: requirements.txt or CMakeLists.txt are code, not docs.
+var docExtensions = []string{".md", ".markdown", ".mdx", ".rst", ".adoc"}
+
+// docFileNames are base names (without extension) treated as documentation,
+// whatever their extension, e.g. LICENSE.txt
+var docFileNames = []string{"readme", "changelog", "contributing", "authors", "license", "copying", "notice", "code_of_conduct"}
+
+func allDocFiles(files []github.PullRequestFile) bool {
+	for _, file := range files {
+		if !isDocFile(file.Filename) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDocFile reports whether the file is documentation: a doc extension, a
+// well-known doc name such as README, or a path under a docs/ or doc/ directory
+func isDocFile(filename string) bool {
+	name := strings.ToLower(filename)
+
+	if strings.HasPrefix(name, "docs/") || strings.HasPrefix(name, "doc/") || strings.Contains(name, "/docs/") || strings.Contains(name, "/doc/") {
+		return true
+	}
+
+	base := path.Base(name)
+	ext := path.Ext(base)
+	for _, docExt := range docExtensions {
+		if ext == docExt {
+			return true
+		}
+	}
+	for _, docName := range docFileNames {
+		if strings.TrimSuffix(base, ext) == docName {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBotCommit reports whether any commit is authored by a bot account or
+// carries a Co-authored-by trailer naming a bot
+func hasBotCommit(commits []github.Commit) bool {
+	for _, commit := range commits {
+		if commit.Author != nil && isBotLogin(commit.Author.Login, commit.Author.Type) {
+			return true
+		}
+		for _, line := range strings.Split(commit.Commit.Message, "\n") {
+			coAuthor, ok := cutPrefixFold(strings.TrimSpace(line), "co-authored-by:")
+			if ok && strings.Contains(strings.ToLower(coAuthor), "[bot]") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isBotLogin(login, accountType string) bool {
+	return accountType == "Bot" || strings.HasSuffix(strings.ToLower(login), "[bot]")
+}
+
+// cutPrefixFold is strings.CutPrefix, ignoring case
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,218 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestDependencyBumpPatterns(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Bump lodash from 4.17.20 to 4.17.21", true},
+		{"build(deps): bump golang.org/x/net from 0.1.0 to 0.7.0", true},
+		{"chore(deps-dev): update eslint", true},
+		{"fix(deps): update module github.com/spf13/cobra to v1.8.0", true},
+		{"Update dependency react to v18", true},
+		{"chore: bump dependencies", true},
+		{"Add bump allocator", false},
+		{"Update README with install steps", false},
+		{"Fix crash when deps are missing", false},
+	}
+
+	for _, tt := range tests {
+		got := false
+		for _, pattern := range DependencyBumpPatterns {
+			if regexp.MustCompile(pattern).MatchString(tt.title) {
+				got = true
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%q matched = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestIsDocFile(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     bool
+	}{
+		{"README.md", true},
+		{"readme", true},
+		{"CHANGELOG", true},
+		{"LICENSE", true},
+		{"docs/setup.html", true},
+		{"website/docs/api.js", true},
+		{"guide.rst", true},
+		{"LICENSE.txt", true},
+		{"docs/notes.txt", true},
+		{"src/doc/intro.txt", true},
+		{"requirements.txt", false},
+		{"CMakeLists.txt", false},
+		{"main.go", false},
+		{"src/docs.go", false},
+		{"Makefile", false},
+	}
+
+	for _, tt := range tests {
+		if got := isDocFile(tt.filename); got != tt.want {
+			t.Errorf("isDocFile(%q) = %v, want %v", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestHasBotCommit(t *testing.T) {
+	human := github.Commit{
+		Commit: github.CommitDetails{Message: "Fix parser\n\nCo-authored-by: Jane <jane@example.com>"},
+		Author: &github.User{Login: "jane", Type: "User"},
+	}
+	coAuthored := github.Commit{
+		Commit: github.CommitDetails{Message: "Fix lint\n\nco-authored-by: pre-commit-ci[bot] <66853113+pre-commit-ci[bot]@users.noreply.github.com>"},
+	}
+	botAuthor := github.Commit{Author: &github.User{Login: "dependabot[bot]", Type: "Bot"}}
+
+	if hasBotCommit([]github.Commit{human}) {
+		t.Error("human commits should not be reported as bot commits")
+	}
+	if !hasBotCommit([]github.Commit{human, coAuthored}) {
+		t.Error("Co-authored-by bot trailer should be detected")
+	}
+	if !hasBotCommit([]github.Commit{botAuthor}) {
+		t.Error("bot author should be detected")
+	}
+}
+
+func TestQualityFilterValidate(t *testing.T) {
+	if err := (QualityFilter{ExcludeTitlePatterns: DependencyBumpPatterns}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := (QualityFilter{ExcludeTitlePatterns: []string{"("}}).Validate(); err == nil {
+		t.Error("Validate() should reject a malformed pattern")
+	}
+}
+
+func TestGetContributionsWithQualityFilter(t *testing.T) {
+	mergedAt := time.Now().UTC()
+	issue := func(number int, title string) github.Issue {
+		return github.Issue{
+			Number:        number,
+			Title:         title,
+			RepositoryURL: "https://api.github.com/repos/owner/repo",
+			HTMLURL:       fmt.Sprintf("https://github.com/owner/repo/pull/%d", number),
+			PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 5,
+				Items: []github.Issue{
+					issue(1, "Add retry support"),
+					issue(2, "Bump lodash from 4.17.20 to 4.17.21"),
+					issue(3, "Fix typo"),
+					issue(4, "Improve install docs"),
+					issue(5, "Apply formatting"),
+				},
+			})
+
+		case strings.HasSuffix(r.URL.Path, "/files"):
+			files := []github.PullRequestFile{{Filename: "main.go"}}
+			if strings.Contains(r.URL.Path, "/pulls/4/") {
+				files = []github.PullRequestFile{{Filename: "README.md"}, {Filename: "docs/install.md"}}
+			}
+			json.NewEncoder(w).Encode(files)
+
+		case strings.HasSuffix(r.URL.Path, "/commits"):
+			message := "Change things"
+			if strings.Contains(r.URL.Path, "/pulls/5/") {
+				message += "\n\nCo-authored-by: github-actions[bot] <41898282+github-actions[bot]@users.noreply.github.com>"
+			}
+			json.NewEncoder(w).Encode([]github.Commit{{Commit: github.CommitDetails{Message: message}}})
+
+		case strings.Contains(r.URL.Path, "/pulls/"):
+			pr := github.PullRequest{Additions: 40, Deletions: 10, Commits: 1, ChangedFiles: 2}
+			if strings.HasSuffix(r.URL.Path, "/pulls/3") {
+				pr = github.PullRequest{Additions: 1, Deletions: 1, Commits: 1, ChangedFiles: 1}
+			}
+			json.NewEncoder(w).Encode(pr)
+
+		case strings.HasPrefix(r.URL.Path, "/repos/"):
+			json.NewEncoder(w).Encode(github.Repository{StargazersCount: 10})
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(
+		WithToken("test-token"),
+		WithQualityFilter(QualityFilter{
+			MinChangedLines:      5,
+			ExcludeDocsOnly:      true,
+			ExcludeTitlePatterns: DependencyBumpPatterns,
+			ExcludeBotCoAuthored: true,
+		}),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stats.Summary.TotalPRsMerged != 1 {
+		t.Errorf("TotalPRsMerged = %d, want 1", stats.Summary.TotalPRsMerged)
+	}
+	if stats.Summary.TotalExcluded != 4 {
+		t.Errorf("TotalExcluded = %d, want 4", stats.Summary.TotalExcluded)
+	}
+
+	wantReasons := map[int]string{
+		2: ExcludeReasonTitlePattern,
+		3: ExcludeReasonTooSmall,
+		4: ExcludeReasonDocsOnly,
+		5: ExcludeReasonBotCoAuthored,
+	}
+	if len(stats.ExcludedPRs) != len(wantReasons) {
+		t.Fatalf("ExcludedPRs = %+v, want %d entries", stats.ExcludedPRs, len(wantReasons))
+	}
+	for i, pr := range stats.ExcludedPRs {
+		if pr.Number != i+2 {
+			t.Errorf("ExcludedPRs[%d].Number = %d, want sorted by number", i, pr.Number)
+		}
+		if pr.Reason != wantReasons[pr.Number] {
+			t.Errorf("PR #%d reason = %q, want %q", pr.Number, pr.Reason, wantReasons[pr.Number])
+		}
+		if pr.Repo != "owner/repo" || pr.Title == "" {
+			t.Errorf("PR #%d = %+v, want repo and title", pr.Number, pr)
+		}
+	}
+}
+
+func TestGetContributionsWithoutQualityFilter(t *testing.T) {
+	client := New(WithDebug(true))
+
+	stats, err := client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.ExcludedPRs != nil || stats.Summary.TotalExcluded != 0 {
+		t.Errorf("nothing should be excluded without a quality filter, got %+v", stats.ExcludedPRs)
+	}
+}
@@ -0,0 +1,103 @@
+package ossstats
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/replay"
+)
+
+// newReplayClient returns a client answered by the recorded responses in
+// testdata/replay_<name>.json
+func newReplayClient(t *testing.T, name string, opts ...Option) *Client {
+	t.Helper()
+
+	fixture, err := replay.Load(filepath.Join("testdata", "replay_"+name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := replay.NewReplayer(fixture, "octocat")
+	return New(append(opts, WithToken("test-token"), WithHTTPClient(&http.Client{Transport: transport}))...)
+}
+
+func TestReplayPagination(t *testing.T) {
+	client := newReplayClient(t, "pagination")
+
+	stats, err := client.GetContributions(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+
+	if stats.Summary.TotalPRsMerged != 101 {
+		t.Errorf("TotalPRsMerged = %d, want 101 across both pages", stats.Summary.TotalPRsMerged)
+	}
+	if len(stats.Contributions) != 1 {
+		t.Fatalf("got %d contributions, want 1", len(stats.Contributions))
+	}
+	if got := stats.Contributions[0]; got.Repo != "acme/widgets" || got.Stars != 1200 {
+		t.Errorf("contribution = %s with %d stars, want acme/widgets with 1200", got.Repo, got.Stars)
+	}
+}
+
+func TestReplayPartialErrors(t *testing.T) {
+	client := newReplayClient(t, "partial_errors", WithLOC(true))
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+
+	var partial *ErrPartialResults
+	if !errors.As(err, &partial) {
+		t.Fatalf("GetContributions() error = %v, want ErrPartialResults", err)
+	}
+	if len(partial.Errors) != 2 {
+		t.Errorf("got %d errors, want 2 (500 and 404): %v", len(partial.Errors), partial.Errors)
+	}
+	if partial.Stats == nil || partial.Stats.Summary.TotalAdditions != 40 {
+		t.Errorf("partial stats should hold the PR that was fetched, got %+v", partial.Stats)
+	}
+}
+
+func TestReplayRateLimited(t *testing.T) {
+	client := newReplayClient(t, "rate_limited", WithTimeout(5*time.Second))
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("GetContributions() error = %v, want ErrRateLimited", err)
+	}
+	if want := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC); !rateLimited.ResetAt.Equal(want) {
+		t.Errorf("ResetAt = %v, want %v", rateLimited.ResetAt, want)
+	}
+}
+
+func TestReplayOtherUser(t *testing.T) {
+	fixture, err := replay.Load(filepath.Join("testdata", "replay_pagination.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := New(WithHTTPClient(&http.Client{Transport: replay.NewReplayer(fixture, "hubot")}))
+
+	stats, err := client.GetContributions(context.Background(), "hubot")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+	if stats.Summary.TotalPRsMerged != 101 {
+		t.Errorf("TotalPRsMerged = %d, want 101", stats.Summary.TotalPRsMerged)
+	}
+}
+
+func TestDebugModeReplays(t *testing.T) {
+	client := New(WithDebug(true), WithLOC(true))
+
+	stats, err := client.GetContributions(context.Background(), "anyone")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+	if stats.Summary.TotalPRsMerged == 0 || stats.Summary.TotalAdditions == 0 {
+		t.Errorf("debug mode should replay recorded contributions, got %+v", stats.Summary)
+	}
+}
@@ -0,0 +1,101 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: 1, Items: []github.Issue{
+				{Number: 7, RepositoryURL: "https://api.github.com/repos/owner/repo", PullRequest: &github.PullRequestRef{MergedAt: &at}},
+			}})
+		case strings.Contains(r.URL.Path, "/pulls/"):
+			json.NewEncoder(w).Encode(github.PullRequest{Additions: 1, Commits: 1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	client := New(
+		WithToken("test-token"),
+		WithLOC(true),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	client.GetContributions(context.Background(), "testuser")
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	root, ok := spans["ossstats.GetContributions"]
+	if !ok {
+		t.Fatalf("no GetContributions span, got %v", spans)
+	}
+	if !hasAttr(root.Attributes(), attribute.String("github.user", "testuser")) {
+		t.Errorf("GetContributions attributes = %v", root.Attributes())
+	}
+
+	for _, name := range []string{"ossstats.searchMergedPRs", "ossstats.fetchPRDetails", "ossstats.enrichWithRepoData"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("no %s span", name)
+			continue
+		}
+		if span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s should be a child of GetContributions", name)
+		}
+	}
+
+	pr, ok := spans["GET /repos/{owner}/{repo}/pulls/{number}"]
+	if !ok {
+		t.Fatalf("no PR request span, got %v", spans)
+	}
+	if pr.Parent().SpanID() != spans["ossstats.fetchPRDetails"].SpanContext().SpanID() {
+		t.Error("PR request should be a child of fetchPRDetails")
+	}
+	for _, want := range []attribute.KeyValue{
+		attribute.String("github.endpoint", "/repos/{owner}/{repo}/pulls/{number}"),
+		attribute.Int("http.response.status_code", http.StatusOK),
+		attribute.Int("http.request.resend_count", 0),
+	} {
+		if !hasAttr(pr.Attributes(), want) {
+			t.Errorf("PR request attributes %v missing %v", pr.Attributes(), want)
+		}
+	}
+
+	repo := spans["GET /repos/{owner}/{repo}"]
+	if repo == nil || repo.Status().Code != codes.Error {
+		t.Errorf("failed repo request span should have an error status")
+	}
+}
+
+func hasAttr(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr.Key == want.Key && attr.Value == want.Value {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package ossstats
 import (
 	"fmt"
 	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
 )
 
 // Stats represents the complete statistics for a GitHub user's
@@ -12,37 +14,82 @@ type Stats struct {
 	GeneratedAt   time.Time      `json:"generatedAt"`
 	Summary       Summary        `json:"summary"`
 	Contributions []Contribution `json:"contributions"`
+	ExcludedPRs   []ExcludedPR   `json:"excludedPRs,omitempty"` // PRs dropped by the quality filter
 }
 
 // Summary contains aggregate statistics across all contributions.
 type Summary struct {
-	TotalProjects  int `json:"totalProjects"`
-	TotalPRsMerged int `json:"totalPRsMerged"`
-	TotalCommits   int `json:"totalCommits"`
-	TotalAdditions int `json:"totalAdditions"`
-	TotalDeletions int `json:"totalDeletions"`
+	TotalProjects  int      `json:"totalProjects"`
+	TotalPRsMerged int      `json:"totalPRsMerged"`
+	TotalCommits   *int     `json:"totalCommits"` // nil (null) when commit counts weren't fetched
+	TotalAdditions int      `json:"totalAdditions"`
+	TotalDeletions int      `json:"totalDeletions"`
+	TotalExcluded  int      `json:"totalExcluded,omitempty"` // Merged PRs dropped by the quality filter
+	Accuracy       Accuracy `json:"accuracy"`                // How exact the commit and line totals are
+
+	// Optional contribution kinds, zero unless enabled on the client
+	TotalReviews           int `json:"totalReviews,omitempty"`
+	TotalIssuesOpened      int `json:"totalIssuesOpened,omitempty"`
+	TotalIssuesClosed      int `json:"totalIssuesClosed,omitempty"`
+	TotalDiscussionAnswers int `json:"totalDiscussionAnswers,omitempty"`
+
+	// PR states, zero unless enabled with WithPRStates
+	TotalPRsOpen           int      `json:"totalPRsOpen,omitempty"`
+	TotalPRsClosedUnmerged int      `json:"totalPRsClosedUnmerged,omitempty"`
+	AcceptanceRate         *float64 `json:"acceptanceRate,omitempty"` // Merged / (merged + closed unmerged), 0 to 1
+}
+
+// Accuracy values of a metric
+const (
+	AccuracyExact     = "exact"     // Counted from the API
+	AccuracyEstimated = "estimated" // Partly counted, partly assumed
+	AccuracyUnknown   = "unknown"   // Not fetched; reported as null or zero
+)
+
+// Accuracy records how the summary metrics that need extra API calls were
+// obtained. Empty values (stats from older versions) mean exact.
+type Accuracy struct {
+	Commits string `json:"commits"`
+	Lines   string `json:"lines"` // Additions and deletions
 }
 
 // Contribution represents a user's contribution to a single external repository.
 type Contribution struct {
-	Repo              string    `json:"repo"`              // Full repo name (owner/repo)
-	Owner             string    `json:"owner"`             // Repository owner
-	RepoName          string    `json:"repoName"`          // Repository name
-	Description       string    `json:"description"`       // Repository description
-	RepoURL           string    `json:"repoURL"`           // Full GitHub URL
-	Stars             int       `json:"stars"`             // Repository star count
-	PRsMerged         int       `json:"prsMerged"`         // Number of merged PRs
-	Commits           int       `json:"commits"`           // Total commits across PRs
-	Additions         int       `json:"additions"`         // Lines added
-	Deletions         int       `json:"deletions"`         // Lines deleted
-	FirstContribution time.Time `json:"firstContribution"` // First PR merged date
-	LastContribution  time.Time `json:"lastContribution"`  // Most recent PR merged date
+	Repo              string    `json:"repo"`                        // Full repo name (owner/repo)
+	Owner             string    `json:"owner"`                       // Repository owner
+	RepoName          string    `json:"repoName"`                    // Repository name
+	Description       string    `json:"description"`                 // Repository description
+	RepoURL           string    `json:"repoURL"`                     // Full GitHub URL
+	Stars             int       `json:"stars"`                       // Repository star count
+	Language          string    `json:"language,omitempty"`          // Primary repository language
+	Topics            []string  `json:"topics,omitempty"`            // Repository topics
+	Archived          bool      `json:"archived,omitempty"`          // Repository is archived
+	Fork              bool      `json:"fork,omitempty"`              // Repository is a fork
+	PRsMerged         int       `json:"prsMerged"`                   // Number of merged PRs
+	PRsOpen           int       `json:"prsOpen,omitempty"`           // Open PRs (WithPRStates)
+	PRsClosedUnmerged int       `json:"prsClosedUnmerged,omitempty"` // PRs closed without merging (WithPRStates)
+	AcceptanceRate    *float64  `json:"acceptanceRate,omitempty"`    // Merged / (merged + closed unmerged), 0 to 1 (WithPRStates)
+	Commits           *int      `json:"commits"`                     // Total commits across PRs, nil (null) when unknown
+	Additions         int       `json:"additions"`                   // Lines added
+	Deletions         int       `json:"deletions"`                   // Lines deleted
+	Reviews           int       `json:"reviews,omitempty"`           // PRs reviewed (WithReviews)
+	IssuesOpened      int       `json:"issuesOpened,omitempty"`      // Issues opened (WithIssues)
+	IssuesClosed      int       `json:"issuesClosed,omitempty"`      // Issues closed by merged PRs (WithClosedIssues)
+	DiscussionAnswers int       `json:"discussionAnswers,omitempty"` // Accepted discussion answers (WithDiscussionAnswers)
+	FirstContribution time.Time `json:"firstContribution"`           // First PR merged (or other contribution) date
+	LastContribution  time.Time `json:"lastContribution"`            // Most recent contribution date
 }
 
+// APIError is an error response of the GitHub API, with its status code,
+// message, documentation URL and request ID. The typed errors below wrap it
+// when the failure came from a response; use errors.As to get it.
+type APIError = github.APIError
+
 // ErrRateLimited indicates that GitHub's rate limit has been exceeded.
 type ErrRateLimited struct {
 	ResetAt time.Time
 	Message string
+	Err     error // The failed request's error, usually an *APIError
 }
 
 func (e *ErrRateLimited) Error() string {
@@ -52,9 +99,30 @@ func (e *ErrRateLimited) Error() string {
 	return fmt.Sprintf("rate limited (resets at %s)", e.ResetAt.Format(time.RFC3339))
 }
 
+func (e *ErrRateLimited) Unwrap() error {
+	return e.Err
+}
+
+// ErrSecondaryRateLimit indicates that GitHub rejected a burst of requests,
+// whatever rate limit budget is left. Retrying after RetryAfter (a minute
+// when GitHub doesn't say) usually succeeds.
+type ErrSecondaryRateLimit struct {
+	RetryAfter time.Duration
+	Err        error // The failed request's error, usually an *APIError
+}
+
+func (e *ErrSecondaryRateLimit) Error() string {
+	return fmt.Sprintf("secondary rate limit exceeded (retry after %s)", e.RetryAfter)
+}
+
+func (e *ErrSecondaryRateLimit) Unwrap() error {
+	return e.Err
+}
+
 // ErrAuthentication indicates an authentication failure with the GitHub API.
 type ErrAuthentication struct {
 	Message string
+	Err     error // The failed request's error, usually an *APIError
 }
 
 func (e *ErrAuthentication) Error() string {
@@ -64,17 +132,44 @@ func (e *ErrAuthentication) Error() string {
 	return "authentication failed"
 }
 
-// ErrNotFound indicates that the specified GitHub user was not found.
-type ErrNotFound struct {
+func (e *ErrAuthentication) Unwrap() error {
+	return e.Err
+}
+
+// ErrForbidden indicates that the token may not access the requested data,
+// e.g. for a missing scope or an organization's SAML enforcement.
+type ErrForbidden struct {
+	Err error // The failed request's error, usually an *APIError
+}
+
+func (e *ErrForbidden) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("access forbidden: %v", e.Err)
+	}
+	return "access forbidden"
+}
+
+func (e *ErrForbidden) Unwrap() error {
+	return e.Err
+}
+
+// ErrUserNotFound indicates that the specified GitHub user does not exist.
+type ErrUserNotFound struct {
 	Username string
 }
 
-func (e *ErrNotFound) Error() string {
+func (e *ErrUserNotFound) Error() string {
 	return fmt.Sprintf("user not found: %s", e.Username)
 }
 
+// ErrNotFound indicates that the specified GitHub user was not found.
+//
+// Deprecated: Use ErrUserNotFound.
+type ErrNotFound = ErrUserNotFound
+
 // ErrPartialResults indicates that the operation completed with partial results
 // due to errors encountered during processing (e.g., rate limiting).
+// errors.Is and errors.As look into Errors.
 type ErrPartialResults struct {
 	Stats   *Stats
 	Errors  []error
@@ -87,3 +182,15 @@ func (e *ErrPartialResults) Error() string {
 	}
 	return fmt.Sprintf("partial results (%d errors encountered)", len(e.Errors))
 }
+
+func (e *ErrPartialResults) Unwrap() []error {
+	return e.Errors
+}
+
+// addCommits adds n to the commit count, starting it at zero if unknown
+func (c *Contribution) addCommits(n int) {
+	if c.Commits == nil {
+		c.Commits = new(int)
+	}
+	*c.Commits += n
+}
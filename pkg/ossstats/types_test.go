@@ -3,6 +3,9 @@ package ossstats
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -15,7 +18,7 @@ func TestStatsJSONMarshal(t *testing.T) {
 		Summary: Summary{
 			TotalProjects:  5,
 			TotalPRsMerged: 10,
-			TotalCommits:   25,
+			TotalCommits:   intPtr(25),
 			TotalAdditions: 500,
 			TotalDeletions: 200,
 		},
@@ -28,7 +31,7 @@ func TestStatsJSONMarshal(t *testing.T) {
 				RepoURL:           "https://github.com/owner/repo",
 				Stars:             100,
 				PRsMerged:         2,
-				Commits:           5,
+				Commits:           intPtr(5),
 				Additions:         50,
 				Deletions:         20,
 				FirstContribution: now,
@@ -232,8 +235,8 @@ func TestSummaryZeroValues(t *testing.T) {
 		t.Errorf("TotalPRsMerged = %d, want 0", summary.TotalPRsMerged)
 	}
 
-	if summary.TotalCommits != 0 {
-		t.Errorf("TotalCommits = %d, want 0", summary.TotalCommits)
+	if summary.TotalCommits != nil {
+		t.Errorf("TotalCommits = %s, want nil", fmtInt(summary.TotalCommits))
 	}
 
 	if summary.TotalAdditions != 0 {
@@ -255,7 +258,7 @@ func TestContributionJSONMarshal(t *testing.T) {
 		RepoURL:           "https://github.com/owner/repo",
 		Stars:             1000,
 		PRsMerged:         5,
-		Commits:           15,
+		Commits:           intPtr(15),
 		Additions:         250,
 		Deletions:         50,
 		FirstContribution: now,
@@ -300,8 +303,8 @@ func TestContributionJSONMarshal(t *testing.T) {
 		t.Errorf("PRsMerged = %d, want %d", decoded.PRsMerged, contrib.PRsMerged)
 	}
 
-	if decoded.Commits != contrib.Commits {
-		t.Errorf("Commits = %d, want %d", decoded.Commits, contrib.Commits)
+	if decoded.Commits == nil || *decoded.Commits != *contrib.Commits {
+		t.Errorf("Commits = %s, want %d", fmtInt(decoded.Commits), *contrib.Commits)
 	}
 
 	if decoded.Additions != contrib.Additions {
@@ -327,6 +330,36 @@ func TestErrorTypeImplementsError(t *testing.T) {
 	var _ error = &ErrAuthentication{}
 	var _ error = &ErrNotFound{}
 	var _ error = &ErrPartialResults{}
+	var _ error = &ErrUserNotFound{}
+	var _ error = &ErrSecondaryRateLimit{}
+	var _ error = &ErrForbidden{}
+	var _ error = &APIError{}
+}
+
+func TestErrorsUnwrap(t *testing.T) {
+	apiErr := &APIError{StatusCode: 403, Message: "Forbidden", RequestID: "ABCD:1234"}
+
+	for _, err := range []error{
+		&ErrRateLimited{Err: apiErr},
+		&ErrSecondaryRateLimit{Err: apiErr},
+		&ErrAuthentication{Err: apiErr},
+		&ErrForbidden{Err: apiErr},
+	} {
+		var got *APIError
+		if !errors.As(err, &got) || got.RequestID != "ABCD:1234" {
+			t.Errorf("errors.As(%T) should find the wrapped APIError", err)
+		}
+	}
+
+	forbidden := &ErrForbidden{Err: apiErr}
+	partial := &ErrPartialResults{Errors: []error{errors.New("other"), fmt.Errorf("fetching PR: %w", forbidden)}}
+	var got *ErrForbidden
+	if !errors.As(partial, &got) || got != forbidden {
+		t.Error("errors.As(ErrPartialResults) should find the errors it collected")
+	}
+	if !errors.Is(partial, forbidden) {
+		t.Error("errors.Is(ErrPartialResults) should find the errors it collected")
+	}
 }
 
 func TestStatsEmptyContributions(t *testing.T) {
@@ -369,3 +402,25 @@ func contains(s, substr string) bool {
 			return false
 		}())
 }
+
+func TestContributionJSONUnknownCommits(t *testing.T) {
+	data, err := json.Marshal(Contribution{Repo: "owner/repo", PRsMerged: 1})
+	if err != nil {
+		t.Fatalf("Failed to marshal Contribution: %v", err)
+	}
+	if !strings.Contains(string(data), `"commits":null`) {
+		t.Errorf("unknown commits should be null, got %s", data)
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+// fmtInt formats an optional count for test messages
+func fmtInt(n *int) string {
+	if n == nil {
+		return "nil"
+	}
+	return strconv.Itoa(*n)
+}